@@ -0,0 +1,18 @@
+package app
+
+import "slices"
+
+// IsAdmin tells whether the user with the email is one of the admins.
+func IsAdmin(admins []string, email string) bool {
+	return slices.Contains(admins, email)
+}
+
+// RequireAdmin answers 403 with err and reports false unless the user in
+// context is one of the admins.
+func RequireAdmin(c Context, admins []string, err error) bool {
+	if !IsAdmin(admins, c.GetString("email")) {
+		c.Forbidden(err)
+		return false
+	}
+	return true
+}
@@ -1,10 +1,14 @@
 package app
 
-import "net/http"
+import (
+	gcontext "context"
+	"net/http"
+)
 
 type MockAppContext struct {
 	Context
 	Params       map[string]string
+	Queries      map[string]string
 	Email        string
 	ResponseData interface{}
 	ResponseCode int
@@ -19,6 +23,14 @@ func (m *MockAppContext) Param(key string) string {
 	return m.Params[key]
 }
 
+func (m *MockAppContext) Ctx() gcontext.Context {
+	return gcontext.Background()
+}
+
+func (m *MockAppContext) Query(key string) string {
+	return m.Queries[key]
+}
+
 func (m *MockAppContext) OK(v interface{}) {
 	m.ResponseData = v
 	m.ResponseCode = http.StatusOK
@@ -33,3 +45,13 @@ func (m *MockAppContext) StoreError(err error) {
 	m.ResponseData = err
 	m.ResponseCode = 450
 }
+
+func (m *MockAppContext) Forbidden(err error) {
+	m.ResponseData = err
+	m.ResponseCode = http.StatusForbidden
+}
+
+func (m *MockAppContext) Conflict(err error) {
+	m.ResponseData = err
+	m.ResponseCode = http.StatusConflict
+}
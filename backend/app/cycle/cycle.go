@@ -12,12 +12,30 @@ const (
 	StatusApproved = "Approved"
 	StatusRunning  = "In Progress"
 	StatusDone     = "Done"
+	StatusRejected = "Rejected"
 )
 
 const (
-	StateReview = "Review"
+	OverduePastEndDate   = "pastEndDate"
+	OverduePendingReview = "pendingReview"
 )
 
+const (
+	AgreementProposed  = "Proposed"
+	AgreementCountered = "Countered"
+	AgreementAgreed    = "Agreed"
+)
+
+const (
+	StateReview   = "Review"
+	StateRevise   = "Revise"
+	StateApproved = "Approved"
+	StateRunning  = "In Progress"
+	StateDone     = "Done"
+)
+
+// Cycle is the legacy cycle model of the cycles collection. Every route
+// serves NewCycle now; Cycle is only read to migrate it into new_cycles.
 type Cycle struct {
 	ID                primitive.ObjectID  `json:"id,omitempty" bson:"_id,omitempty"`
 	SenderMail        string              `json:"senderMail" bson:"sender_mail" binding:"required"`
@@ -31,30 +49,6 @@ type Cycle struct {
 	Comment           string              `json:"comment" bson:"comment"`
 }
 
-type CycleSave struct {
-	ID                primitive.ObjectID  `json:"id,omitempty" bson:"_id,omitempty"`
-	SenderMail        string              `json:"senderMail" bson:"sender_mail" binding:"required"`
-	ReceiverMail      string              `json:"receiverMail" bson:"receiver_mail" binding:"required"`
-	StartDate         time.Time           `json:"startDate" bson:"start_date" binding:"required"`
-	EndDate           time.Time           `json:"endDate" bson:"end_date" binding:"required"`
-	QuantitativeSkill []QuantitativeSkill `json:"quantitativeSkill" bson:"quantitative_skill"`
-	IntuitiveSkill    []IntuitiveSkill    `json:"intuitiveSkill" bson:"intuitive_skill"`
-	Comment           string              `json:"comment" bson:"comment"`
-}
-
-type CycleDisplay struct {
-	ID                primitive.ObjectID         `json:"id" bson:"_id"`
-	SenderMail        string                     `json:"senderMail" bson:"sender_mail"`
-	ReceiverMail      string                     `json:"receiverMail" bson:"receiver_mail"`
-	StartDate         time.Time                  `json:"startDate" bson:"start_date"`
-	EndDate           time.Time                  `json:"endDate" bson:"end_date"`
-	QuantitativeSkill []QuantitativeSkillDisplay `json:"quantitativeSkill" bson:"quantitative_skill"`
-	IntuitiveSkill    []IntuitiveSkill           `json:"intuitiveSkill" bson:"intuitive_skill"`
-	Status            string                     `json:"status" bson:"status"`
-	State             string                     `json:"state" bson:"state"`
-	Comment           string                     `json:"comment" bson:"comment"`
-}
-
 type QuantitativeSkill struct {
 	ID            primitive.ObjectID `json:"id" bson:"id"`
 	PersonalScore int                `json:"personalScore" bson:"personal_score" binding:"required"`
@@ -64,28 +58,6 @@ type QuantitativeSkill struct {
 	Comment       string             `json:"comment" bson:"comment"`
 }
 
-type QuantitativeSkillDisplay struct {
-	ID            primitive.ObjectID `json:"id" bson:"id"`
-	Name          string             `json:"name" bson:"name"`
-	Description   string             `json:"description" bson:"description"`
-	Logo          string             `json:"logo" bson:"logo"`
-	PersonalScore int                `json:"personalScore" bson:"personal_score" binding:"required"`
-	GoalScore     int                `json:"goalScore" bson:"goal_score" binding:"required"`
-	LeadGoalScore int                `json:"leadGoalScore" bson:"lead_goal_score"`
-	FinalScore    int                `json:"finalScore" bson:"final_score"`
-	Comment       string             `json:"comment" bson:"comment"`
-}
-
-type CycleInput struct {
-	ReceiverMail string    `json:"receiverMail" bson:"receiver_mail" binding:"required"`
-	StartDate    time.Time `json:"startDate" bson:"start_date" binding:"required"`
-	EndDate      time.Time `json:"endDate" bson:"end_date" binding:"required"`
-	// QuantitiveSkill >= 1 skill
-	QuantitativeSkill []QuantitativeSkill `json:"quantitativeSkill" bson:"quantitative_skill" binding:"required"`
-	IntuitiveSkill    []IntuitiveSkill    `json:"intuitiveSkill" bson:"intuitive_skill"`
-	Comment           string              `json:"comment" bson:"comment"`
-}
-
 type IntuitiveSkill struct {
 	Name    string `json:"name" bson:"name" binding:"required"`
 	Status  string `json:"status" bson:"status"`
@@ -93,34 +65,6 @@ type IntuitiveSkill struct {
 	Comment string `json:"comment" bson:"comment"`
 }
 
-type QuantiativeSkillToUpdate struct {
-	ID         primitive.ObjectID `json:"id" bson:"id"`
-	FinalScore int                `json:"finalScore" bson:"final_score"`
-}
-
-type GetFinalScoreResponse struct {
-	Email             string                     `json:"email" binding:"required"`
-	QuantiativeSkills []QuantiativeSkillToUpdate `json:"quantiativeSkills"`
-}
-
-type CycleWithUserDetail struct {
-	ID                primitive.ObjectID         `json:"id" bson:"_id"`
-	UID               string                     `json:"sub" bson:"user_id"`
-	FirstName         string                     `json:"givenName" bson:"given_name"`
-	LastName          string                     `json:"familyName" bson:"family_name"`
-	JobRole           string                     `json:"jobRole" bson:"job_role"`
-	Level             string                     `json:"level" bson:"level"`
-	SenderMail        string                     `json:"senderMail" bson:"sender_mail"`
-	ReceiverMail      string                     `json:"receiverMail" bson:"receiver_mail"`
-	StartDate         time.Time                  `json:"startDate" bson:"start_date"`
-	EndDate           time.Time                  `json:"endDate" bson:"end_date"`
-	QuantitativeSkill []QuantitativeSkillDisplay `json:"quantitativeSkill" bson:"quantitative_skill"`
-	IntuitiveSkill    []IntuitiveSkill           `json:"intuitiveSkill" bson:"intuitive_skill"`
-	Status            string                     `json:"status" bson:"status"`
-	State             string                     `json:"state" bson:"state"`
-	Comment           string                     `json:"comment" bson:"comment"`
-}
-
 // new cycle
 type NewCycle struct {
 	ID             primitive.ObjectID `json:"id" bson:"_id,omitempty"`
@@ -129,9 +73,27 @@ type NewCycle struct {
 	StartDate      time.Time          `json:"startDate" bson:"startDate" binding:"required"`
 	EndDate        time.Time          `json:"endDate" bson:"endDate" binding:"required"`
 	Status         string             `json:"status" bson:"status" binding:"required"`
-	Comment        string             `json:"comment" bson:"comment"`
 	HardSkills     []HardSkill        `json:"hardSkills" bson:"hardSkills,omitempty"`
 	State          string             `json:"state" bson:"state"`
+	// PeriodID is the review period the cycle belongs to. Cycles from before
+	// periods existed get it when a period covering their start is added.
+	PeriodID primitive.ObjectID `json:"periodId" bson:"periodId,omitempty"`
+	// RolledFrom is the done cycle this one was rolled over from.
+	RolledFrom *primitive.ObjectID `json:"rolledFrom,omitempty" bson:"rolledFrom,omitempty"`
+	// ScoresAppliedAt is set once the mutual scores have been written to the
+	// ariser's profile.
+	ScoresAppliedAt *time.Time `json:"scoresAppliedAt,omitempty" bson:"scoresAppliedAt,omitempty"`
+	// SubmittedAt is when the ariser last sent the goals for review.
+	SubmittedAt *time.Time `json:"submittedAt,omitempty" bson:"submittedAt,omitempty"`
+	// DeletedAt is set while the cycle is in the trash. Deleted cycles are
+	// left out of every listing and purged once kept for long enough.
+	DeletedAt *time.Time `json:"deletedAt,omitempty" bson:"deletedAt,omitempty"`
+	DeletedBy string     `json:"deletedBy,omitempty" bson:"deletedBy,omitempty"`
+	// Overdue is set by the cycle monitor to why the cycle is late.
+	Overdue string `json:"overdue,omitempty" bson:"overdue,omitempty"`
+	// Version goes up on every change to the goals, scores, dates or status
+	// and is given out as the ETag of the cycle.
+	Version int `json:"version" bson:"version"`
 }
 
 type NewCycleDisplay struct {
@@ -141,7 +103,6 @@ type NewCycleDisplay struct {
 	StartDate      time.Time          `json:"startDate" bson:"startDate" `
 	EndDate        time.Time          `json:"endDate" bson:"endDate"`
 	Status         string             `json:"status" bson:"status"`
-	Comment        string             `json:"comment" bson:"comment"`
 	HardSkills     []HardSkillDisplay `json:"hardSkills,omitempty" bson:"hardSkills,omitempty"`
 	State          string             `json:"state" bson:"state"`
 }
@@ -157,13 +118,79 @@ type NewCycleWithUserDetail struct {
 	StartDate      time.Time          `json:"startDate" bson:"startDate" `
 	EndDate        time.Time          `json:"endDate" bson:"endDate"`
 	Status         string             `json:"status" bson:"status"`
-	Comment        string             `json:"comment" bson:"comment"`
 	HardSkills     []HardSkillDisplay `json:"hardSkills,omitempty" bson:"hardSkills,omitempty"`
 	State          string             `json:"state" bson:"state"`
 }
 
+// NewCycleInput opens a cycle in the given review period, or in the one open
+// now when PeriodID is empty. Dates left empty default to the period's.
+type NewCycleInput struct {
+	TeamLeaderMail string        `json:"teamLeaderMail" binding:"required"`
+	PeriodID       string        `json:"periodId"`
+	StartDate      time.Time     `json:"startDate"`
+	EndDate        time.Time     `json:"endDate"`
+	HardSkills     []GoalRequest `json:"hardSkills" binding:"dive"`
+}
+
+// BulkCycleInput picks the review period and dates like NewCycleInput.
+type BulkCycleInput struct {
+	PeriodID  string    `json:"periodId"`
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+}
+
+// BulkCycleResult tells what became of a member when opening the cycles of a
+// squad. Members who already have a cycle in the period are skipped.
+type BulkCycleResult struct {
+	AriserMail string `json:"ariserMail"`
+	CycleID    string `json:"cycleId,omitempty"`
+	Skipped    bool   `json:"skipped,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+type UpdateCycleRequest struct {
+	StartDate  time.Time     `json:"startDate" binding:"required"`
+	EndDate    time.Time     `json:"endDate" binding:"required"`
+	HardSkills []GoalRequest `json:"hardSkills" binding:"dive"`
+	Status     string        `json:"status"`
+}
+
+// GoalRequest is a new goal for a hard skill of the cycle. Scores are only
+// changed through the lead score and agreement steps.
+type GoalRequest struct {
+	Name      string `json:"name" binding:"required"`
+	GoalScore int    `json:"goalScore" binding:"min=0"`
+}
+
+type UpdateStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+}
+
+type LeadScoresRequest struct {
+	HardSkills []LeadScore `json:"hardSkills" binding:"required,dive"`
+}
+
+type LeadScore struct {
+	Name  string `json:"name" binding:"required"`
+	Score int    `json:"score" binding:"required,min=1"`
+}
+
+type AgreementRequest struct {
+	HardSkills []SkillAgreement `json:"hardSkills" binding:"required,dive"`
+}
+
+// SkillAgreement is the ariser's answer to a lead score: either accept it or
+// propose CounterScore instead.
+type SkillAgreement struct {
+	Name         string `json:"name" binding:"required"`
+	Accept       bool   `json:"accept"`
+	CounterScore int    `json:"counterScore"`
+}
+
+// UpdateGoalSkillsRequest holds the goals the ariser sets on the hard skills
+// of the cycle. Scores other than the goal are not the ariser's to send.
 type UpdateGoalSkillsRequest struct {
-	HardSkills []HardSkill `json:"hardSkills" bson:"hardSkills" binding:"required"`
+	HardSkills []GoalRequest `json:"hardSkills" binding:"required,dive"`
 }
 
 type HardSkill struct {
@@ -174,8 +201,12 @@ type HardSkill struct {
 	PersonalScore int                `json:"personalScore" bson:"personalScore" binding:"required"`
 	GoalScore     int                `json:"goalScore" bson:"goalScore" binding:"required"`
 	LeadScore     int                `json:"leadScore" bson:"leadScore"`
+	CounterScore  int                `json:"counterScore" bson:"counterScore"`
+	Agreement     string             `json:"agreement" bson:"agreement"`
 	MutualScore   int                `json:"mutualScore" bson:"mutualScore"`
-	Comment       string             `json:"comment" bson:"comment"`
+	// RubricVersion is the version of the skill levels the skill is measured
+	// against, the one in effect when the cycle started.
+	RubricVersion int `json:"rubricVersion,omitempty" bson:"rubricVersion,omitempty"`
 }
 type HardSkillDisplay struct {
 	ID            primitive.ObjectID `json:"id" bson:"id"`
@@ -184,6 +215,9 @@ type HardSkillDisplay struct {
 	SkillLevels   []SkillLevel       `json:"skillLevels" bson:"skillLevels" `
 	PersonalScore int                `json:"personalScore" bson:"personalScore" `
 	GoalScore     int                `json:"goalScore" bson:"goalScore" `
+	LeadScore     int                `json:"leadScore" bson:"leadScore"`
+	MutualScore   int                `json:"mutualScore" bson:"mutualScore"`
+	RubricVersion int                `json:"rubricVersion,omitempty" bson:"rubricVersion,omitempty"`
 }
 
 type SkillLevel struct {
@@ -0,0 +1,186 @@
+package cycle
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/squad"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/user"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SquadMembers is the part of the squad storage used to find who a team lead
+// opens cycles for.
+type SquadMembers interface {
+	GetOneByID(id string) (*squad.Squad, error)
+	GetAllBySquadId(ctx context.Context, squadId primitive.ObjectID) ([]user.User, error)
+}
+
+type BulkStorage interface {
+	DraftStorage
+	GetArisersWithCycle(ctx context.Context, periodID primitive.ObjectID) ([]string, error)
+}
+
+type bulkHandler struct {
+	storage BulkStorage
+	catalog HardSkillCatalog
+	squads  SquadMembers
+}
+
+func NewBulkHandler(st BulkStorage, catalog HardSkillCatalog, squads SquadMembers) *bulkHandler {
+	return &bulkHandler{
+		storage: st,
+		catalog: catalog,
+		squads:  squads,
+	}
+}
+
+var invalidSquadIdError = cycleHandlerError{message: "invalid squad id"}
+var notSquadTeamLeadError = cycleHandlerError{message: "only the team lead of this squad can open its cycles"}
+var squadMemberNotFoundError = cycleHandlerError{message: "squad member not found"}
+
+// InsertForSquad godoc
+//
+//	@summary		InsertForSquad
+//	@description	Open a cycle for every member of a squad in the same review period and dates. Members who already have a cycle in the period are skipped, so it can be run again for new members. A member whose pre-filled goals break the goal policy of their job role and level gets an error instead of a cycle.
+//	@tags			cycle
+//	@id				InsertForSquad
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			squadID	path		string					true	"Squad ID"
+//	@param			reqJson	body		BulkCycleInput			true	"Cycle period"
+//	@response		200		{array}		cycle.BulkCycleResult	"Result of each member"
+//	@response		400		{object}	app.Response			"Bad Request"
+//	@response		403		{object}	app.Response			"Not the squad team lead"
+//	@response		404		{object}	app.Response			"Squad, members or review period not found"
+//	@response		409		{object}	app.Response			"No review period is open"
+//	@response		450		{object}	app.Response			"Store Error"
+//	@response		500		{object}	app.Response			"Internal Server Error"
+//	@router			/squads/{squadID}/cycles [post]
+func (h *bulkHandler) InsertForSquad(c app.Context) {
+	email := c.GetString("email")
+	sqId, err := primitive.ObjectIDFromHex(c.Param("squadID"))
+	if err != nil {
+		c.BadRequest(invalidSquadIdError)
+		return
+	}
+
+	var input BulkCycleInput
+	if err := c.Bind(&input); err != nil {
+		c.BadRequest(invalidInsertOneInputError)
+		return
+	}
+	period, err := findPeriod(c.Ctx(), h.storage, input.PeriodID)
+	if err != nil {
+		periodError(c, err)
+		return
+	}
+	start, end, err := period.CycleDates(input.StartDate, input.EndDate)
+	if err != nil {
+		c.BadRequest(err)
+		return
+	}
+
+	sq, err := h.squads.GetOneByID(sqId.Hex())
+	if err != nil {
+		if errors.As(err, &squad.SquadStorageError{}) {
+			c.NotFound(err)
+			return
+		}
+		c.InternalServerError(err)
+		return
+	}
+	if sq.TeamleadMail != email {
+		c.Forbidden(notSquadTeamLeadError)
+		return
+	}
+
+	members, err := h.squads.GetAllBySquadId(c.Ctx(), sqId)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.NotFound(squadMemberNotFoundError)
+			return
+		}
+		c.InternalServerError(err)
+		return
+	}
+
+	started, err := h.storage.GetArisersWithCycle(c.Ctx(), period.ID)
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	results := []BulkCycleResult{}
+	for i := range members {
+		if members[i].Email == email {
+			continue
+		}
+		if slices.Contains(started, members[i].Email) {
+			results = append(results, BulkCycleResult{AriserMail: members[i].Email, Skipped: true})
+			continue
+		}
+		results = append(results, h.insertForMember(c.Ctx(), &members[i], email, period.ID, start, end))
+	}
+
+	c.OK(results)
+}
+
+func (h *bulkHandler) insertForMember(ctx context.Context, member *user.User, leadMail string, periodID primitive.ObjectID, start time.Time, end time.Time) BulkCycleResult {
+	result := BulkCycleResult{AriserMail: member.Email}
+
+	catalog, err := h.catalog.GetByRole(ctx, member.JobRole)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	draft := NewDraft(member, catalog)
+	draft.TeamLeaderMail = leadMail
+	draft.PeriodID = periodID
+	draft.StartDate = start
+	draft.EndDate = end
+	if draft.HardSkills, err = pinCatalogRubrics(ctx, h.catalog, draft.HardSkills, start); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	policy, err := h.storage.GetGoalPolicy(ctx, member.JobRole, member.Level)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if violations := policy.Check(draft.HardSkills); len(violations) > 0 {
+		result.Error = policyErrorText(GoalPolicyError{Violations: violations})
+		return result
+	}
+
+	res, err := h.storage.InsertNew(ctx, *draft, leadMail)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.CycleID = res.ID.Hex()
+	return result
+}
+
+// policyErrorText puts the violations of a GoalPolicyError after its message,
+// as a member result has no room for the list itself.
+func policyErrorText(err GoalPolicyError) string {
+	lines := []string{}
+	for _, v := range err.Violations {
+		if v.Skill == "" {
+			lines = append(lines, v.Message)
+			continue
+		}
+		lines = append(lines, v.Skill+": "+v.Message)
+	}
+	return err.Error() + ": " + strings.Join(lines, "; ")
+}
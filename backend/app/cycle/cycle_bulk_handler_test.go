@@ -0,0 +1,189 @@
+package cycle
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/squad"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/user"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+type mockSquadMembers struct {
+	squad     *squad.Squad
+	members   []user.User
+	squadErr  error
+	memberErr error
+}
+
+func (m *mockSquadMembers) GetOneByID(id string) (*squad.Squad, error) {
+	if m.squadErr != nil {
+		return nil, m.squadErr
+	}
+	return m.squad, nil
+}
+
+func (m *mockSquadMembers) GetAllBySquadId(ctx context.Context, squadId primitive.ObjectID) ([]user.User, error) {
+	if m.memberErr != nil {
+		return nil, m.memberErr
+	}
+	return m.members, nil
+}
+
+func TestInsertForSquad(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	squadId := mockObjectId(7)
+	reqBody := `{"startDate":"2024-01-01T00:00:00Z","endDate":"2024-06-30T00:00:00Z"}`
+
+	testCases := []struct {
+		name             string
+		email            string
+		squadID          string
+		squads           *mockSquadMembers
+		failFor          string
+		started          []string
+		policy           *GoalPolicy
+		noPeriod         bool
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:    "should return 200 and result of each member except the lead",
+			email:   "lead@arise.tech",
+			squadID: squadId.hexId,
+			squads: &mockSquadMembers{
+				squad: &squad.Squad{Id: squadId.objectId, TeamleadMail: "lead@arise.tech"},
+				members: []user.User{
+					{Email: "lead@arise.tech", JobRole: "frontend"},
+					{Email: "a@arise.tech", JobRole: "frontend"},
+					{Email: "b@arise.tech", JobRole: "frontend"},
+				},
+			},
+			failFor:        "b@arise.tech",
+			expectedStatus: http.StatusOK,
+			expectedResponse: `{
+				"status": "success",
+				"message": "",
+				"data": [
+					{"ariserMail": "a@arise.tech", "cycleId": "000000000000000000000001"},
+					{"ariserMail": "b@arise.tech", "error": "insert error"}
+				]
+			}`,
+		},
+		{
+			name:    "should return 200 and skip members who already have a cycle in the period",
+			email:   "lead@arise.tech",
+			squadID: squadId.hexId,
+			squads: &mockSquadMembers{
+				squad: &squad.Squad{Id: squadId.objectId, TeamleadMail: "lead@arise.tech"},
+				members: []user.User{
+					{Email: "a@arise.tech", JobRole: "frontend"},
+					{Email: "c@arise.tech", JobRole: "frontend"},
+				},
+			},
+			started:        []string{"a@arise.tech"},
+			expectedStatus: http.StatusOK,
+			expectedResponse: `{
+				"status": "success",
+				"message": "",
+				"data": [
+					{"ariserMail": "a@arise.tech", "skipped": true},
+					{"ariserMail": "c@arise.tech", "cycleId": "000000000000000000000001"}
+				]
+			}`,
+		},
+		{
+			name:    "should return 200 and an error for members whose goals break the goal policy",
+			email:   "lead@arise.tech",
+			squadID: squadId.hexId,
+			squads: &mockSquadMembers{
+				squad:   &squad.Squad{Id: squadId.objectId, TeamleadMail: "lead@arise.tech"},
+				members: []user.User{{Email: "a@arise.tech", JobRole: "frontend"}},
+			},
+			policy:         &GoalPolicy{MaxLevelJump: 1, MandatorySkills: []string{"Go"}},
+			expectedStatus: http.StatusOK,
+			expectedResponse: `{
+				"status": "success",
+				"message": "",
+				"data": [
+					{"ariserMail": "a@arise.tech", "error": "goals break the goal-setting policy: Go: skill is mandatory for this job role and level"}
+				]
+			}`,
+		},
+		{
+			name:    "should return 409 when no period is open",
+			email:   "lead@arise.tech",
+			squadID: squadId.hexId,
+			squads: &mockSquadMembers{
+				squad: &squad.Squad{Id: squadId.objectId, TeamleadMail: "lead@arise.tech"},
+			},
+			noPeriod:         true,
+			expectedStatus:   http.StatusConflict,
+			expectedResponse: `{"status": "error", "message": "no review period is open, pick one with periodId"}`,
+		},
+		{
+			name:    "should return 403 when user is not the squad team lead",
+			email:   "a@arise.tech",
+			squadID: squadId.hexId,
+			squads: &mockSquadMembers{
+				squad: &squad.Squad{Id: squadId.objectId, TeamleadMail: "lead@arise.tech"},
+			},
+			expectedStatus:   http.StatusForbidden,
+			expectedResponse: `{"status": "error", "message": "only the team lead of this squad can open its cycles"}`,
+		},
+		{
+			name:             "should return 400 when squad id is invalid",
+			email:            "lead@arise.tech",
+			squadID:          "not-valid",
+			squads:           &mockSquadMembers{},
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status": "error", "message": "invalid squad id"}`,
+		},
+		{
+			name:    "should return 404 when squad has no member",
+			email:   "lead@arise.tech",
+			squadID: squadId.hexId,
+			squads: &mockSquadMembers{
+				squad:     &squad.Squad{Id: squadId.objectId, TeamleadMail: "lead@arise.tech"},
+				memberErr: mongo.ErrNoDocuments,
+			},
+			expectedStatus:   http.StatusNotFound,
+			expectedResponse: `{"status": "error", "message": "squad member not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, catalog := draftFixtures()
+			st := &mockDraftStorage{failFor: tc.failFor, started: tc.started, period: h1Period(), policy: tc.policy}
+			if tc.noPeriod {
+				st.period = nil
+			}
+			handler := NewBulkHandler(st, &mockHardSkillCatalog{skills: catalog}, tc.squads)
+
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", tc.email)
+			})
+			engine.POST("/squads/:squadID/cycles", app.NewGinHandler(handler.InsertForSquad, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/squads/"+tc.squadID+"/cycles", strings.NewReader(reqBody))
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+			if tc.policy != nil {
+				assert.Nil(t, st.inserted)
+			}
+		})
+	}
+}
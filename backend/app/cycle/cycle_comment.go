@@ -0,0 +1,46 @@
+package cycle
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CycleComment is one comment in the thread of a cycle, or of one hard skill
+// of it when Skill is set. A reply points at the first comment of its thread
+// with ParentID; only that first comment can be resolved.
+type CycleComment struct {
+	ID         primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	CycleID    primitive.ObjectID  `json:"cycleId" bson:"cycleId"`
+	Skill      string              `json:"skill,omitempty" bson:"skill,omitempty"`
+	ParentID   *primitive.ObjectID `json:"parentId,omitempty" bson:"parentId,omitempty"`
+	Author     string              `json:"author" bson:"author"`
+	Body       string              `json:"body" bson:"body"`
+	CreatedAt  time.Time           `json:"createdAt" bson:"createdAt"`
+	EditedAt   *time.Time          `json:"editedAt,omitempty" bson:"editedAt,omitempty"`
+	History    []CommentRevision   `json:"history" bson:"history"`
+	Resolved   bool                `json:"resolved" bson:"resolved"`
+	ResolvedBy string              `json:"resolvedBy,omitempty" bson:"resolvedBy,omitempty"`
+	ResolvedAt *time.Time          `json:"resolvedAt,omitempty" bson:"resolvedAt,omitempty"`
+}
+
+// CommentRevision is an earlier body of an edited comment and when it was
+// written.
+type CommentRevision struct {
+	Body string    `json:"body" bson:"body"`
+	At   time.Time `json:"at" bson:"at"`
+}
+
+type PostCommentRequest struct {
+	Body     string `json:"body" binding:"required"`
+	Skill    string `json:"skill"`
+	ParentID string `json:"parentId"`
+}
+
+type EditCommentRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+type ResolveCommentRequest struct {
+	Resolved bool `json:"resolved"`
+}
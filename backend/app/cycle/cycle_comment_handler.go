@@ -0,0 +1,270 @@
+package cycle
+
+import (
+	"context"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CommentStorage interface {
+	GetNewByID(id string) (*NewCycle, error)
+	InsertComment(ctx context.Context, comment CycleComment) (*CycleComment, error)
+	GetComments(ctx context.Context, cycleID string, skill *string) ([]CycleComment, error)
+	GetCommentByID(ctx context.Context, id string) (*CycleComment, error)
+	EditComment(ctx context.Context, id primitive.ObjectID, author string, body string) (*CycleComment, error)
+	ResolveComment(ctx context.Context, id primitive.ObjectID, email string, resolved bool) (*CycleComment, error)
+}
+
+type commentHandler struct {
+	storage CommentStorage
+}
+
+func NewCommentHandler(st CommentStorage) *commentHandler {
+	return &commentHandler{
+		storage: st,
+	}
+}
+
+var notCycleMemberError = cycleHandlerError{message: "only the ariser and team lead of this cycle can comment on it"}
+var notCommentAuthorError = cycleHandlerError{message: "only the author can edit a comment"}
+var invalidParentCommentError = cycleHandlerError{message: "a reply must answer the first comment of a thread on the same skill"}
+var resolveReplyError = cycleHandlerError{message: "only the first comment of a thread can be resolved"}
+
+// Comments godoc
+//
+//	@summary		Comments
+//	@description	List the comment threads of a cycle, oldest first. Use skill to only get the threads of one hard skill.
+//	@tags			cycle
+//	@id				Comments
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			id		path		string				true	"Cycle ID"
+//	@param			skill	query		string				false	"Hard skill name"
+//	@response		200		{array}		cycle.CycleComment	"OK"
+//	@response		400		{object}	app.Response		"Bad Request"
+//	@response		403		{object}	app.Response		"Not a member of the cycle"
+//	@response		404		{object}	app.Response		"Cycle not found"
+//	@response		500		{object}	app.Response		"Internal Server Error"
+//	@router			/cycles/{id}/comments [get]
+func (h *commentHandler) Comments(c app.Context) {
+	id := c.Param("id")
+	if _, ok := h.member(c, id); !ok {
+		return
+	}
+
+	var skill *string
+	if s := c.Query("skill"); s != "" {
+		skill = &s
+	}
+
+	comments, err := h.storage.GetComments(c.Ctx(), id, skill)
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(comments)
+}
+
+// PostComment godoc
+//
+//	@summary		PostComment
+//	@description	Start a comment thread on a cycle or one of its hard skills, or reply to one with parentId
+//	@tags			cycle
+//	@id				PostComment
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			id		path		string				true	"Cycle ID"
+//	@param			reqJson	body		PostCommentRequest	true	"Comment"
+//	@response		200		{object}	cycle.CycleComment	"OK"
+//	@response		400		{object}	app.Response		"Bad Request"
+//	@response		403		{object}	app.Response		"Not a member of the cycle"
+//	@response		404		{object}	app.Response		"Cycle not found"
+//	@response		500		{object}	app.Response		"Internal Server Error"
+//	@router			/cycles/{id}/comments [post]
+func (h *commentHandler) PostComment(c app.Context) {
+	id := c.Param("id")
+	var req PostCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.BadRequest(invalidRequestError)
+		return
+	}
+
+	cy, ok := h.member(c, id)
+	if !ok {
+		return
+	}
+
+	if req.Skill != "" {
+		if _, err := findSkill(cy.HardSkills, req.Skill, 0); err != nil {
+			c.BadRequest(err)
+			return
+		}
+	}
+
+	comment := CycleComment{
+		CycleID:   cy.ID,
+		Skill:     req.Skill,
+		Author:    c.GetString("email"),
+		Body:      req.Body,
+		CreatedAt: time.Now(),
+	}
+	if req.ParentID != "" {
+		parent, err := h.storage.GetCommentByID(c.Ctx(), req.ParentID)
+		if err != nil || parent.CycleID != cy.ID || parent.Skill != req.Skill || parent.ParentID != nil {
+			c.BadRequest(invalidParentCommentError)
+			return
+		}
+		comment.ParentID = &parent.ID
+	}
+
+	res, err := h.storage.InsertComment(c.Ctx(), comment)
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(res)
+}
+
+// EditComment godoc
+//
+//	@summary		EditComment
+//	@description	Change the body of your own comment. The earlier body is kept in its history.
+//	@tags			cycle
+//	@id				EditComment
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			id			path		string				true	"Cycle ID"
+//	@param			commentID	path		string				true	"Comment ID"
+//	@param			reqJson		body		EditCommentRequest	true	"New body"
+//	@response		200			{object}	cycle.CycleComment	"OK"
+//	@response		400			{object}	app.Response		"Bad Request"
+//	@response		403			{object}	app.Response		"Not the author"
+//	@response		404			{object}	app.Response		"Cycle or comment not found"
+//	@response		500			{object}	app.Response		"Internal Server Error"
+//	@router			/cycles/{id}/comments/{commentID} [put]
+func (h *commentHandler) EditComment(c app.Context) {
+	var req EditCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.BadRequest(invalidRequestError)
+		return
+	}
+
+	comment, ok := h.comment(c)
+	if !ok {
+		return
+	}
+	email := c.GetString("email")
+	if comment.Author != email {
+		c.Forbidden(notCommentAuthorError)
+		return
+	}
+
+	res, err := h.storage.EditComment(c.Ctx(), comment.ID, email, req.Body)
+	if err != nil {
+		if err == commentNotFoundError {
+			c.NotFound(err)
+			return
+		}
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(res)
+}
+
+// ResolveComment godoc
+//
+//	@summary		ResolveComment
+//	@description	Mark a comment thread as resolved, or reopen it
+//	@tags			cycle
+//	@id				ResolveComment
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			id			path		string					true	"Cycle ID"
+//	@param			commentID	path		string					true	"Comment ID"
+//	@param			reqJson		body		ResolveCommentRequest	true	"Resolved or not"
+//	@response		200			{object}	cycle.CycleComment		"OK"
+//	@response		400			{object}	app.Response			"Bad Request"
+//	@response		403			{object}	app.Response			"Not a member of the cycle"
+//	@response		404			{object}	app.Response			"Cycle or comment not found"
+//	@response		500			{object}	app.Response			"Internal Server Error"
+//	@router			/cycles/{id}/comments/{commentID}/resolve [put]
+func (h *commentHandler) ResolveComment(c app.Context) {
+	var req ResolveCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.BadRequest(invalidRequestError)
+		return
+	}
+
+	comment, ok := h.comment(c)
+	if !ok {
+		return
+	}
+	if comment.ParentID != nil {
+		c.BadRequest(resolveReplyError)
+		return
+	}
+
+	res, err := h.storage.ResolveComment(c.Ctx(), comment.ID, c.GetString("email"), req.Resolved)
+	if err != nil {
+		if err == commentNotFoundError {
+			c.NotFound(err)
+			return
+		}
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(res)
+}
+
+// member loads the cycle and checks the caller is its ariser or team lead.
+// It writes the error response itself and reports whether to go on.
+func (h *commentHandler) member(c app.Context, id string) (*NewCycle, bool) {
+	cy, err := h.storage.GetNewByID(id)
+	if err != nil {
+		if err == cycleNotFoundError {
+			c.NotFound(err)
+			return nil, false
+		}
+		c.BadRequest(err)
+		return nil, false
+	}
+	if ActorOf(cy, c.GetString("email")) == "" {
+		c.Forbidden(notCycleMemberError)
+		return nil, false
+	}
+	return cy, true
+}
+
+// comment loads the comment of the path, checking the caller is a member of
+// its cycle.
+func (h *commentHandler) comment(c app.Context) (*CycleComment, bool) {
+	cy, ok := h.member(c, c.Param("id"))
+	if !ok {
+		return nil, false
+	}
+
+	comment, err := h.storage.GetCommentByID(c.Ctx(), c.Param("commentID"))
+	if err != nil {
+		if err == commentNotFoundError {
+			c.NotFound(err)
+			return nil, false
+		}
+		c.BadRequest(err)
+		return nil, false
+	}
+	if comment.CycleID != cy.ID {
+		c.NotFound(commentNotFoundError)
+		return nil, false
+	}
+	return comment, true
+}
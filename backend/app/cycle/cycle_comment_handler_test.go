@@ -0,0 +1,251 @@
+package cycle
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+type mockCommentStorage struct {
+	cycle    *NewCycle
+	comments map[string]*CycleComment
+	inserted *CycleComment
+	edited   string
+	resolved *bool
+}
+
+func (m *mockCommentStorage) GetNewByID(id string) (*NewCycle, error) {
+	if m.cycle == nil {
+		return nil, cycleNotFoundError
+	}
+	return m.cycle, nil
+}
+
+func (m *mockCommentStorage) InsertComment(ctx context.Context, comment CycleComment) (*CycleComment, error) {
+	m.inserted = &comment
+	return &comment, nil
+}
+
+func (m *mockCommentStorage) GetComments(ctx context.Context, cycleID string, skill *string) ([]CycleComment, error) {
+	return []CycleComment{}, nil
+}
+
+func (m *mockCommentStorage) GetCommentByID(ctx context.Context, id string) (*CycleComment, error) {
+	comment, found := m.comments[id]
+	if !found {
+		return nil, commentNotFoundError
+	}
+	return comment, nil
+}
+
+func (m *mockCommentStorage) EditComment(ctx context.Context, id primitive.ObjectID, author string, body string) (*CycleComment, error) {
+	m.edited = body
+	comment := *m.comments[id.Hex()]
+	comment.Body = body
+	return &comment, nil
+}
+
+func (m *mockCommentStorage) ResolveComment(ctx context.Context, id primitive.ObjectID, email string, resolved bool) (*CycleComment, error) {
+	m.resolved = &resolved
+	comment := *m.comments[id.Hex()]
+	comment.Resolved = resolved
+	return &comment, nil
+}
+
+func TestCommentHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cycleID := mockObjectId(1)
+	otherCycleID := mockObjectId(2)
+	thread := mockObjectId(10)
+	reply := mockObjectId(11)
+	foreign := mockObjectId(12)
+
+	newStorage := func() *mockCommentStorage {
+		return &mockCommentStorage{
+			cycle: &NewCycle{
+				ID:             cycleID.objectId,
+				TeamLeaderMail: "lead@arise.tech",
+				AriserMail:     "ariser@arise.tech",
+				HardSkills:     []HardSkill{{Name: "CSS"}},
+			},
+			comments: map[string]*CycleComment{
+				thread.hexId:  {ID: thread.objectId, CycleID: cycleID.objectId, Skill: "CSS", Author: "ariser@arise.tech", Body: "I want to reach 4"},
+				reply.hexId:   {ID: reply.objectId, CycleID: cycleID.objectId, Skill: "CSS", ParentID: &thread.objectId, Author: "lead@arise.tech", Body: "Let's aim for 3"},
+				foreign.hexId: {ID: foreign.objectId, CycleID: otherCycleID.objectId, Author: "lead@arise.tech", Body: "elsewhere"},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		email          string
+		reqBody        string
+		notFound       bool
+		expectedStatus int
+		check          func(t *testing.T, st *mockCommentStorage)
+	}{
+		{
+			name:           "should return 200 when listing comments",
+			method:         http.MethodGet,
+			path:           "/comments?skill=CSS",
+			email:          "lead@arise.tech",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "should return 200 and keep author when posting on a skill",
+			method:         http.MethodPost,
+			path:           "/comments",
+			email:          "lead@arise.tech",
+			reqBody:        `{"body":"Good progress","skill":"CSS"}`,
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, st *mockCommentStorage) {
+				assert.Equal(t, "lead@arise.tech", st.inserted.Author)
+				assert.Equal(t, "CSS", st.inserted.Skill)
+				assert.Equal(t, cycleID.objectId, st.inserted.CycleID)
+				assert.Nil(t, st.inserted.ParentID)
+			},
+		},
+		{
+			name:           "should return 200 when replying to a thread",
+			method:         http.MethodPost,
+			path:           "/comments",
+			email:          "lead@arise.tech",
+			reqBody:        `{"body":"Agreed","skill":"CSS","parentId":"` + thread.hexId + `"}`,
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, st *mockCommentStorage) {
+				assert.Equal(t, &thread.objectId, st.inserted.ParentID)
+			},
+		},
+		{
+			name:           "should return 400 when replying to a reply",
+			method:         http.MethodPost,
+			path:           "/comments",
+			email:          "ariser@arise.tech",
+			reqBody:        `{"body":"Ok","skill":"CSS","parentId":"` + reply.hexId + `"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should return 400 when skill is not in the cycle",
+			method:         http.MethodPost,
+			path:           "/comments",
+			email:          "ariser@arise.tech",
+			reqBody:        `{"body":"Hi","skill":"Go"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should return 400 when body is missing",
+			method:         http.MethodPost,
+			path:           "/comments",
+			email:          "ariser@arise.tech",
+			reqBody:        `{"skill":"CSS"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should return 403 when posting on someone else's cycle",
+			method:         http.MethodPost,
+			path:           "/comments",
+			email:          "stranger@arise.tech",
+			reqBody:        `{"body":"Hi"}`,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "should return 404 when cycle is not found",
+			method:         http.MethodGet,
+			path:           "/comments",
+			email:          "lead@arise.tech",
+			notFound:       true,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "should return 200 when author edits a comment",
+			method:         http.MethodPut,
+			path:           "/comments/" + thread.hexId,
+			email:          "ariser@arise.tech",
+			reqBody:        `{"body":"I want to reach 5"}`,
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, st *mockCommentStorage) {
+				assert.Equal(t, "I want to reach 5", st.edited)
+			},
+		},
+		{
+			name:           "should return 403 when editing someone else's comment",
+			method:         http.MethodPut,
+			path:           "/comments/" + thread.hexId,
+			email:          "lead@arise.tech",
+			reqBody:        `{"body":"Changed"}`,
+			expectedStatus: http.StatusForbidden,
+			check: func(t *testing.T, st *mockCommentStorage) {
+				assert.Empty(t, st.edited)
+			},
+		},
+		{
+			name:           "should return 404 when comment belongs to another cycle",
+			method:         http.MethodPut,
+			path:           "/comments/" + foreign.hexId,
+			email:          "lead@arise.tech",
+			reqBody:        `{"body":"Changed"}`,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "should return 200 when resolving a thread",
+			method:         http.MethodPut,
+			path:           "/comments/" + thread.hexId + "/resolve",
+			email:          "lead@arise.tech",
+			reqBody:        `{"resolved":true}`,
+			expectedStatus: http.StatusOK,
+			check: func(t *testing.T, st *mockCommentStorage) {
+				assert.Equal(t, true, *st.resolved)
+			},
+		},
+		{
+			name:           "should return 400 when resolving a reply",
+			method:         http.MethodPut,
+			path:           "/comments/" + reply.hexId + "/resolve",
+			email:          "lead@arise.tech",
+			reqBody:        `{"resolved":true}`,
+			expectedStatus: http.StatusBadRequest,
+			check: func(t *testing.T, st *mockCommentStorage) {
+				assert.Nil(t, st.resolved)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := newStorage()
+			if tc.notFound {
+				st.cycle = nil
+			}
+			handler := NewCommentHandler(st)
+
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", tc.email)
+			})
+			engine.GET("/cycles/:id/comments", app.NewGinHandler(handler.Comments, zap.NewNop()))
+			engine.POST("/cycles/:id/comments", app.NewGinHandler(handler.PostComment, zap.NewNop()))
+			engine.PUT("/cycles/:id/comments/:commentID", app.NewGinHandler(handler.EditComment, zap.NewNop()))
+			engine.PUT("/cycles/:id/comments/:commentID/resolve", app.NewGinHandler(handler.ResolveComment, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(tc.method, "/cycles/"+cycleID.hexId+tc.path, strings.NewReader(tc.reqBody))
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.check != nil {
+				tc.check(t, st)
+			}
+		})
+	}
+}
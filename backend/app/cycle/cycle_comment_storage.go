@@ -0,0 +1,200 @@
+package cycle
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const cycleCommentCollection = "cycle_comments"
+
+var commentNotFoundError = CycleStorageError{message: "comment not found"}
+
+// InsertComment adds a comment to a cycle and records it in the history of
+// the cycle in the same transaction.
+func (s *storage) InsertComment(ctx context.Context, comment CycleComment) (*CycleComment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if comment.History == nil {
+		comment.History = []CommentRevision{}
+	}
+	err := s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		res, err := s.db.Collection(cycleCommentCollection).InsertOne(sc, comment)
+		if err != nil {
+			return err
+		}
+		comment.ID = res.InsertedID.(primitive.ObjectID)
+		return s.recordCommentEvent(sc, comment.Author, nil, &comment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// GetComments lists the comments of a cycle, oldest first. A nil skill lists
+// every thread; an empty one only the threads on the cycle itself.
+func (s *storage) GetComments(ctx context.Context, cycleID string, skill *string) ([]CycleComment, error) {
+	objId, err := primitive.ObjectIDFromHex(cycleID)
+	if err != nil {
+		return nil, invalidRequestError
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"cycleId": objId}
+	if skill != nil {
+		if *skill == "" {
+			filter["skill"] = bson.M{"$exists": false}
+		} else {
+			filter["skill"] = *skill
+		}
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := s.db.Collection(cycleCommentCollection).Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := []CycleComment{}
+	if err := cursor.All(ctx, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (s *storage) GetCommentByID(ctx context.Context, id string) (*CycleComment, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, invalidRequestError
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var comment CycleComment
+	if err := s.db.Collection(cycleCommentCollection).FindOne(ctx, bson.M{"_id": objId}).Decode(&comment); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, commentNotFoundError
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// EditComment replaces the body of a comment of the given author and keeps
+// the replaced body in its history, in one atomic update. The edit and its
+// event are written in one transaction.
+func (s *storage) EditComment(ctx context.Context, id primitive.ObjectID, author string, body string) (*CycleComment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	update := mongo.Pipeline{{{Key: "$set", Value: bson.M{
+		"history": bson.M{"$concatArrays": bson.A{
+			bson.M{"$ifNull": bson.A{"$history", bson.A{}}},
+			bson.A{bson.M{"body": "$body", "at": bson.M{"$ifNull": bson.A{"$editedAt", "$createdAt"}}}},
+		}},
+		"body":     body,
+		"editedAt": now,
+	}}}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var after CycleComment
+	err := s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		var before CycleComment
+		err := s.db.Collection(cycleCommentCollection).FindOneAndUpdate(sc, bson.M{"_id": id, "author": author}, update, opts).Decode(&before)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				return commentNotFoundError
+			}
+			return err
+		}
+
+		after = before
+		after.History = append(after.History, CommentRevision{Body: before.Body, At: revisedAt(&before)})
+		after.Body = body
+		after.EditedAt = &now
+		return s.recordCommentEvent(sc, author, &before, &after)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &after, nil
+}
+
+func (s *storage) ResolveComment(ctx context.Context, id primitive.ObjectID, email string, resolved bool) (*CycleComment, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	update := bson.M{"$set": bson.M{"resolved": true, "resolvedBy": email, "resolvedAt": now}}
+	if !resolved {
+		update = bson.M{
+			"$set":   bson.M{"resolved": false},
+			"$unset": bson.M{"resolvedBy": "", "resolvedAt": ""},
+		}
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var after CycleComment
+	err := s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		var before CycleComment
+		if err := s.db.Collection(cycleCommentCollection).FindOneAndUpdate(sc, bson.M{"_id": id}, update, opts).Decode(&before); err != nil {
+			if err == mongo.ErrNoDocuments {
+				return commentNotFoundError
+			}
+			return err
+		}
+
+		after = before
+		after.Resolved = resolved
+		after.ResolvedBy = ""
+		after.ResolvedAt = nil
+		if resolved {
+			after.ResolvedBy = email
+			after.ResolvedAt = &now
+		}
+		return s.recordCommentEvent(sc, email, &before, &after)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &after, nil
+}
+
+// recordCommentEvent appends a comment change to the history of its cycle.
+// before is nil for a new comment. It must run inside the transaction of the
+// change.
+func (s *storage) recordCommentEvent(sc mongo.SessionContext, actor string, before *CycleComment, after *CycleComment) error {
+	field := "comments." + after.ID.Hex()
+	var b any
+	if before != nil {
+		b = commentSummary(before)
+	}
+
+	event := CycleEvent{
+		CycleID: after.CycleID,
+		Type:    EventCommented,
+		Actor:   actor,
+		At:      time.Now(),
+		Changes: []FieldChange{{Field: field, Before: b, After: commentSummary(after)}},
+	}
+	_, err := s.db.Collection(cycleEventCollection).InsertOne(sc, event)
+	return err
+}
+
+func commentSummary(c *CycleComment) bson.M {
+	return bson.M{"skill": c.Skill, "body": c.Body, "resolved": c.Resolved}
+}
+
+func revisedAt(c *CycleComment) time.Time {
+	if c.EditedAt != nil {
+		return *c.EditedAt
+	}
+	return c.CreatedAt
+}
@@ -0,0 +1,151 @@
+package cycle
+
+import (
+	"context"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+)
+
+type DeletionStorage interface {
+	GetNewByID(id string) (*NewCycle, error)
+	GetDeletedByID(ctx context.Context, id string) (*NewCycle, error)
+	GetDeletedCycles(ctx context.Context, page PageRequest) (*Page[*NewCycle], error)
+	SoftDeleteCycle(ctx context.Context, id string, email string) (*NewCycle, error)
+	RestoreCycle(ctx context.Context, id string, email string) (*NewCycle, error)
+}
+
+type deletionHandler struct {
+	storage DeletionStorage
+	admins  []string
+}
+
+func NewDeletionHandler(st DeletionStorage, admins []string) *deletionHandler {
+	return &deletionHandler{
+		storage: st,
+		admins:  admins,
+	}
+}
+
+var notCycleDeleterError = cycleHandlerError{message: "only admins can delete or restore a cycle"}
+var notTrashAdminError = cycleHandlerError{message: "only admins can list deleted cycles"}
+
+// DeleteByID godoc
+//
+//	@summary		DeleteByID
+//	@description	Move a cycle to the trash. It is left out of every listing, can be restored and is purged for good once kept for the retention period.
+//	@tags			cycle
+//	@id				DeleteByID
+//	@security		BearerAuth
+//	@produce		json
+//	@param			id	path		string			true	"Cycle ID"
+//	@response		200	{object}	cycle.NewCycle	"OK"
+//	@response		400	{object}	app.Response	"invalid cycle id"
+//	@response		403	{object}	app.Response	"Not an admin"
+//	@response		404	{object}	app.Response	"cycle not found"
+//	@response		500	{object}	app.Response	"Internal Server Error"
+//	@router			/cycles/{id} [delete]
+func (h *deletionHandler) DeleteByID(c app.Context) {
+	if !app.RequireAdmin(c, h.admins, notCycleDeleterError) {
+		return
+	}
+
+	cy, err := h.storage.GetNewByID(c.Param("id"))
+	if err != nil {
+		if err == cycleNotFoundError {
+			c.NotFound(err)
+			return
+		}
+		c.BadRequest(invalidRequestError)
+		return
+	}
+	res, err := h.storage.SoftDeleteCycle(c.Ctx(), cy.ID.Hex(), c.GetString("email"))
+	if err != nil {
+		deletionError(c, err)
+		return
+	}
+
+	c.OK(res)
+}
+
+// Restore godoc
+//
+//	@summary		Restore
+//	@description	Take a cycle out of the trash.
+//	@tags			cycle
+//	@id				RestoreCycle
+//	@security		BearerAuth
+//	@produce		json
+//	@param			id	path		string			true	"Cycle ID"
+//	@response		200	{object}	cycle.NewCycle	"OK"
+//	@response		400	{object}	app.Response	"invalid cycle id"
+//	@response		403	{object}	app.Response	"Not an admin"
+//	@response		404	{object}	app.Response	"No deleted cycle with this ID"
+//	@response		409	{object}	app.Response	"Cycle is not deleted"
+//	@response		500	{object}	app.Response	"Internal Server Error"
+//	@router			/cycles/{id}/restore [post]
+func (h *deletionHandler) Restore(c app.Context) {
+	if !app.RequireAdmin(c, h.admins, notCycleDeleterError) {
+		return
+	}
+
+	cy, err := h.storage.GetDeletedByID(c.Ctx(), c.Param("id"))
+	if err != nil {
+		deletionError(c, err)
+		return
+	}
+	res, err := h.storage.RestoreCycle(c.Ctx(), cy.ID.Hex(), c.GetString("email"))
+	if err != nil {
+		deletionError(c, err)
+		return
+	}
+
+	c.OK(res)
+}
+
+// GetDeleted godoc
+//
+//	@summary		GetDeleted
+//	@description	Get a page of the cycles in the trash.
+//	@tags			cycle
+//	@id				GetDeletedCycles
+//	@security		BearerAuth
+//	@produce		json
+//	@param			cursor	query		string						false	"nextCursor of the previous page"
+//	@param			limit	query		int							false	"Page size, 10 by default and 100 at most"
+//	@param			sort	query		string						false	"startDate (default), endDate or status"
+//	@param			order	query		string						false	"desc (default) or asc"
+//	@response		200		{object}	cycle.Page[cycle.NewCycle]	"OK"
+//	@response		400		{object}	app.Response				"Bad Request"
+//	@response		403		{object}	app.Response				"Not an admin"
+//	@response		450		{object}	app.Response				"Store Error"
+//	@router			/admin/cycles/deleted [get]
+func (h *deletionHandler) GetDeleted(c app.Context) {
+	if !app.RequireAdmin(c, h.admins, notTrashAdminError) {
+		return
+	}
+	page, ok := pageRequest(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.storage.GetDeletedCycles(c.Ctx(), page)
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(res)
+}
+
+func deletionError(c app.Context, err error) {
+	switch err {
+	case invalidRequestError:
+		c.BadRequest(err)
+	case cycleNotFoundError:
+		c.NotFound(err)
+	case cycleNotDeletedError:
+		c.Conflict(err)
+	default:
+		c.InternalServerError(err)
+	}
+}
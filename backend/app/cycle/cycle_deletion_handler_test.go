@@ -0,0 +1,219 @@
+package cycle
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+type mockDeletionStorage struct {
+	cycle    *NewCycle
+	page     PageRequest
+	deleted  string
+	restored string
+}
+
+func (m *mockDeletionStorage) find(id string, deleted bool) (*NewCycle, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, invalidRequestError
+	}
+	if m.cycle == nil || m.cycle.ID.Hex() != id || (m.cycle.DeletedAt != nil) != deleted {
+		return nil, cycleNotFoundError
+	}
+	return m.cycle, nil
+}
+
+func (m *mockDeletionStorage) GetNewByID(id string) (*NewCycle, error) {
+	return m.find(id, false)
+}
+
+func (m *mockDeletionStorage) GetDeletedByID(ctx context.Context, id string) (*NewCycle, error) {
+	return m.find(id, true)
+}
+
+func (m *mockDeletionStorage) GetDeletedCycles(ctx context.Context, page PageRequest) (*Page[*NewCycle], error) {
+	m.page = page
+	return &Page[*NewCycle]{Items: []*NewCycle{}}, nil
+}
+
+func (m *mockDeletionStorage) SoftDeleteCycle(ctx context.Context, id string, email string) (*NewCycle, error) {
+	m.deleted = id
+	cy := *m.cycle
+	now := time.Now()
+	cy.DeletedAt, cy.DeletedBy = &now, email
+	return &cy, nil
+}
+
+func (m *mockDeletionStorage) RestoreCycle(ctx context.Context, id string, email string) (*NewCycle, error) {
+	m.restored = id
+	cy := *m.cycle
+	cy.DeletedAt, cy.DeletedBy = nil, ""
+	return &cy, nil
+}
+
+func deletionEngine(h *deletionHandler, email string) *gin.Engine {
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Set("email", email)
+	})
+	engine.DELETE("/cycles/:id", app.NewGinHandler(h.DeleteByID, zap.NewNop()))
+	engine.POST("/cycles/:id/restore", app.NewGinHandler(h.Restore, zap.NewNop()))
+	engine.GET("/admin/cycles/deleted", app.NewGinHandler(h.GetDeleted, zap.NewNop()))
+	return engine
+}
+
+func TestDeletionHandlerDeleteByID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		email          string
+		id             string
+		expectedStatus int
+		expectDeleted  bool
+	}{
+		{
+			name:           "should return 200 and move the cycle to the trash for an admin",
+			email:          "admin@arise.tech",
+			id:             mockObjectId(24).hexId,
+			expectedStatus: http.StatusOK,
+			expectDeleted:  true,
+		},
+		{
+			name:           "should return 403 when the team lead deletes it",
+			email:          "lead@arise.tech",
+			id:             mockObjectId(24).hexId,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "should return 403 when the ariser deletes it",
+			email:          "ariser@arise.tech",
+			id:             mockObjectId(24).hexId,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "should return 400 when invalid Id",
+			email:          "admin@arise.tech",
+			id:             "dkls",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should return 404 when not found",
+			email:          "admin@arise.tech",
+			id:             mockObjectId(100).hexId,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockDeletionStorage{cycle: &NewCycle{
+				ID:             mockObjectId(24).objectId,
+				AriserMail:     "ariser@arise.tech",
+				TeamLeaderMail: "lead@arise.tech",
+			}}
+			engine := deletionEngine(NewDeletionHandler(st, []string{"admin@arise.tech"}), tc.email)
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodDelete, "/cycles/"+tc.id, nil)
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectDeleted {
+				assert.Equal(t, mockObjectId(24).hexId, st.deleted)
+			} else {
+				assert.Empty(t, st.deleted)
+			}
+		})
+	}
+}
+
+func TestDeletionHandlerRestore(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	deletedAt := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name           string
+		email          string
+		deletedAt      *time.Time
+		expectedStatus int
+		expectRestored bool
+	}{
+		{
+			name:           "should return 200 and take the cycle out of the trash",
+			email:          "admin@arise.tech",
+			deletedAt:      &deletedAt,
+			expectedStatus: http.StatusOK,
+			expectRestored: true,
+		},
+		{
+			name:           "should return 403 when not an admin",
+			email:          "lead@arise.tech",
+			deletedAt:      &deletedAt,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "should return 404 when the cycle is not in the trash",
+			email:          "admin@arise.tech",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockDeletionStorage{cycle: &NewCycle{
+				ID:             mockObjectId(24).objectId,
+				AriserMail:     "ariser@arise.tech",
+				TeamLeaderMail: "lead@arise.tech",
+				DeletedAt:      tc.deletedAt,
+				DeletedBy:      "lead@arise.tech",
+			}}
+			engine := deletionEngine(NewDeletionHandler(st, []string{"admin@arise.tech"}), tc.email)
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/cycles/"+mockObjectId(24).hexId+"/restore", nil)
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectRestored {
+				assert.Equal(t, mockObjectId(24).hexId, st.restored)
+			} else {
+				assert.Empty(t, st.restored)
+			}
+		})
+	}
+}
+
+func TestDeletionHandlerGetDeleted(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should return 200 and a page of the trash for an admin", func(t *testing.T) {
+		st := &mockDeletionStorage{}
+		engine := deletionEngine(NewDeletionHandler(st, []string{"admin@arise.tech"}), "admin@arise.tech")
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/cycles/deleted?limit=20", nil)
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 20, st.page.Limit)
+	})
+
+	t.Run("should return 403 when not an admin", func(t *testing.T) {
+		engine := deletionEngine(NewDeletionHandler(&mockDeletionStorage{}, []string{"admin@arise.tech"}), "lead@arise.tech")
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/cycles/deleted", nil)
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
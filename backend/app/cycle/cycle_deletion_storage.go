@@ -0,0 +1,137 @@
+package cycle
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var cycleNotDeletedError = CycleStorageError{message: "cycle is not deleted"}
+
+// GetDeletedByID gets a cycle that is in the trash.
+func (s *storage) GetDeletedByID(ctx context.Context, id string) (*NewCycle, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, invalidRequestError
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var cycle NewCycle
+	filter := bson.M{"_id": objId, "deletedAt": bson.M{"$ne": nil}}
+	if err := s.db.Collection(newCycleCollection).FindOne(ctx, filter).Decode(&cycle); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, cycleNotFoundError
+		}
+		return nil, err
+	}
+	return &cycle, nil
+}
+
+// GetDeletedCycles lists the cycles in the trash one page at a time.
+func (s *storage) GetDeletedCycles(ctx context.Context, page PageRequest) (*Page[*NewCycle], error) {
+	return s.findPage(ctx, bson.M{"deletedAt": bson.M{"$ne": nil}}, page)
+}
+
+// SoftDeleteCycle moves a cycle to the trash, where it stays until it is
+// restored or purged.
+func (s *storage) SoftDeleteCycle(ctx context.Context, id string, email string) (*NewCycle, error) {
+	return s.setDeleted(ctx, id, email, true)
+}
+
+// RestoreCycle takes a cycle out of the trash.
+func (s *storage) RestoreCycle(ctx context.Context, id string, email string) (*NewCycle, error) {
+	return s.setDeleted(ctx, id, email, false)
+}
+
+func (s *storage) setDeleted(ctx context.Context, id string, email string, deleted bool) (*NewCycle, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, invalidRequestError
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"_id": objId, "deletedAt": nil}
+	update := bson.M{"$set": bson.M{"deletedAt": now, "deletedBy": email}, "$inc": bson.M{"version": 1}}
+	eventType := EventDeleted
+	if !deleted {
+		filter["deletedAt"] = bson.M{"$ne": nil}
+		update = bson.M{"$unset": bson.M{"deletedAt": "", "deletedBy": ""}, "$inc": bson.M{"version": 1}}
+		eventType = EventRestored
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var cycle NewCycle
+	err = s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		var before NewCycle
+		if err := s.db.Collection(newCycleCollection).FindOneAndUpdate(sc, filter, update, opts).Decode(&before); err != nil {
+			if err == mongo.ErrNoDocuments {
+				if deleted {
+					return cycleNotFoundError
+				}
+				return cycleNotDeletedError
+			}
+			return err
+		}
+
+		cycle = before
+		cycle.DeletedAt, cycle.DeletedBy = nil, ""
+		if deleted {
+			cycle.DeletedAt, cycle.DeletedBy = &now, email
+		}
+		cycle.Version++
+		return s.recordEvent(sc, eventType, email, &before, &cycle)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &cycle, nil
+}
+
+// PurgeDeleted removes for good the cycles deleted before the given time,
+// along with their comments, peer feedback and history, and returns how many
+// it removed.
+func (s *storage) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var purged int64
+	err := s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		purged = 0
+		ids, err := s.db.Collection(newCycleCollection).Distinct(sc, "_id", bson.M{"deletedAt": bson.M{"$lt": deletedBefore}})
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		res, err := s.db.Collection(newCycleCollection).DeleteMany(sc, bson.M{"_id": bson.M{"$in": ids}})
+		if err != nil {
+			return err
+		}
+		purged = res.DeletedCount
+
+		byCycle := bson.M{"cycleId": bson.M{"$in": ids}}
+		if _, err := s.db.Collection(cycleCommentCollection).DeleteMany(sc, byCycle); err != nil {
+			return err
+		}
+		if _, err := s.db.Collection(peerFeedbackCollection).DeleteMany(sc, byCycle); err != nil {
+			return err
+		}
+		_, err = s.db.Collection(cycleEventCollection).DeleteMany(sc, byCycle)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, nil
+}
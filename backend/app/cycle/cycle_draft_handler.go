@@ -0,0 +1,238 @@
+package cycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/skill"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/user"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type DraftStorage interface {
+	PeriodLookup
+	GetUsersHardSkillByEmail(ctx context.Context, email string) (*user.User, error)
+	GetGoalPolicy(ctx context.Context, jobRole string, level string) (*GoalPolicy, error)
+	InsertNew(ctx context.Context, cy NewCycle, email string) (*NewCycle, error)
+}
+
+// HardSkillCatalog is the part of skill.Storage a draft is built from.
+type HardSkillCatalog interface {
+	GetByRole(ctx context.Context, role string) ([]skill.HardSkill, error)
+	GetRubricVersions(ctx context.Context, skillIDs []primitive.ObjectID) ([]skill.RubricVersion, error)
+}
+
+type draftHandler struct {
+	storage DraftStorage
+	catalog HardSkillCatalog
+}
+
+func NewDraftHandler(st DraftStorage, catalog HardSkillCatalog) *draftHandler {
+	return &draftHandler{
+		storage: st,
+		catalog: catalog,
+	}
+}
+
+var invalidCycleDateError = cycleHandlerError{message: "end date must be after start date"}
+var unknownDraftSkillError = cycleHandlerError{message: "hard skills must be from the catalog of the job role, each once"}
+
+// Draft godoc
+//
+//	@summary		Draft
+//	@description	Get a new cycle pre-filled with the hard skills of the user's job role, with the skill levels in effect today, and the user's current level of each skill
+//	@tags			cycle
+//	@id				DraftCycle
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@response		200	{object}	cycle.NewCycle	"OK"
+//	@response		404	{object}	app.Response	"User not found"
+//	@response		500	{object}	app.Response	"Internal Server Error"
+//	@router			/cycles/draft [get]
+func (h *draftHandler) Draft(c app.Context) {
+	draft, _, err := h.draft(c.Ctx(), c.GetString("email"))
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.NotFound(err)
+			return
+		}
+		c.InternalServerError(err)
+		return
+	}
+	if draft.HardSkills, err = pinCatalogRubrics(c.Ctx(), h.catalog, draft.HardSkills, time.Now()); err != nil {
+		c.InternalServerError(err)
+		return
+	}
+
+	c.OK(draft)
+}
+
+// InsertNew godoc
+//
+//	@summary		InsertNew
+//	@description	Start a new cycle in a review period, the open one by default. Dates left empty default to the period's. Hard skills are picked by name from the job-role catalog with their goal, all of them when left empty. Hard skills are measured against the skill levels in effect on the start date. The goals must follow the goal policy of the user's job role and level.
+//	@tags			cycle
+//	@id				InsertNewCycle
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			reqJson	body		NewCycleInput	true	"New cycle input"
+//	@response		200		{object}	cycle.NewCycle	"OK"
+//	@response		400		{object}	app.Response	"Bad Request"
+//	@response		404		{object}	app.Response	"User or review period not found"
+//	@response		409		{object}	app.Response	"No review period is open"
+//	@response		422		{object}	app.Response	"Goals break the goal policy, data lists each cycle.PolicyViolation"
+//	@response		500		{object}	app.Response	"Internal Server Error"
+//	@router			/cycles [post]
+func (h *draftHandler) InsertNew(c app.Context) {
+	email := c.GetString("email")
+
+	var input NewCycleInput
+	if err := c.Bind(&input); err != nil {
+		c.BadRequest(invalidInsertOneInputError)
+		return
+	}
+	if err := ValidateEmail(input.TeamLeaderMail); err != nil {
+		c.BadRequest(err)
+		return
+	}
+
+	period, err := findPeriod(c.Ctx(), h.storage, input.PeriodID)
+	if err != nil {
+		periodError(c, err)
+		return
+	}
+	start, end, err := period.CycleDates(input.StartDate, input.EndDate)
+	if err != nil {
+		c.BadRequest(err)
+		return
+	}
+
+	draft, u, err := h.draft(c.Ctx(), email)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.NotFound(err)
+			return
+		}
+		c.InternalServerError(err)
+		return
+	}
+
+	draft.TeamLeaderMail = input.TeamLeaderMail
+	draft.PeriodID = period.ID
+	draft.StartDate = start
+	draft.EndDate = end
+	if len(input.HardSkills) > 0 {
+		if draft.HardSkills, err = pickGoals(draft.HardSkills, input.HardSkills); err != nil {
+			c.BadRequest(err)
+			return
+		}
+	}
+	if draft.HardSkills, err = pinCatalogRubrics(c.Ctx(), h.catalog, draft.HardSkills, start); err != nil {
+		c.InternalServerError(err)
+		return
+	}
+
+	policy, err := h.storage.GetGoalPolicy(c.Ctx(), u.JobRole, u.Level)
+	if err != nil {
+		c.InternalServerError(err)
+		return
+	}
+	if violations := policy.Check(draft.HardSkills); len(violations) > 0 {
+		c.UnprocessableEntity(violations, GoalPolicyError{Violations: violations})
+		return
+	}
+
+	res, err := h.storage.InsertNew(c.Ctx(), *draft, email)
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(res)
+}
+
+// draft builds the draft of the user, who is returned along with it.
+func (h *draftHandler) draft(ctx context.Context, email string) (*NewCycle, *user.User, error) {
+	u, err := h.storage.GetUsersHardSkillByEmail(ctx, email)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	catalog, err := h.catalog.GetByRole(ctx, u.JobRole)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot get hard skills of job role %q: %w", u.JobRole, err)
+	}
+
+	return NewDraft(u, catalog), u, nil
+}
+
+// NewDraft builds a pending cycle for the user out of the hard skills of
+// their job role. Each skill starts at the user's current level, which is
+// also the goal until the ariser raises it.
+func NewDraft(u *user.User, catalog []skill.HardSkill) *NewCycle {
+	levels := make(map[string]int)
+	for _, hs := range u.HardSkills {
+		levels[hs.Name] = hs.CurrentLevel
+	}
+
+	sorted := make([]skill.HardSkill, len(catalog))
+	copy(sorted, catalog)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Sort < sorted[j].Sort
+	})
+
+	hardSkills := []HardSkill{}
+	for _, hs := range sorted {
+		skillLevels := []SkillLevel{}
+		for _, l := range hs.SkillLevel {
+			skillLevels = append(skillLevels, SkillLevel{
+				Level:            l.Level,
+				LevelDescription: string(l.LevelDescription),
+			})
+		}
+		hardSkills = append(hardSkills, HardSkill{
+			ID:            hs.ID,
+			Name:          hs.Name,
+			Description:   string(hs.Description),
+			SkillLevels:   skillLevels,
+			PersonalScore: levels[hs.Name],
+			GoalScore:     levels[hs.Name],
+		})
+	}
+
+	return &NewCycle{
+		AriserMail: u.Email,
+		Status:     StatusPending,
+		State:      StateReview,
+		HardSkills: hardSkills,
+	}
+}
+
+// pickGoals keeps the draft hard skills the ariser picked, in their order,
+// with the goals they set. Everything else comes from the catalog and the
+// user's profile, so an ariser can't pick their own baseline or rubric.
+func pickGoals(draft []HardSkill, goals []GoalRequest) ([]HardSkill, error) {
+	byName := make(map[string]HardSkill)
+	for _, hs := range draft {
+		byName[hs.Name] = hs
+	}
+
+	hardSkills := []HardSkill{}
+	for _, goal := range goals {
+		hs, ok := byName[goal.Name]
+		if !ok {
+			return nil, unknownDraftSkillError
+		}
+		delete(byName, goal.Name)
+		hs.GoalScore = goal.GoalScore
+		hardSkills = append(hardSkills, hs)
+	}
+	return hardSkills, nil
+}
@@ -0,0 +1,386 @@
+package cycle
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/skill"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/user"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+type mockDraftStorage struct {
+	user     *user.User
+	period   *ReviewPeriod
+	policy   *GoalPolicy
+	inserted *NewCycle
+	failFor  string
+	started  []string
+	err      error
+}
+
+func (m *mockDraftStorage) GetPeriodByID(ctx context.Context, id string) (*ReviewPeriod, error) {
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return nil, invalidRequestError
+	}
+	if m.period == nil || m.period.ID.Hex() != id {
+		return nil, periodNotFoundError
+	}
+	return m.period, nil
+}
+
+func (m *mockDraftStorage) GetOpenPeriod(ctx context.Context, at time.Time) (*ReviewPeriod, error) {
+	if m.period == nil {
+		return nil, noOpenPeriodError
+	}
+	return m.period, nil
+}
+
+func (m *mockDraftStorage) GetUsersHardSkillByEmail(ctx context.Context, email string) (*user.User, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.user, nil
+}
+
+func (m *mockDraftStorage) GetGoalPolicy(ctx context.Context, jobRole string, level string) (*GoalPolicy, error) {
+	if m.policy == nil {
+		return DefaultGoalPolicy(jobRole, level), nil
+	}
+	return m.policy, nil
+}
+
+func (m *mockDraftStorage) InsertNew(ctx context.Context, cy NewCycle, email string) (*NewCycle, error) {
+	if cy.AriserMail == m.failFor {
+		return nil, errors.New("insert error")
+	}
+	cy.ID = mockObjectId(1).objectId
+	m.inserted = &cy
+	return &cy, nil
+}
+
+func (m *mockDraftStorage) GetArisersWithCycle(ctx context.Context, periodID primitive.ObjectID) ([]string, error) {
+	return m.started, nil
+}
+
+type mockHardSkillCatalog struct {
+	role     string
+	skills   []skill.HardSkill
+	versions []skill.RubricVersion
+	err      error
+}
+
+func (m *mockHardSkillCatalog) GetByRole(ctx context.Context, role string) ([]skill.HardSkill, error) {
+	m.role = role
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.skills, nil
+}
+
+func (m *mockHardSkillCatalog) GetRubricVersions(ctx context.Context, skillIDs []primitive.ObjectID) ([]skill.RubricVersion, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.versions, nil
+}
+
+func h1Period() *ReviewPeriod {
+	return &ReviewPeriod{
+		ID:              mockObjectId(9).objectId,
+		Name:            "2024 H1",
+		OpenDate:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		CloseDate:       time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+		CalibrationDate: time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func draftFixtures() (*user.User, []skill.HardSkill) {
+	u := &user.User{
+		Email:   "ariser@arise.tech",
+		JobRole: "frontend",
+		HardSkills: []user.MyHardSkill{
+			{Name: "CSS", CurrentLevel: 3},
+		},
+	}
+	catalog := []skill.HardSkill{
+		{
+			ID:          mockObjectId(2).objectId,
+			Name:        "CSS",
+			Description: skill.Description,
+			Sort:        1,
+			SkillLevel:  []skill.SkillLevel{{Level: 1, LevelDescription: skill.ExampleLevel1}},
+		},
+		{
+			ID:          mockObjectId(3).objectId,
+			Name:        "HTML",
+			Description: skill.Description,
+			Sort:        0,
+			SkillLevel:  []skill.SkillLevel{{Level: 1, LevelDescription: skill.ExampleLevel1}},
+		},
+	}
+	return u, catalog
+}
+
+func draftRubrics() []skill.RubricVersion {
+	return []skill.RubricVersion{
+		{
+			SkillID:    mockObjectId(2).objectId,
+			Version:    1,
+			SkillLevel: []skill.SkillLevel{{Level: 1, LevelDescription: skill.ExampleLevel1}},
+		},
+		{
+			SkillID:       mockObjectId(2).objectId,
+			Version:       2,
+			EffectiveFrom: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			SkillLevel:    []skill.SkillLevel{{Level: 1, LevelDescription: "styles a page"}, {Level: 2, LevelDescription: "styles a site"}},
+		},
+	}
+}
+
+func TestDraft(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should return 200 and hard skills of job role with current levels", func(t *testing.T) {
+		u, catalog := draftFixtures()
+		st := &mockDraftStorage{user: u}
+		cat := &mockHardSkillCatalog{skills: catalog}
+		handler := NewDraftHandler(st, cat)
+
+		engine := gin.New()
+		engine.GET("/cycles/draft", app.NewGinHandler(handler.Draft, zap.NewNop()))
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cycles/draft", nil)
+
+		engine.ServeHTTP(rec, req)
+
+		want := `{
+			"status": "success",
+			"message": "",
+			"data": {
+				"id": "000000000000000000000000",
+				"teamLeaderMail": "",
+				"ariserMail": "ariser@arise.tech",
+				"startDate": "0001-01-01T00:00:00Z",
+				"endDate": "0001-01-01T00:00:00Z",
+				"status": "Pending",
+				"state": "Review",
+				"periodId": "000000000000000000000000",
+				"version": 0,
+				"hardSkills": [
+					{
+						"id": "000000000000000000000003",
+						"name": "HTML",
+						"description": "description",
+						"skillLevels": [{"level": 1, "levelDescription": "example level 1"}],
+						"personalScore": 0,
+						"goalScore": 0,
+						"leadScore": 0,
+						"counterScore": 0,
+						"agreement": "",
+						"mutualScore": 0
+					},
+					{
+						"id": "000000000000000000000002",
+						"name": "CSS",
+						"description": "description",
+						"skillLevels": [{"level": 1, "levelDescription": "example level 1"}],
+						"personalScore": 3,
+						"goalScore": 3,
+						"leadScore": 0,
+						"counterScore": 0,
+						"agreement": "",
+						"mutualScore": 0
+					}
+				]
+			}
+		}`
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "frontend", cat.role)
+		assert.JSONEq(t, want, rec.Body.String())
+	})
+
+	t.Run("should return 404 when user is not found", func(t *testing.T) {
+		handler := NewDraftHandler(&mockDraftStorage{err: mongo.ErrNoDocuments}, &mockHardSkillCatalog{})
+
+		engine := gin.New()
+		engine.GET("/cycles/draft", app.NewGinHandler(handler.Draft, zap.NewNop()))
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cycles/draft", nil)
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("should return 500 when catalog is failed", func(t *testing.T) {
+		u, _ := draftFixtures()
+		handler := NewDraftHandler(&mockDraftStorage{user: u}, &mockHardSkillCatalog{err: errors.New("catalog error")})
+
+		engine := gin.New()
+		engine.GET("/cycles/draft", app.NewGinHandler(handler.Draft, zap.NewNop()))
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cycles/draft", nil)
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestInsertNew(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		reqBody        string
+		noPeriod       bool
+		policy         *GoalPolicy
+		expectedStatus int
+		checkInserted  func(t *testing.T, cy *NewCycle)
+	}{
+		{
+			name:           "should return 200 and pre-filled hard skills when none are given",
+			reqBody:        `{"teamLeaderMail":"lead@arise.tech","startDate":"2024-01-01T00:00:00Z","endDate":"2024-06-30T00:00:00Z"}`,
+			expectedStatus: http.StatusOK,
+			checkInserted: func(t *testing.T, cy *NewCycle) {
+				assert.Equal(t, "lead@arise.tech", cy.TeamLeaderMail)
+				assert.Equal(t, "ariser@arise.tech", cy.AriserMail)
+				assert.Equal(t, mockObjectId(9).objectId, cy.PeriodID)
+				assert.Len(t, cy.HardSkills, 2)
+				assert.Equal(t, 1, cy.HardSkills[1].RubricVersion)
+				assert.Equal(t, 0, cy.HardSkills[0].RubricVersion)
+			},
+		},
+		{
+			name:           "should return 200 and dates of the open period when none are given",
+			reqBody:        `{"teamLeaderMail":"lead@arise.tech"}`,
+			expectedStatus: http.StatusOK,
+			checkInserted: func(t *testing.T, cy *NewCycle) {
+				assert.Equal(t, mockObjectId(9).objectId, cy.PeriodID)
+				assert.Equal(t, h1Period().OpenDate, cy.StartDate)
+				assert.Equal(t, h1Period().CloseDate, cy.EndDate)
+			},
+		},
+		{
+			name:           "should return 200 and keep a given start date inside the period",
+			reqBody:        `{"teamLeaderMail":"lead@arise.tech","periodId":"000000000000000000000009","startDate":"2024-03-01T00:00:00Z"}`,
+			expectedStatus: http.StatusOK,
+			checkInserted: func(t *testing.T, cy *NewCycle) {
+				assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), cy.StartDate)
+				assert.Equal(t, h1Period().CloseDate, cy.EndDate)
+				assert.Equal(t, 2, cy.HardSkills[1].RubricVersion)
+				assert.Equal(t, []SkillLevel{{Level: 1, LevelDescription: "styles a page"}, {Level: 2, LevelDescription: "styles a site"}}, cy.HardSkills[1].SkillLevels)
+			},
+		},
+		{
+			name:           "should return 400 when dates are outside the period",
+			reqBody:        `{"teamLeaderMail":"lead@arise.tech","startDate":"2024-01-01T00:00:00Z","endDate":"2024-08-31T00:00:00Z"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should return 404 when period is not found",
+			reqBody:        `{"teamLeaderMail":"lead@arise.tech","periodId":"000000000000000000000008"}`,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "should return 409 when no period is open",
+			reqBody:        `{"teamLeaderMail":"lead@arise.tech"}`,
+			noPeriod:       true,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "should return 200 and keep given goals with everything else from the catalog and profile",
+			reqBody:        `{"teamLeaderMail":"lead@arise.tech","startDate":"2024-01-01T00:00:00Z","endDate":"2024-06-30T00:00:00Z","hardSkills":[{"name":"CSS","personalScore":1,"goalScore":4,"leadScore":5,"mutualScore":5,"description":"made up","skillLevels":[{"level":1,"levelDescription":"made up"}]}]}`,
+			expectedStatus: http.StatusOK,
+			checkInserted: func(t *testing.T, cy *NewCycle) {
+				assert.Len(t, cy.HardSkills, 1)
+				assert.Equal(t, mockObjectId(2).objectId, cy.HardSkills[0].ID)
+				assert.Equal(t, string(skill.Description), cy.HardSkills[0].Description)
+				assert.Equal(t, []SkillLevel{{Level: 1, LevelDescription: string(skill.ExampleLevel1)}}, cy.HardSkills[0].SkillLevels)
+				assert.Equal(t, 3, cy.HardSkills[0].PersonalScore)
+				assert.Equal(t, 4, cy.HardSkills[0].GoalScore)
+				assert.Equal(t, 0, cy.HardSkills[0].LeadScore)
+				assert.Equal(t, 0, cy.HardSkills[0].MutualScore)
+			},
+		},
+		{
+			name:           "should return 400 when a hard skill is not in the catalog",
+			reqBody:        `{"teamLeaderMail":"lead@arise.tech","startDate":"2024-01-01T00:00:00Z","endDate":"2024-06-30T00:00:00Z","hardSkills":[{"name":"COBOL","goalScore":4}]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should return 400 when a hard skill is given twice",
+			reqBody:        `{"teamLeaderMail":"lead@arise.tech","startDate":"2024-01-01T00:00:00Z","endDate":"2024-06-30T00:00:00Z","hardSkills":[{"name":"CSS","goalScore":4},{"name":"CSS","goalScore":3}]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should return 422 when goal score jumps more than one level",
+			reqBody:        `{"teamLeaderMail":"lead@arise.tech","startDate":"2024-01-01T00:00:00Z","endDate":"2024-06-30T00:00:00Z","hardSkills":[{"name":"CSS","goalScore":5}]}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "should return 200 when the policy allows a two-level jump",
+			reqBody:        `{"teamLeaderMail":"lead@arise.tech","startDate":"2024-01-01T00:00:00Z","endDate":"2024-06-30T00:00:00Z","hardSkills":[{"name":"CSS","goalScore":5}]}`,
+			policy:         &GoalPolicy{JobRole: "frontend", Level: "Senior", MaxLevelJump: 2},
+			expectedStatus: http.StatusOK,
+			checkInserted: func(t *testing.T, cy *NewCycle) {
+				assert.Equal(t, 5, cy.HardSkills[0].GoalScore)
+			},
+		},
+		{
+			name:           "should return 422 when a mandatory skill is left out",
+			reqBody:        `{"teamLeaderMail":"lead@arise.tech","startDate":"2024-01-01T00:00:00Z","endDate":"2024-06-30T00:00:00Z","hardSkills":[{"name":"CSS","goalScore":4}]}`,
+			policy:         &GoalPolicy{JobRole: "frontend", MaxLevelJump: 1, MandatorySkills: []string{"HTML"}},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "should return 400 when end date is before start date",
+			reqBody:        `{"teamLeaderMail":"lead@arise.tech","startDate":"2024-06-30T00:00:00Z","endDate":"2024-01-01T00:00:00Z"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should return 400 when team lead is missing",
+			reqBody:        `{"startDate":"2024-01-01T00:00:00Z","endDate":"2024-06-30T00:00:00Z"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u, catalog := draftFixtures()
+			st := &mockDraftStorage{user: u, period: h1Period(), policy: tc.policy}
+			if tc.noPeriod {
+				st.period = nil
+			}
+			handler := NewDraftHandler(st, &mockHardSkillCatalog{skills: catalog, versions: draftRubrics()})
+
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", "ariser@arise.tech")
+			})
+			engine.POST("/cycles", app.NewGinHandler(handler.InsertNew, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/cycles", strings.NewReader(tc.reqBody))
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.checkInserted != nil {
+				tc.checkInserted(t, st.inserted)
+			} else {
+				assert.Nil(t, st.inserted)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package cycle
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	EventCreated       = "created"
+	EventUpdated       = "updated"
+	EventGoalsUpdated  = "goals_updated"
+	EventStatusChanged = "status_changed"
+	EventScoresChanged = "scores_changed"
+	EventCommented     = "commented"
+	EventOverdue       = "overdue"
+	EventLeadChanged   = "lead_changed"
+	EventRolledOver    = "rolled_over"
+	EventDeleted       = "deleted"
+	EventRestored      = "restored"
+	EventPeerInvited   = "peer_invited"
+	EventPeerSubmitted = "peer_submitted"
+	EventPeriodLinked  = "period_linked"
+)
+
+// CycleEvent is one entry of the append-only history of a NewCycle.
+type CycleEvent struct {
+	ID      primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	CycleID primitive.ObjectID `json:"cycleId" bson:"cycleId"`
+	Type    string             `json:"type" bson:"type"`
+	Actor   string             `json:"actor" bson:"actor"`
+	At      time.Time          `json:"at" bson:"at"`
+	Changes []FieldChange      `json:"changes" bson:"changes"`
+}
+
+type FieldChange struct {
+	Field  string `json:"field" bson:"field"`
+	Before any    `json:"before" bson:"before"`
+	After  any    `json:"after" bson:"after"`
+}
+
+// diffCycles lists every field that differs between two versions of the same
+// cycle. Hard skills are matched by name, so a field path looks like
+// "hardSkills.CSS.goalScore".
+func diffCycles(before *NewCycle, after *NewCycle) []FieldChange {
+	changes := []FieldChange{}
+	add := func(field string, b, a any) {
+		if bt, ok := b.(time.Time); ok && bt.Equal(a.(time.Time)) {
+			return
+		}
+		if b != a {
+			changes = append(changes, FieldChange{Field: field, Before: b, After: a})
+		}
+	}
+
+	add("teamLeaderMail", before.TeamLeaderMail, after.TeamLeaderMail)
+	add("startDate", before.StartDate, after.StartDate)
+	add("endDate", before.EndDate, after.EndDate)
+	add("status", before.Status, after.Status)
+	add("state", before.State, after.State)
+	add("overdue", before.Overdue, after.Overdue)
+	add("deletedBy", before.DeletedBy, after.DeletedBy)
+
+	beforeSkills := make(map[string]HardSkill)
+	for _, hs := range before.HardSkills {
+		beforeSkills[hs.Name] = hs
+	}
+	afterSkills := make(map[string]bool)
+	for _, a := range after.HardSkills {
+		afterSkills[a.Name] = true
+		prefix := "hardSkills." + a.Name
+		b, found := beforeSkills[a.Name]
+		if !found {
+			changes = append(changes, FieldChange{Field: prefix, Before: nil, After: a})
+			continue
+		}
+		add(prefix+".personalScore", b.PersonalScore, a.PersonalScore)
+		add(prefix+".goalScore", b.GoalScore, a.GoalScore)
+		add(prefix+".leadScore", b.LeadScore, a.LeadScore)
+		add(prefix+".counterScore", b.CounterScore, a.CounterScore)
+		add(prefix+".agreement", b.Agreement, a.Agreement)
+		add(prefix+".mutualScore", b.MutualScore, a.MutualScore)
+	}
+	for _, b := range before.HardSkills {
+		if !afterSkills[b.Name] {
+			changes = append(changes, FieldChange{Field: "hardSkills." + b.Name, Before: b, After: nil})
+		}
+	}
+
+	return changes
+}
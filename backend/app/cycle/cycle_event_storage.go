@@ -0,0 +1,53 @@
+package cycle
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const cycleEventCollection = "cycle_events"
+
+// recordEvent appends what changed between before and after to the history
+// of the cycle. Nothing is written when both versions are the same.
+func (s *storage) recordEvent(ctx context.Context, eventType string, actor string, before *NewCycle, after *NewCycle) error {
+	changes := diffCycles(before, after)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	event := CycleEvent{
+		CycleID: after.ID,
+		Type:    eventType,
+		Actor:   actor,
+		At:      time.Now(),
+		Changes: changes,
+	}
+	_, err := s.db.Collection(cycleEventCollection).InsertOne(ctx, event)
+	return err
+}
+
+func (s *storage) GetEventsByCycleID(ctx context.Context, id string) ([]CycleEvent, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, invalidRequestError
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"cycleId": objId}
+	findOptions := options.Find().SetSort(bson.D{{Key: "at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := s.db.Collection(cycleEventCollection).Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []CycleEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
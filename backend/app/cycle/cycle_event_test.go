@@ -0,0 +1,46 @@
+package cycle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffCycles(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := &NewCycle{
+		StartDate: start,
+		Status:    StatusRunning,
+		State:     StateRunning,
+		HardSkills: []HardSkill{
+			{Name: "CSS", PersonalScore: 2, GoalScore: 3},
+			{Name: "HTML", PersonalScore: 1, GoalScore: 2},
+		},
+	}
+	after := &NewCycle{
+		StartDate: start.In(time.FixedZone("ICT", 7*60*60)),
+		Status:    StatusPending,
+		State:     StateReview,
+		HardSkills: []HardSkill{
+			{Name: "CSS", PersonalScore: 2, GoalScore: 2},
+			{Name: "Go", PersonalScore: 1, GoalScore: 2},
+		},
+	}
+
+	changes := diffCycles(before, after)
+
+	assert.Equal(t, []FieldChange{
+		{Field: "status", Before: StatusRunning, After: StatusPending},
+		{Field: "state", Before: StateRunning, After: StateReview},
+		{Field: "hardSkills.CSS.goalScore", Before: 3, After: 2},
+		{Field: "hardSkills.Go", Before: nil, After: after.HardSkills[1]},
+		{Field: "hardSkills.HTML", Before: before.HardSkills[1], After: nil},
+	}, changes)
+}
+
+func TestDiffCyclesWithoutChange(t *testing.T) {
+	cy := &NewCycle{Status: StatusPending, HardSkills: []HardSkill{{Name: "CSS", GoalScore: 3}}}
+
+	assert.Empty(t, diffCycles(cy, cy))
+}
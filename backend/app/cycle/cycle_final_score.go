@@ -0,0 +1,41 @@
+package cycle
+
+import "gitdev.devops.krungthai.com/aster/ariskill/app/user"
+
+// ApplyMutualScores returns the user's hard skills with the current level of
+// every skill scored in the cycle set to its mutual score. Skills the user
+// doesn't have yet are added; skills without a mutual score are left alone.
+// Applying the same cycle twice gives the same result.
+func ApplyMutualScores(userSkills []user.MyHardSkill, cycleSkills []HardSkill) []user.MyHardSkill {
+	result := make([]user.MyHardSkill, len(userSkills))
+	copy(result, userSkills)
+
+	index := make(map[string]int)
+	for i, hs := range result {
+		index[hs.Name] = i
+	}
+
+	for _, hs := range cycleSkills {
+		if hs.MutualScore == 0 {
+			continue
+		}
+		if i, found := index[hs.Name]; found {
+			result[i].CurrentLevel = hs.MutualScore
+			continue
+		}
+
+		levels := []user.SkillLevel{}
+		for _, l := range hs.SkillLevels {
+			levels = append(levels, user.SkillLevel{Level: l.Level, LevelDescription: l.LevelDescription})
+		}
+		index[hs.Name] = len(result)
+		result = append(result, user.MyHardSkill{
+			Name:         hs.Name,
+			Description:  hs.Description,
+			CurrentLevel: hs.MutualScore,
+			SkillLevel:   levels,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,39 @@
+package cycle
+
+import (
+	"testing"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyMutualScores(t *testing.T) {
+	userSkills := []user.MyHardSkill{
+		{Name: "CSS", CurrentLevel: 2, Sort: 1},
+		{Name: "HTML", CurrentLevel: 3, Sort: 0},
+	}
+	cycleSkills := []HardSkill{
+		{Name: "CSS", MutualScore: 3},
+		{Name: "HTML", LeadScore: 4},
+		{Name: "Go", Description: "language", MutualScore: 1, SkillLevels: []SkillLevel{{Level: 1, LevelDescription: "basic"}}},
+	}
+
+	want := []user.MyHardSkill{
+		{Name: "CSS", CurrentLevel: 3, Sort: 1},
+		{Name: "HTML", CurrentLevel: 3, Sort: 0},
+		{Name: "Go", Description: "language", CurrentLevel: 1, SkillLevel: []user.SkillLevel{{Level: 1, LevelDescription: "basic"}}},
+	}
+
+	t.Run("should set levels to mutual scores and add new skills", func(t *testing.T) {
+		got := ApplyMutualScores(userSkills, cycleSkills)
+
+		assert.Equal(t, want, got)
+		assert.Equal(t, 2, userSkills[0].CurrentLevel, "input must not be changed")
+	})
+
+	t.Run("should give the same result when applied twice", func(t *testing.T) {
+		got := ApplyMutualScores(ApplyMutualScores(userSkills, cycleSkills), cycleSkills)
+
+		assert.Equal(t, want, got)
+	})
+}
@@ -2,33 +2,31 @@ package cycle
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strconv"
+	"slices"
 	"time"
 
 	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/user"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type Storage interface {
-	UpdateByID(id string, updateCycle Cycle) error
-	UpdateByIDSave(id string, updateCycle CycleSave) error
-	InsertOne(cy CycleInput, mail string) (*Cycle, error)
-	GetAllFromReceiverEmail(status string, email string, page int) ([]*Cycle, error)
+	GetAllFromReceiverEmail(ctx context.Context, status string, email string, page PageRequest) (*Page[*NewCycle], error)
 	UpdateUserFinalScore(id string) error
-	GetFromUserEmail(email string) ([]*Cycle, error)
-	GetByID(id string) (*Cycle, error)
-	DeleteOne(id string) error
-	ToDisplayFormat(cy *Cycle) (CycleDisplay, error)
-	ToDisplayFormatAll(cycles []*Cycle) []*CycleDisplay
-	GetUserDetailWithEmail(cycles []*CycleDisplay) []*CycleWithUserDetail
+	GetFromUserEmail(ctx context.Context, email string, page PageRequest) (*Page[*NewCycle], error)
 	GetNewByID(id string) (*NewCycle, error)
 	ToNewUserDetailFormat(cy *NewCycle) (*NewCycleWithUserDetail, error)
+	ToNewUserDetailFormatAll(cycles []*NewCycle) ([]*NewCycleWithUserDetail, error)
 	GetLatestCycleFromUserEmail(email string) (*NewCycle, error)
-	UpdateHardSkillsByEmail(ctx context.Context, email string, goalSkillRequest UpdateGoalSkillsRequest) (*NewCycle, error)
-	// UpdateByEmail(email string, updateCycle UpdateGoalSkillsRequest) error
-	// GetFromUserEmailNewCycle(email string) (*NewCycle, error)
+	UpdateHardSkillsByEmail(ctx context.Context, email string, version int, goalSkillRequest UpdateGoalSkillsRequest) (*NewCycle, error)
+	UpdateNewByID(ctx context.Context, id string, from string, version int, cy NewCycle, email string) (*NewCycle, error)
+	UpdateNewStatusByID(ctx context.Context, id string, version int, status string, state string, email string) (*NewCycle, error)
+	GetEventsByCycleID(ctx context.Context, id string) ([]CycleEvent, error)
+	GetUsersHardSkillByEmail(ctx context.Context, email string) (*user.User, error)
+	GetGoalPolicy(ctx context.Context, jobRole string, level string) (*GoalPolicy, error)
 }
 
 type cycleHandler struct {
@@ -48,8 +46,10 @@ func (e cycleHandlerError) Error() string {
 	return e.message
 }
 
-var numberOfSkillError = cycleHandlerError{message: "each type of skill should have atleast one"}
 var invalidInsertOneInputError = cycleHandlerError{message: "invalid or missing required field"}
+var notCycleOwnerError = cycleHandlerError{message: "only the ariser and team lead of this cycle can access it"}
+var notCycleFinaliserError = cycleHandlerError{message: "only the team lead of this cycle can apply its final scores"}
+var unknownGoalSkillError = cycleHandlerError{message: "goals can only be set on the hard skills of the cycle"}
 
 func NewCycleHandler(st Storage) *cycleHandler {
 	return &cycleHandler{
@@ -57,74 +57,61 @@ func NewCycleHandler(st Storage) *cycleHandler {
 	}
 }
 
-// InsertOne godoc
+// UpdateCycle godoc
 //
-//	@summary		InsertOne
-//	@description	Insert new Cycles
+//	@summary		UpdateByID
+//	@description	Update the period and the goals of the hard skills of a cycle. Scores are kept as they are and the goals must follow the goal policy of the ariser's job role and level. A status change has to be a legal move of the cycle state machine.
 //	@tags			cycle
-//	@id				InsertOneCycle
+//	@id				UpdateByID
 //	@security		BearerAuth
 //	@accept			json
 //	@produce		json
-//	@param			reqJson	body		CycleInput			true	"Cycle input Object"
-//	@response		200		{array}		cycle.CycleDisplay	"OK"
-//	@response		400		{object}	app.Response		"Bad Request"
-//	@response		401		{object}	app.Response		"Unauthorized"
-//	@response		404		{object}	app.Response		"Not Found"
-//	@response		500		{object}	app.Response		"Internal Server Error"
-//	@router			/cycles [post]
-func (h *cycleHandler) InsertOne(c app.Context) {
-	mail := c.GetString("email")
-	var insert CycleInput
-
-	// It'll return EOF error if your POST with wrong BODY format.
-	err := c.Bind(&insert)
-	if err != nil {
-		c.BadRequest(invalidInsertOneInputError)
-		return
-	}
-
-	// User have to select atleast 1 quantitive skill.
-	if len(insert.QuantitativeSkill) < 1 && len(insert.IntuitiveSkill) < 1 {
-		c.BadRequest(numberOfSkillError)
-		return
-	}
-
-	res, err := h.storage.InsertOne(insert, mail)
-
-	if err != nil {
-		c.InternalServerError(err)
-		return
-	}
-
-	toDisplay, err := h.storage.ToDisplayFormat(res)
-	if err != nil {
-		c.InternalServerError(err)
-		return
-	}
-
-	c.OK(toDisplay)
+//	@param			id			path		string				true	"Cycle ID"
+//	@param			If-Match	header		string				true	"ETag of the cycle"
+//	@param			reqJson		body		UpdateCycleRequest	true	"Cycle input Object"
+//	@response		200			{object}	cycle.NewCycle		"OK"
+//	@response		400			{object}	app.Response		"Bad Request"
+//	@response		401			{object}	app.Response		"Unauthorized"
+//	@response		403			{object}	app.Response		"Not the ariser or team lead of the cycle"
+//	@response		404			{object}	app.Response		"Not Found"
+//	@response		409			{object}	app.Response		"Illegal status transition"
+//	@response		412			{object}	app.Response		"Cycle was changed since it was read"
+//	@response		422			{object}	app.Response		"Goals break the goal policy, data lists each cycle.PolicyViolation"
+//	@response		428			{object}	app.Response		"If-Match is missing"
+//	@response		500			{object}	app.Response		"Internal Server Error"
+//	@router			/cycles/{id}  [post]
+func (cy *cycleHandler) UpdateByID(c app.Context) {
+	cy.update(c, true)
 }
 
-// UpdateCycle godoc
+// UpdateByIDSave godoc
 //
-//	@summary		UpdateByID
-//	@description	Update cycle with specific ID
+//	@summary		UpdateByIDSave
+//	@description	Save the period and the goals of the hard skills of a cycle without touching its status or scores. The goals must follow the goal policy of the ariser's job role and level.
 //	@tags			cycle
-//	@id				UpdateByID
+//	@id				UpdateByIDSave
 //	@security		BearerAuth
 //	@accept			json
 //	@produce		json
-//	@param			reqJson	body		Cycle				true	"Cycle input Object"
-//	@response		200		{array}		cycle.CycleDisplay	"OK"
-//	@response		400		{object}	app.Response		"Bad Request"
-//	@response		401		{object}	app.Response		"Unauthorized"
-//	@response		404		{object}	app.Response		"Not Found"
-//	@response		500		{object}	app.Response		"Internal Server Error"
-//	@router			/cycles/{id}  [post]
-func (cy *cycleHandler) UpdateByID(c app.Context) {
+//	@param			id			path		string				true	"Cycle ID"
+//	@param			If-Match	header		string				true	"ETag of the cycle"
+//	@param			reqJson		body		UpdateCycleRequest	true	"Cycle input Object"
+//	@response		200			{object}	cycle.NewCycle		"OK"
+//	@response		400			{object}	app.Response		"Bad Request"
+//	@response		403			{object}	app.Response		"Not the ariser or team lead of the cycle"
+//	@response		404			{object}	app.Response		"Not Found"
+//	@response		412			{object}	app.Response		"Cycle was changed since it was read"
+//	@response		422			{object}	app.Response		"Goals break the goal policy, data lists each cycle.PolicyViolation"
+//	@response		428			{object}	app.Response		"If-Match is missing"
+//	@response		500			{object}	app.Response		"Internal Server Error"
+//	@router			/cycles/save/{id}  [post]
+func (cy *cycleHandler) UpdateByIDSave(c app.Context) {
+	cy.update(c, false)
+}
+
+func (cy *cycleHandler) update(c app.Context, withStatus bool) {
 	id := c.Param("id")
-	var json Cycle
+	var json UpdateCycleRequest
 
 	err := c.ShouldBindJSON(&json)
 
@@ -132,137 +119,205 @@ func (cy *cycleHandler) UpdateByID(c app.Context) {
 		c.BadRequest(err)
 		return
 	}
-	err = cy.storage.UpdateByID(id, json)
+	version, ok := app.IfMatch(c)
+	if !ok {
+		return
+	}
 
+	current, err := cy.storage.GetNewByID(id)
 	if err != nil {
+		if err == cycleNotFoundError {
+			c.NotFound(err)
+			return
+		}
 		c.InternalServerError(err)
 		return
 	}
-	// TODO: Implement function GetByID
-	res, err := cy.storage.GetByID(id)
-	if err != nil {
-		c.InternalServerError(err)
+
+	email := c.GetString("email")
+	if ActorOf(current, email) == "" {
+		c.Forbidden(notCycleOwnerError)
 		return
 	}
+	if current.Version != version {
+		c.PreconditionFailed(cycleVersionChangedError)
+		return
+	}
+	updated := *current
+	updated.StartDate = json.StartDate
+	updated.EndDate = json.EndDate
+	if json.HardSkills != nil {
+		updated.HardSkills, err = withGoals(current.HardSkills, json.HardSkills)
+		if err != nil {
+			c.BadRequest(err)
+			return
+		}
+		if !cy.followsGoalPolicy(c, current.AriserMail, updated.HardSkills) {
+			return
+		}
+	}
+	if withStatus && json.Status != "" && json.Status != current.Status {
+		state, err := Transition(current.Status, json.Status, ActorOf(current, email))
+		if err != nil {
+			c.Conflict(err)
+			return
+		}
+		updated.Status = json.Status
+		updated.State = state
+	}
 
-	toDisplay, err := cy.storage.ToDisplayFormat(res)
+	res, err := cy.storage.UpdateNewByID(c.Ctx(), id, current.Status, version, updated, email)
 	if err != nil {
+		if err == cycleVersionChangedError {
+			c.PreconditionFailed(err)
+			return
+		}
 		c.InternalServerError(err)
 		return
 	}
 
-	c.OK(toDisplay)
+	c.Header("ETag", app.ETag(res.Version))
+	c.OK(res)
 }
 
-func (cy *cycleHandler) UpdateByIDSave(c app.Context) {
-	id := c.Param("id")
-	var json CycleSave
-	// var json map[string]interface{}
-
-	err := c.ShouldBindJSON(&json)
-
+// followsGoalPolicy checks the goals against the goal policy of the ariser,
+// answering 422 with the violations when they break it.
+func (cy *cycleHandler) followsGoalPolicy(c app.Context, ariserMail string, hardSkills []HardSkill) bool {
+	ariser, err := cy.storage.GetUsersHardSkillByEmail(c.Ctx(), ariserMail)
 	if err != nil {
-		c.BadRequest(err)
-		return
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.NotFound(err)
+			return false
+		}
+		c.InternalServerError(err)
+		return false
 	}
-	// delete(json, "status")
-
-	err = cy.storage.UpdateByIDSave(id, json)
-
+	policy, err := cy.storage.GetGoalPolicy(c.Ctx(), ariser.JobRole, ariser.Level)
 	if err != nil {
 		c.InternalServerError(err)
-		return
+		return false
 	}
-	// TODO: Implement function GetByID
-	res, _ := cy.storage.GetByID(id)
-	toDisplay, _ := cy.storage.ToDisplayFormat(res)
-	c.OK(toDisplay)
+
+	var policyErr GoalPolicyError
+	if err := policy.checkGoals(hardSkills); errors.As(err, &policyErr) {
+		c.UnprocessableEntity(policyErr.Violations, err)
+		return false
+	}
+	return true
+}
+
+// withGoals copies the requested goals onto the hard skills of the cycle,
+// keeping everything else, scores included, as it is stored.
+func withGoals(current []HardSkill, goals []GoalRequest) ([]HardSkill, error) {
+	hardSkills := slices.Clone(current)
+	for _, goal := range goals {
+		i := slices.IndexFunc(hardSkills, func(hs HardSkill) bool { return hs.Name == goal.Name })
+		if i < 0 {
+			return nil, unknownGoalSkillError
+		}
+		hardSkills[i].GoalScore = goal.GoalScore
+	}
+	return hardSkills, nil
 }
 
 // GetAllFromReceiverEmail godoc
 //
 //	@summary		GetAllFromReceiverEmail
-//	@description	Get Cycles by sending status and page and receiver email (in context)
+//	@description	Get a page of the cycles the team lead (in context) reviews, only those with the status unless it is All
 //	@tags			cycle
 //	@id				GetAllFromReceiverEmail
 //	@security		BearerAuth
 //	@accept			json
 //	@produce		json
-//	@response		200	{array}		cycle.CycleDisplay	"OK"
-//	@response		400	{object}	app.Response		"Bad Request"
-//	@response		401	{object}	app.Response		"Unauthorized"
-//	@response		404	{object}	app.Response		"Not Found"
-//	@response		500	{object}	app.Response		"Internal Server Error"
-//	@router			/cycles/email/{status}/{page} [get]
+//	@param			status	path		string												true	"Cycle status or All"
+//	@param			cursor	query		string												false	"nextCursor of the previous page"
+//	@param			limit	query		int													false	"Page size, 10 by default and 100 at most"
+//	@param			sort	query		string												false	"startDate (default), endDate or status"
+//	@param			order	query		string												false	"desc (default) or asc"
+//	@response		200		{object}	cycle.Page[cycle.NewCycleWithUserDetail]	"OK"
+//	@response		400		{object}	app.Response										"Bad Request"
+//	@response		401		{object}	app.Response										"Unauthorized"
+//	@response		450		{object}	app.Response										"Store Error"
+//	@router			/cycles/email/{status} [get]
 func (cy *cycleHandler) GetAllFromReceiverEmail(c app.Context) {
-	status, page := c.Param("status"), c.Param("page")
+	status := c.Param("status")
 	email := c.GetString("email")
 
-	pageNO, _ := strconv.Atoi(page)
-	if pageNO < 1 {
+	if err := ValidateEmail(email); err != nil {
 		c.BadRequest(invalidRequestError)
 		return
 	}
 
-	if err := ValidateEmail(email); err != nil {
+	if !validateCycleStatus(status) {
 		c.BadRequest(invalidRequestError)
 		return
 	}
 
-	if !validateCycleStatus(status) {
-		c.BadRequest(invalidRequestError)
+	page, ok := pageRequest(c)
+	if !ok {
 		return
 	}
 
-	res, err := cy.storage.GetAllFromReceiverEmail(status, email, pageNO)
+	res, err := cy.storage.GetAllFromReceiverEmail(c.Ctx(), status, email, page)
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+	result, err := cy.storage.ToNewUserDetailFormatAll(res.Items)
 	if err != nil {
 		c.StoreError(err)
 		return
 	}
-	result := cy.storage.ToDisplayFormatAll(res)
-
-	// fmt.Println("result: ", result[0])
 
-	c.OK(cy.storage.GetUserDetailWithEmail(result))
+	c.OK(withItems(res, result))
 }
 
 // GetFromUserEmail godoc
 //
 //	@summary		GetFromUserEmail
-//	@description	Get Cycles by from User email (In context)
+//	@description	Get a page of the cycles of the user (in context)
 //	@tags			cycle
 //	@id				GetFromUserEmail
 //	@security		BearerAuth
 //	@accept			json
 //	@produce		json
-//	@response		200	{object}	cycle.CycleDisplay	"Cycle retrieved successfully."
-//	@response		400	{object}	app.Response		"Invalid request format or data missing."
-//	@response		401	{object}	app.Response		"Authorization failed. Please provide a valid token."
-//	@response		404	{object}	app.Response		"Cycle not found with the specified ID."
-//	@response		500	{object}	app.Response		"An internal server error occurred while processing the request."
+//	@param			cursor	query		string							false	"nextCursor of the previous page"
+//	@param			limit	query		int								false	"Page size, 10 by default and 100 at most"
+//	@param			sort	query		string							false	"startDate (default), endDate or status"
+//	@param			order	query		string							false	"desc (default) or asc"
+//	@response		200		{object}	cycle.Page[cycle.NewCycle]	"Cycle retrieved successfully."
+//	@response		400		{object}	app.Response					"Invalid request format or data missing."
+//	@response		401		{object}	app.Response					"Authorization failed. Please provide a valid token."
+//	@response		450		{object}	app.Response					"Store Error"
 //	@router			/cycles/email/user [get]
 func (cy *cycleHandler) GetAllFromUserEmail(c app.Context) {
 	email := c.GetString("email")
-	res, err := cy.storage.GetFromUserEmail(email)
+	page, ok := pageRequest(c)
+	if !ok {
+		return
+	}
+
+	res, err := cy.storage.GetFromUserEmail(c.Ctx(), email, page)
 	if err != nil {
 		c.StoreError(err)
 		return
 	}
 
-	c.OK(cy.storage.ToDisplayFormatAll(res))
+	c.OK(res)
 }
 
 // @summary		Get a cycle by ID
-// @description	Retrieves a cycle by its unique identifier.
+// @description	Retrieves a cycle by its unique identifier. Only the ariser and team lead of the cycle can read it.
 // @tags			cycle
 // @id				GetOneCycleByID
 // @security		BearerAuth
 // @accept			json
 // @produce		json
 // @param			id	path		string			true	"Cycle ID"
-// @response		200	{object}	cycle.Cycle		"Cycle retrieved successfully."
+// @response		200	{object}	cycle.NewCycleWithUserDetail	"Cycle retrieved successfully."
 // @response		400	{object}	app.Response	"Invalid request format or data missing."
 // @response		401	{object}	app.Response	"Authorization failed. Please provide a valid token."
+// @response		403	{object}	app.Response	"Not the ariser or team lead of the cycle."
 // @response		404	{object}	app.Response	"Cycle not found with the specified ID."
 // @response		500	{object}	app.Response	"An internal server error occurred while processing the request."
 // @router			/cycles/{id} [get]
@@ -282,49 +337,19 @@ func (h *cycleHandler) GetOneByID(c app.Context) {
 		c.InternalServerError(err)
 		return
 	}
-
-	toUserDetail, err := h.storage.ToNewUserDetailFormat(res)
-	if err != nil {
-		c.InternalServerError(err)
+	if ActorOf(res, c.GetString("email")) == "" {
+		c.Forbidden(notCycleOwnerError)
 		return
 	}
 
-	c.OK(toUserDetail)
-}
-
-// DeleteByID godoc
-//
-//	@summary		DeleteByID
-//	@description	Delete Cycles by cycles ID
-//	@tags			cycle
-//	@id				DeleteByID
-//	@security		BearerAuth
-//	@accept			json
-//	@produce		json
-//	@param			squadID	path		string			true	"Squad ID"
-//	@response		200		{object}	app.Response	"nil"
-//	@response		400		{object}	app.Response	"invalid cycle id"
-//	@response		404		{object}	app.Response	"cycle not found"
-//	@response		500		{object}	app.Response	"Internal Server Error"
-//	@router			/cycles/{id} [delete]
-func (h *cycleHandler) DeleteByID(c app.Context) {
-	id := c.Param("id")
-	err := h.storage.DeleteOne(id)
+	toUserDetail, err := h.storage.ToNewUserDetailFormat(res)
 	if err != nil {
-		if err == invalidRequestError {
-			c.BadRequest(err)
-			return
-		}
-		if err == cycleNotFoundError {
-			c.NotFound(err)
-			return
-		}
-
 		c.InternalServerError(err)
 		return
 	}
 
-	c.OK(nil)
+	c.Header("ETag", app.ETag(res.Version))
+	c.OK(toUserDetail)
 }
 
 // GetCycleProgess godoc
@@ -339,30 +364,33 @@ func (h *cycleHandler) DeleteByID(c app.Context) {
 //	@param			cycleId	path		string			true	"Cycle Id"
 //	@response		200		{object}	app.Response	"nil"
 //	@response		400		{object}	app.Response	"invalid cycle id"
+//	@response		403		{object}	app.Response	"Not the ariser or team lead of the cycle"
 //	@response		404		{object}	app.Response	"cycle not found"
 //	@response		500		{object}	app.Response	"Internal Server Error"
 //	@router			/cycles/progress/{id} [get]
 func (u *cycleHandler) GetCycleProgess(c app.Context) {
 	id := c.Param("id")
-	cycle, err := u.storage.GetByID(id)
+	cycle, err := u.storage.GetNewByID(id)
 	if err != nil {
+		if err == cycleNotFoundError {
+			c.NotFound(err)
+			return
+		}
 		c.InternalServerError(err)
 		return
 	}
-
-	cycleReponse, err := u.storage.ToDisplayFormat(cycle)
-	if err != nil {
-		c.InternalServerError(err)
+	if ActorOf(cycle, c.GetString("email")) == "" {
+		c.Forbidden(notCycleOwnerError)
 		return
 	}
 
-	endDate := cycleReponse.EndDate
+	endDate := cycle.EndDate
 	var cycleProgress []GetCycleProgress
-	for _, cycle := range cycleReponse.QuantitativeSkill {
+	for _, hs := range cycle.HardSkills {
 		cycleProgress = append(cycleProgress, GetCycleProgress{
-			Name:          cycle.Name,
-			PersonalScore: cycle.PersonalScore,
-			GoalScore:     cycle.LeadGoalScore,
+			Name:          hs.Name,
+			PersonalScore: hs.PersonalScore,
+			GoalScore:     hs.GoalScore,
 			EndDate:       endDate.Format(time.RFC3339),
 		})
 	}
@@ -372,7 +400,7 @@ func (u *cycleHandler) GetCycleProgess(c app.Context) {
 // UpdateUserFinalScore godoc
 //
 //	@summary		UpdateUserFinalScore
-//	@description	Update user score by Score in cycle.
+//	@description	Apply the mutual scores of a done cycle to the ariser's hard-skill levels. Reaching Done does it already; this retries it and is a no-op once applied. Only the team lead of the cycle can do it.
 //	@tags			cycle
 //	@id				UpdateUserFinalScore
 //	@security		BearerAuth
@@ -381,7 +409,9 @@ func (u *cycleHandler) GetCycleProgess(c app.Context) {
 //	@param			cycleId	path		string			true	"CycleId"
 //	@response		200		{object}	app.Response	"nil"
 //	@response		400		{object}	app.Response	"invalid cycle id"
+//	@response		403		{object}	app.Response	"Not the team lead of the cycle"
 //	@response		404		{object}	app.Response	"cycle not found"
+//	@response		409		{object}	app.Response	"cycle is not done"
 //	@response		500		{object}	app.Response	"Internal Server Error"
 //	@router			/cycles/update/{id} [post]
 func (cy *cycleHandler) UpdateUserFinalScore(c app.Context) {
@@ -391,8 +421,29 @@ func (cy *cycleHandler) UpdateUserFinalScore(c app.Context) {
 		return
 	}
 
-	err := cy.storage.UpdateUserFinalScore(id)
+	current, err := cy.storage.GetNewByID(id)
 	if err != nil {
+		if err == cycleNotFoundError {
+			c.NotFound(err)
+			return
+		}
+		c.InternalServerError(err)
+		return
+	}
+	if ActorOf(current, c.GetString("email")) != ActorTeamLeader {
+		c.Forbidden(notCycleFinaliserError)
+		return
+	}
+
+	if err := cy.storage.UpdateUserFinalScore(id); err != nil {
+		if err == cycleNotFoundError {
+			c.NotFound(err)
+			return
+		}
+		if err == cycleNotDoneError {
+			c.Conflict(err)
+			return
+		}
 		c.StoreError(err)
 		return
 	}
@@ -403,17 +454,21 @@ func (cy *cycleHandler) UpdateUserFinalScore(c app.Context) {
 // UpdateHardSkillsByEmail godoc
 //
 //	@Summary		Update Hard Skills for a User's Active Cycle
-//	@Description	Inserts Hard Skills into the existing cycle for the specified user.
+//	@Description	Sets the goals on the hard skills of the latest cycle of the user, only the goal score of each skill is taken. The goals must follow the goal policy of the user's job role and level. If-Match must hold the ETag of the cycle as last read.
 //	@Tags			cycle
 //	@ID				UpdateHardSkillsByEmail
 //	@Security		BearerAuth
 //	@Accept			json
 //	@Produce		json
-//	@Param			reqJson	body		UpdateGoalSkillsRequest	true	"Hard Skills input"
-//	@Success		200		{object}	app.Response			"Successful operation"
-//	@Failure		400		{object}	app.Response			"Error marshaling JSON or mismatched goal score"
-//	@Failure		404		{object}	app.Response			"Cycle not found"
-//	@Failure		500		{object}	app.Response			"Internal Server Error"
+//	@Param			If-Match	header		string					true	"ETag of the cycle"
+//	@Param			reqJson		body		UpdateGoalSkillsRequest	true	"Hard Skills input"
+//	@Success		200			{object}	app.Response			"Successful operation"
+//	@Failure		400			{object}	app.Response			"Error marshaling JSON or a skill the cycle does not have"
+//	@Failure		404			{object}	app.Response			"Cycle not found"
+//	@Failure		412			{object}	app.Response			"Cycle was changed since it was read"
+//	@Failure		422			{object}	app.Response			"Goals break the goal policy, data lists each cycle.PolicyViolation"
+//	@Failure		428			{object}	app.Response			"If-Match is missing"
+//	@Failure		500			{object}	app.Response			"Internal Server Error"
 //	@Router			/cycles/goal [put]
 func (cy *cycleHandler) UpdateHardSkillsByEmail(c app.Context) {
 	email := c.GetString("email")
@@ -425,19 +480,145 @@ func (cy *cycleHandler) UpdateHardSkillsByEmail(c app.Context) {
 		return
 	}
 
-	for _, hardSkill := range skillJson.HardSkills {
-		if hardSkill.PersonalScore+1 < hardSkill.GoalScore || hardSkill.PersonalScore > hardSkill.GoalScore {
-			c.BadRequest(fmt.Errorf("miss match goal-score"))
+	version, ok := app.IfMatch(c)
+	if !ok {
+		return
+	}
+
+	res, err := cy.storage.UpdateHardSkillsByEmail(c.Ctx(), email, version, skillJson)
+	if err != nil {
+		if err == unknownGoalSkillError {
+			c.BadRequest(err)
+			return
+		}
+		if err == cycleVersionChangedError {
+			c.PreconditionFailed(err)
+			return
+		}
+		if errors.As(err, &CycleTransitionError{}) {
+			c.Conflict(err)
+			return
+		}
+		var policyErr GoalPolicyError
+		if errors.As(err, &policyErr) {
+			c.UnprocessableEntity(policyErr.Violations, err)
+			return
+		}
+		c.StoreError(err)
+		return
+	}
+	c.Header("ETag", app.ETag(res.Version))
+	c.OK(map[string]string{})
+}
+
+// UpdateStatusByID godoc
+//
+//	@summary		UpdateStatusByID
+//	@description	Move a cycle to another status. Only moves listed in the cycle state machine are allowed, and only for the ariser or team lead they belong to. If-Match must hold the ETag of the cycle as last read.
+//	@tags			cycle
+//	@id				UpdateStatusByID
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			id			path		string				true	"Cycle ID"
+//	@param			If-Match	header		string				true	"ETag of the cycle"
+//	@param			reqJson		body		UpdateStatusRequest	true	"New status"
+//	@response		200			{object}	cycle.NewCycle		"OK"
+//	@response		400			{object}	app.Response		"Bad Request"
+//	@response		404			{object}	app.Response		"Cycle not found"
+//	@response		409			{object}	app.Response		"Illegal status transition"
+//	@response		412			{object}	app.Response		"Cycle was changed since it was read"
+//	@response		428			{object}	app.Response		"If-Match is missing"
+//	@response		450			{object}	app.Response		"Store Error"
+//	@response		500			{object}	app.Response		"Internal Server Error"
+//	@router			/cycles/{id}/status [put]
+func (cy *cycleHandler) UpdateStatusByID(c app.Context) {
+	id := c.Param("id")
+
+	var req UpdateStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.BadRequest(invalidRequestError)
+		return
+	}
+	version, ok := app.IfMatch(c)
+	if !ok {
+		return
+	}
+
+	current, err := cy.storage.GetNewByID(id)
+	if err != nil {
+		if err == cycleNotFoundError {
+			c.NotFound(err)
 			return
 		}
+		c.InternalServerError(err)
+		return
 	}
 
-	_, err = cy.storage.UpdateHardSkillsByEmail(c.Ctx(), email, skillJson)
+	email := c.GetString("email")
+	state, err := Transition(current.Status, req.Status, ActorOf(current, email))
 	if err != nil {
+		c.Conflict(err)
+		return
+	}
+	if current.Version != version {
+		c.PreconditionFailed(cycleVersionChangedError)
+		return
+	}
+
+	res, err := cy.storage.UpdateNewStatusByID(c.Ctx(), id, version, req.Status, state, email)
+	if err != nil {
+		if err == cycleVersionChangedError {
+			c.PreconditionFailed(err)
+			return
+		}
 		c.StoreError(err)
 		return
 	}
-	c.OK(map[string]string{})
+
+	c.Header("ETag", app.ETag(res.Version))
+	c.OK(res)
+}
+
+// HistoryByID godoc
+//
+//	@summary		HistoryByID
+//	@description	Get every recorded change of a cycle, oldest first. Only the ariser and team lead of the cycle can read it.
+//	@tags			cycle
+//	@id				HistoryByID
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			id	path		string				true	"Cycle ID"
+//	@response		200	{array}		cycle.CycleEvent	"OK"
+//	@response		400	{object}	app.Response		"Bad Request"
+//	@response		403	{object}	app.Response		"Not the ariser or team lead of the cycle"
+//	@response		404	{object}	app.Response		"Cycle not found"
+//	@response		500	{object}	app.Response		"Internal Server Error"
+//	@router			/cycles/{id}/history [get]
+func (cy *cycleHandler) HistoryByID(c app.Context) {
+	id := c.Param("id")
+	current, err := cy.storage.GetNewByID(id)
+	if err != nil {
+		if err == cycleNotFoundError {
+			c.NotFound(err)
+			return
+		}
+		c.BadRequest(err)
+		return
+	}
+	if ActorOf(current, c.GetString("email")) == "" {
+		c.Forbidden(notCycleOwnerError)
+		return
+	}
+
+	events, err := cy.storage.GetEventsByCycleID(c.Ctx(), id)
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(events)
 }
 
 // GetLatestCycleFromEmail
@@ -453,5 +634,6 @@ func (cy *cycleHandler) GetLatestCycleFromUserEmail(c app.Context) {
 		c.BadRequest(fmt.Errorf("unable to get cycle"))
 		return
 	}
+	c.Header("ETag", app.ETag(cycle.Version))
 	c.OK(cycle)
 }
@@ -7,7 +7,6 @@ import (
 	_ "io"
 	"net/http"
 	"net/http/httptest"
-	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -45,7 +44,6 @@ func TestCycleHandlerGetOneByID(t *testing.T) {
 					EndDate:        endDate,
 					HardSkills:     []HardSkill{},
 					Status:         "pending",
-					Comment:        "",
 				},
 			},
 			err: nil,
@@ -55,6 +53,9 @@ func TestCycleHandlerGetOneByID(t *testing.T) {
 		handler := NewCycleHandler(mockStorage)
 
 		engine := gin.New()
+		engine.Use(func(c *gin.Context) {
+			c.Set("email", "ariser@arise.tech")
+		})
 		engine.GET("/cycles/:id", app.NewGinHandler(handler.GetOneByID, zap.NewNop()))
 		rec := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodGet, "/cycles/"+cycleId.hexId, nil)
@@ -76,8 +77,7 @@ func TestCycleHandlerGetOneByID(t *testing.T) {
 				"startDate": "%s",
 				"endDate": "%s",
 				"status": "pending",
-				"state": "",
-				"comment": ""
+				"state": ""
 			}
 		}`, startDateFormatted, endDateFormatted)
 		assert.Equal(t, 200, rec.Code)
@@ -85,6 +85,39 @@ func TestCycleHandlerGetOneByID(t *testing.T) {
 		mockStorage.Verify(t)
 	})
 
+	t.Run("should return 403 when user is not the ariser or team lead of the cycle", func(t *testing.T) {
+		cycleId := mockObjectId(10)
+		mockStorage := &mockCycleStorage{
+			newCycles: []*NewCycle{
+				{
+					ID:             cycleId.objectId,
+					AriserMail:     "ariser@arise.tech",
+					TeamLeaderMail: "teamleader@arise.tech",
+				},
+			},
+		}
+		mockStorage.ExpectToCall("GetNewByID")
+		handler := NewCycleHandler(mockStorage)
+
+		engine := gin.New()
+		engine.Use(func(c *gin.Context) {
+			c.Set("email", "other@arise.tech")
+		})
+		engine.GET("/cycles/:id", app.NewGinHandler(handler.GetOneByID, zap.NewNop()))
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cycles/"+cycleId.hexId, nil)
+
+		engine.ServeHTTP(rec, req)
+
+		want := `{
+			"status":"error",
+			"message":"only the ariser and team lead of this cycle can access it"
+		}`
+		assert.Equal(t, 403, rec.Code)
+		assert.JSONEq(t, want, rec.Body.String())
+		mockStorage.Verify(t)
+	})
+
 	t.Run("should return 400 and data is nil when invalid Id", func(t *testing.T) {
 		cycleId := "55"
 		mockStorage := &mockCycleStorage{
@@ -172,30 +205,31 @@ func TestGetAllFromEmailWithMockContext(t *testing.T) {
 	testCases := []struct {
 		name          string
 		status        string
-		page          int
+		limit         string
 		email         string
-		cyclesReturn  []*Cycle
+		cyclesReturn  []*NewCycle
 		err           error
 		checkResponse func(t *testing.T, res interface{}, code int, storage *mockCycleStorage)
 	}{
 		{
 			name:         "Should return 200 Status OK when input correct request",
 			status:       StatusAll,
-			page:         1,
-			email:        expectCycles[0].ReceiverMail,
+			email:        expectCycles[0].TeamLeaderMail,
 			cyclesReturn: expectCycles,
 			err:          nil,
 			checkResponse: func(t *testing.T, res interface{}, code int, storage *mockCycleStorage) {
 				storage.Verify(t)
 				require.Equal(t, http.StatusOK, code)
-				require.Len(t, res, len(expectCycles))
+				page := res.(*Page[*NewCycleWithUserDetail])
+				require.Len(t, page.Items, len(expectCycles))
+				require.Equal(t, int64(len(expectCycles)), page.Total)
+				require.Equal(t, PageRequest{Limit: 10, Sort: SortStartDate, Desc: true}, storage.page)
 			},
 		},
 		{
 			name:         "Should return 450 Status StoreError when db is error",
 			status:       StatusAll,
-			page:         1,
-			email:        expectCycles[0].ReceiverMail,
+			email:        expectCycles[0].TeamLeaderMail,
 			cyclesReturn: nil,
 			err:          dbConnectNotFound,
 			checkResponse: func(t *testing.T, res interface{}, code int, storage *mockCycleStorage) {
@@ -205,21 +239,20 @@ func TestGetAllFromEmailWithMockContext(t *testing.T) {
 			},
 		},
 		{
-			name:         "Should return 400 Status BadRequest when page is not valid",
+			name:         "Should return 400 Status BadRequest when limit is not valid",
 			status:       StatusAll,
-			page:         -1,
-			email:        expectCycles[0].ReceiverMail,
+			limit:        "-1",
+			email:        expectCycles[0].TeamLeaderMail,
 			cyclesReturn: nil,
 			err:          invalidRequestError,
 			checkResponse: func(t *testing.T, res interface{}, code int, storage *mockCycleStorage) {
 				require.Equal(t, http.StatusBadRequest, code)
-				require.Equal(t, invalidRequestError, res)
+				require.Equal(t, invalidPageSizeError, res)
 			},
 		},
 		{
 			name:         "Should return 400 Status BadRequest when email is not valid",
 			status:       StatusAll,
-			page:         1,
 			email:        "not-valid",
 			cyclesReturn: nil,
 			err:          invalidRequestError,
@@ -231,8 +264,7 @@ func TestGetAllFromEmailWithMockContext(t *testing.T) {
 		{
 			name:         "Should return 400 Status BadRequest when status is not valid",
 			status:       "test",
-			page:         1,
-			email:        expectCycles[0].ReceiverMail,
+			email:        expectCycles[0].TeamLeaderMail,
 			cyclesReturn: nil,
 			err:          invalidRequestError,
 			checkResponse: func(t *testing.T, res interface{}, code int, storage *mockCycleStorage) {
@@ -246,7 +278,6 @@ func TestGetAllFromEmailWithMockContext(t *testing.T) {
 		tc := testCases[i]
 		t.Run(tc.name, func(t *testing.T) {
 			mockStorage := &mockCycleStorage{
-				cycles:       cycles,
 				cyclesReturn: tc.cyclesReturn,
 				err:          tc.err,
 			}
@@ -255,7 +286,9 @@ func TestGetAllFromEmailWithMockContext(t *testing.T) {
 			context := app.MockAppContext{
 				Params: map[string]string{
 					"status": tc.status,
-					"page":   strconv.Itoa(tc.page),
+				},
+				Queries: map[string]string{
+					"limit": tc.limit,
 				},
 				Email: tc.email,
 			}
@@ -266,279 +299,202 @@ func TestGetAllFromEmailWithMockContext(t *testing.T) {
 	}
 }
 
-// type CycleInput struct {
-// 	ReceiverMail string    `json:"receiverMail" bson:"receiver_mail"`
-// 	StartDate    time.Time `json:"startDate" bson:"start_date"`
-// 	EndDate      time.Time `json:"endDate" bson:"end_date"`
-// 	// QuantitiveSkill >= 1 skill
-// 	QuantitativeSkill []QuantitativeSkill `json:"quantitativeSkill" bson:"quantitative_skill"`
-// 	IntuitiveSkill    []IntuitiveSkill    `json:"intuitiveSkill" bson:"intuitive_skill"`
-// 	Comment           string              `json:"comment" bson:"comment"`
-// }
-
-//	func fmtJsonBody(body map[string]any) *bytes.Buffer {
-//		rsl, _ := json.Marshal(body)
-//		return bytes.NewBuffer(rsl)
-//	}
-func TestInsertOneCycle(t *testing.T) {
-	gin.SetMode("test")
-	t.Run("should return 200 when include is true", func(t *testing.T) {
-		reqBody :=
-			`{
-				"receiverMail":"prawith.a@arise.tech",
-				"startDate":"2023-11-01T00:00:00.000Z",
-				"endDate":"2023-11-30T00:00:00.000Z",
-				"quantitativeSkill":[
-					{
-							"id":"64e17f43e098346113ae4f53",
-							"personalScore":3,
-							"goalScore":5,
-							"finalScore":3,
-							"comment":""
-						},
-						{
-							"id":"64e17f43e098346113ae4f5c",
-							"personalScore":2,
-							"goalScore":3,
-							"finalScore":2,
-							"comment":""
-						}
-				],
-				"intuitiveSkill":[],
-				"comment":""
-			}`
-
-		mock := &mockCycleStorage{
-			cycle: &Cycle{},
+func randomCycles(n int) []*NewCycle {
+	var cycles []*NewCycle
+
+	recieverEmail := "ariser@arise.dev"
+	for i := 0; i < n; i++ {
+		cycle := NewCycle{
+			ID:             primitive.NewObjectID(),
+			AriserMail:     "ariser@arise.dev",
+			TeamLeaderMail: recieverEmail,
+			StartDate:      time.Now(),
+			EndDate:        time.Now().Add(24 * 5 * time.Hour),
+			HardSkills:     []HardSkill{},
+			Status:         randomCycleStatus(),
+			State:          StateReview,
 		}
-		handler := NewCycleHandler(mock)
 
-		engine := gin.New()
-		engine.POST("/cycles", app.NewGinHandler(handler.InsertOne, zap.NewNop()))
-		rec := httptest.NewRecorder()
+		cycles = append(cycles, &cycle)
+	}
 
-		req, _ := http.NewRequest(http.MethodPost, "/cycles", strings.NewReader(reqBody))
-		engine.ServeHTTP(rec, req)
+	return cycles
+}
 
-		resp := rec.Body.String()
-		want := `{
-			"status": "success",
-			"message": "",
-			"data": {
-				"comment":"",
-				"endDate":"0001-01-01T00:00:00Z",
-				"id":"000000000000000000000000",
-				"intuitiveSkill":[],
-				"quantitativeSkill":[],
-				"receiverMail":"",
-				"senderMail":"",
-				"startDate":"0001-01-01T00:00:00Z",
-				"status":"",
-				"state":""
-			}
-		}`
-		// fmt.Println(resp)
-		assert.Equal(t, 200, rec.Code)
-		assert.JSONEq(t, want, resp)
-	})
+func randomCycleStatus() string {
+	return StatusPending
+}
 
-	t.Run("Return 400 When Input in cycle is invalid", func(t *testing.T) {
-		reqBody :=
-			`{
-				"startDate":"2023-11-01T00:00:00.000Z",
-				"endDate":"2023-11-30T00:00:00.000Z",
-				"quantitativeSkill":[
-					{
-							"id":"64e17f43e098346113ae4f53",
-							"personalScore":3,
-							"goalScore":5,
-							"finalScore":3,
-							"comment":""
-						},
-						{
-							"id":"64e17f43e098346113ae4f5c",
-							"personalScore":2,
-							"goalScore":3,
-							"finalScore":2,
-							"comment":""
-						}
-				],
-				"intuitiveSkill":[],
-				"comment":""
-			}`
-
-		mock := &mockCycleStorage{
-			cycle: &Cycle{},
+func getExpectCycles(cycles []*NewCycle) []*NewCycle {
+	email := cycles[0].TeamLeaderMail // random email from cycles
+	var expectCycles []*NewCycle
+	for _, cy := range cycles {
+		if cy.TeamLeaderMail == email {
+			expectCycles = append(expectCycles, cy)
 		}
-		handler := NewCycleHandler(mock)
+	}
+
+	return expectCycles
+}
 
+func TestUpdateById(t *testing.T) {
+	t.Run("Return HTTP status 200 when update data by ID is working", func(t *testing.T) {
+		cycle := randomCycles(1)
+		cycle[0].HardSkills = []HardSkill{{Name: "CSS", PersonalScore: 2, GoalScore: 2, LeadScore: 3}}
+		reqJson := fmt.Sprintf(`{
+			"startDate":%q,
+			"endDate":%q,
+			"hardSkills":[{"name":"CSS","personalScore":5,"goalScore":3,"leadScore":5,"agreement":"accepted","mutualScore":5}],
+			"status":%q
+		}`, cycle[0].StartDate.Format(time.RFC3339), cycle[0].EndDate.Format(time.RFC3339), cycle[0].Status)
+
+		mockStorage := &mockCycleStorage{
+			newCycles: cycle,
+			err:       nil,
+		}
+		mockStorage.ExpectToCall("GetNewByID")
+		mockStorage.ExpectToCall("UpdateNewByID")
+		handler := NewCycleHandler(mockStorage)
 		engine := gin.New()
-		engine.POST("/cycles", app.NewGinHandler(handler.InsertOne, zap.NewNop()))
+		engine.Use(func(c *gin.Context) {
+			c.Set("email", cycle[0].AriserMail)
+		})
+
+		engine.POST("/cycles/:id", app.NewGinHandler(handler.UpdateByID, zap.NewNop()))
 		rec := httptest.NewRecorder()
 
-		req, _ := http.NewRequest(http.MethodPost, "/cycles", strings.NewReader(reqBody))
+		req, _ := http.NewRequest(http.MethodPost, "/cycles/"+cycle[0].ID.Hex(), strings.NewReader(reqJson))
+		req.Header.Set("If-Match", app.ETag(0))
+
 		engine.ServeHTTP(rec, req)
 
-		resp := rec.Body.String()
-		want := fmt.Sprintf(`{
-			"status": "error",
-			"message": "%s"
-		}`, invalidInsertOneInputError)
-		// fmt.Println(resp)
-		assert.Equal(t, 400, rec.Code)
-		assert.JSONEq(t, want, resp)
+		var resp struct {
+			Data NewCycle `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, 200, rec.Code)
+		assert.Equal(t, []HardSkill{{Name: "CSS", PersonalScore: 2, GoalScore: 3, LeadScore: 3}}, resp.Data.HardSkills)
+		assert.Equal(t, cycle[0].AriserMail, resp.Data.AriserMail)
+		mockStorage.Verify(t)
 	})
 
-	t.Run("should return 400 and message When input of Cycle is incorrect", func(t *testing.T) {
-		reqBody :=
-			`{
-				"receiverMail":"prawith.a@arise.tech",
-				"startDate":"2023-11-01T00:00:00.000Z",
-				"endDate":"2023-11-30T00:00:00.000Z",
-				"quantitativeSkill":[],
-				"intuitiveSkill":[],
-				"comment":""
-			}`
-
-		mock := &mockCycleStorage{
-			cycle: &Cycle{},
+	t.Run("Return HTTP status 400 when a goal is not a hard skill of the cycle", func(t *testing.T) {
+		cycle := randomCycles(1)
+		cycle[0].HardSkills = []HardSkill{{Name: "CSS", GoalScore: 2}}
+		reqBody := &UpdateCycleRequest{
+			StartDate:  cycle[0].StartDate,
+			EndDate:    cycle[0].EndDate,
+			HardSkills: []GoalRequest{{Name: "Kotlin", GoalScore: 3}},
 		}
-		handler := NewCycleHandler(mock)
+		reqJson, err := json.Marshal(reqBody)
+		require.NoError(t, err)
 
+		mockStorage := &mockCycleStorage{
+			newCycles: cycle,
+		}
+		mockStorage.ExpectToCall("GetNewByID")
+		handler := NewCycleHandler(mockStorage)
 		engine := gin.New()
-		engine.POST("/cycles", app.NewGinHandler(handler.InsertOne, zap.NewNop()))
+		engine.Use(func(c *gin.Context) {
+			c.Set("email", cycle[0].AriserMail)
+		})
+
+		engine.POST("/cycles/:id", app.NewGinHandler(handler.UpdateByID, zap.NewNop()))
 		rec := httptest.NewRecorder()
 
-		req, _ := http.NewRequest(http.MethodPost, "/cycles", strings.NewReader(reqBody))
+		req, _ := http.NewRequest(http.MethodPost, "/cycles/"+cycle[0].ID.Hex(), strings.NewReader(string(reqJson)))
+		req.Header.Set("If-Match", app.ETag(0))
+
 		engine.ServeHTTP(rec, req)
 
-		resp := rec.Body.String()
-		want := fmt.Sprintf(`{
-			"status": "error",
-			"message": "%s"
-		}`, numberOfSkillError)
-		// fmt.Println(resp)
 		assert.Equal(t, 400, rec.Code)
-		assert.JSONEq(t, want, resp)
+		assert.JSONEq(t, `{"status":"error","message":"goals can only be set on the hard skills of the cycle"}`, rec.Body.String())
+		assert.False(t, mockStorage.methodsToCall["UpdateNewByID"])
 	})
 
-	t.Run("should return 500 when storage is failed", func(t *testing.T) {
-		reqBody :=
-			`{
-				"receiverMail":"prawith.a@arise.tech",
-				"startDate":"2023-11-01T00:00:00.000Z",
-				"endDate":"2023-11-30T00:00:00.000Z",
-				"quantitativeSkill":[
-					{
-							"id":"64e17f43e098346113ae4f53",
-							"personalScore":3,
-							"goalScore":5,
-							"finalScore":3,
-							"comment":""
-						},
-						{
-							"id":"64e17f43e098346113ae4f5c",
-							"personalScore":2,
-							"goalScore":3,
-							"finalScore":2,
-							"comment":""
-						}
-				],
-				"intuitiveSkill":[],
-				"comment":""
-			}`
-
-		mock := &mockCycleStorage{
-			err: errors.New("Error from mongo"),
+	t.Run("Return HTTP status 422 when a goal breaks the goal policy", func(t *testing.T) {
+		cycle := randomCycles(1)
+		cycle[0].HardSkills = []HardSkill{{Name: "CSS", PersonalScore: 2, GoalScore: 2}}
+		reqBody := &UpdateCycleRequest{
+			StartDate:  cycle[0].StartDate,
+			EndDate:    cycle[0].EndDate,
+			HardSkills: []GoalRequest{{Name: "CSS", GoalScore: 5}},
 		}
-		handler := NewCycleHandler(mock)
-
-		engine := gin.New()
-		engine.POST("/cycles", app.NewGinHandler(handler.InsertOne, zap.NewNop()))
-		rec := httptest.NewRecorder()
-		// log.Println("463", rec)
-		req, _ := http.NewRequest(http.MethodPost, "/cycles", strings.NewReader(reqBody))
-		// log.Println("465", err)
-		engine.ServeHTTP(rec, req)
-		// log.Println(err, req, rec)
-		resp := rec.Body.String()
-		want := `{
-			"status": "error",
-			"message": "Error from mongo"
-		}`
+		reqJson, err := json.Marshal(reqBody)
+		require.NoError(t, err)
 
-		// log.Println(rec.Code)
-		assert.Equal(t, 500, rec.Code)
-		assert.JSONEq(t, want, resp)
-	})
-}
+		for _, path := range []string{"/cycles/", "/cycles/save/"} {
+			mockStorage := &mockCycleStorage{
+				newCycles: cycle,
+			}
+			mockStorage.ExpectToCall("GetNewByID")
+			handler := NewCycleHandler(mockStorage)
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", cycle[0].AriserMail)
+			})
 
-func TestCycleHandlerDeleteByID(t *testing.T) {
-	gin.SetMode(gin.TestMode)
-	t.Run("should return 200 and success delete", func(t *testing.T) {
-		cycleId := mockObjectId(24)
-		mockStorage := &mockCycleStorage{
-			err: nil,
-		}
-		mockStorage.ExpectToCall("DeleteByID")
-		handler := NewCycleHandler(mockStorage)
+			engine.POST("/cycles/:id", app.NewGinHandler(handler.UpdateByID, zap.NewNop()))
+			engine.POST("/cycles/save/:id", app.NewGinHandler(handler.UpdateByIDSave, zap.NewNop()))
+			rec := httptest.NewRecorder()
 
-		engine := gin.New()
-		engine.DELETE("/cycles/:id", app.NewGinHandler(handler.DeleteByID, zap.NewNop()))
-		rec := httptest.NewRecorder()
-		req, _ := http.NewRequest(http.MethodDelete, "/cycles/"+cycleId.hexId, nil)
+			req, _ := http.NewRequest(http.MethodPost, path+cycle[0].ID.Hex(), strings.NewReader(string(reqJson)))
+			req.Header.Set("If-Match", app.ETag(0))
 
-		engine.ServeHTTP(rec, req)
+			engine.ServeHTTP(rec, req)
 
-		resp := rec.Body.String()
-		want := `{
-			"status": "success",
-			"message": ""
-		}`
-		assert.Equal(t, 200, rec.Code)
-		assert.JSONEq(t, want, resp)
-		mockStorage.Verify(t)
+			assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, path)
+			assert.JSONEq(t, `{"status":"error","message":"goals break the goal-setting policy","data":[{"skill":"CSS","rule":"max_level_jump","message":"goal 5 is more than 1 level(s) above the current level 2"}]}`, rec.Body.String())
+			assert.False(t, mockStorage.methodsToCall["UpdateNewByID"], path)
+		}
 	})
 
-	t.Run("should return 400 when invalid Id", func(t *testing.T) {
+	t.Run("Return HTTP status 400 when user put invalid ID field or missing body", func(t *testing.T) {
 		cycleId := "dkls"
+
 		mockStorage := &mockCycleStorage{
 			err: invalidRequestError,
 		}
-		mockStorage.ExpectToCall("DeleteByID")
-
 		handler := NewCycleHandler(mockStorage)
-
 		engine := gin.New()
-		engine.DELETE("/cycles/:id", app.NewGinHandler(handler.DeleteByID, zap.NewNop()))
+
+		engine.POST("/cycles/:id", app.NewGinHandler(handler.UpdateByID, zap.NewNop()))
 		rec := httptest.NewRecorder()
-		req, _ := http.NewRequest(http.MethodDelete, "/cycles/"+cycleId, nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/cycles/"+cycleId, nil)
 
 		engine.ServeHTTP(rec, req)
 
 		resp := rec.Body.String()
-		want := fmt.Sprintf(`{
-				"status":"error",
-				"message":"%s"
-			}`, invalidRequestError.Error())
+		want := `{
+			"status":"error",
+			"message":"invalid request"
+		}`
 		assert.Equal(t, 400, rec.Code)
 		assert.JSONEq(t, want, resp)
-		mockStorage.Verify(t)
 	})
 
-	t.Run("should return 404 when not found match squad", func(t *testing.T) {
-		cycleId := mockObjectId(24)
+	t.Run("Return HTTP status 404 when cycle is not found", func(t *testing.T) {
+		cycle := randomCycles(1)
+		reqBody := &UpdateCycleRequest{
+			StartDate: cycle[0].StartDate,
+			EndDate:   cycle[0].EndDate,
+		}
+		reqJson, err := json.Marshal(reqBody)
+		require.NoError(t, err)
+
 		mockStorage := &mockCycleStorage{
 			err: cycleNotFoundError,
 		}
-		mockStorage.ExpectToCall("DeleteByID")
-
+		mockStorage.ExpectToCall("GetNewByID")
 		handler := NewCycleHandler(mockStorage)
-
 		engine := gin.New()
-		engine.DELETE("/cycles/:id", app.NewGinHandler(handler.DeleteByID, zap.NewNop()))
+
+		engine.POST("/cycles/:id", app.NewGinHandler(handler.UpdateByID, zap.NewNop()))
 		rec := httptest.NewRecorder()
-		req, _ := http.NewRequest(http.MethodDelete, "/cycles/"+cycleId.hexId, nil)
+
+		req, _ := http.NewRequest(http.MethodPost, "/cycles/"+"123", strings.NewReader(string(reqJson)))
+		req.Header.Set("If-Match", app.ETag(0))
 
 		engine.ServeHTTP(rec, req)
 
@@ -552,185 +508,122 @@ func TestCycleHandlerDeleteByID(t *testing.T) {
 		mockStorage.Verify(t)
 	})
 
-	t.Run("should return 500 intenal server error", func(t *testing.T) {
-		cycleId := mockObjectId(100)
-		mockStorage := &mockCycleStorage{
-			err: errors.New("error from storage"),
-		}
-		mockStorage.ExpectToCall("DeleteByID")
-
-		handler := NewCycleHandler(mockStorage)
-
-		engine := gin.New()
-		engine.DELETE("/cycles/:id", app.NewGinHandler(handler.DeleteByID, zap.NewNop()))
-		rec := httptest.NewRecorder()
-		req, _ := http.NewRequest(http.MethodDelete, "/cycles/"+cycleId.hexId, nil)
-
-		engine.ServeHTTP(rec, req)
-
-		resp := rec.Body.String()
-		want := `{
-			"status":"error",
-			"message":"error from storage"
-		}`
-		assert.Equal(t, 500, rec.Code)
-		assert.JSONEq(t, want, resp)
-		mockStorage.Verify(t)
-	})
-}
-
-func randomCycles(n int) []*Cycle {
-	var cycles []*Cycle
-
-	recieverEmail := "ariser@arise.dev"
-	for i := 0; i < n; i++ {
-		cycle := Cycle{
-			ID:                primitive.NewObjectID(),
-			SenderMail:        "ariser@arise.dev",
-			ReceiverMail:      recieverEmail,
-			StartDate:         time.Now(),
-			EndDate:           time.Now().Add(24 * 5 * time.Hour),
-			QuantitativeSkill: []QuantitativeSkill{},
-			IntuitiveSkill:    []IntuitiveSkill{},
-			Status:            randomCycleStatus(),
-		}
-
-		cycles = append(cycles, &cycle)
-	}
-
-	return cycles
-}
-
-func randomCycleStatus() string {
-	return StatusPending
-}
-
-func getExpectCycles(cycles []*Cycle) []*Cycle {
-	email := cycles[0].ReceiverMail // random email from cycles
-	var expectCycles []*Cycle
-	for _, cy := range cycles {
-		if cy.ReceiverMail == email {
-			expectCycles = append(expectCycles, cy)
-		}
-	}
-
-	return expectCycles
-}
-
-func TestUpdateById(t *testing.T) {
-	t.Run("Return HTTP status 200 when update data by ID is working", func(t *testing.T) {
+	t.Run("Return HTTP status 403 when user is not the ariser or team lead", func(t *testing.T) {
 		cycle := randomCycles(1)
-		reqBody := &Cycle{
-			ID:                cycle[0].ID,
-			SenderMail:        "updatetestById@arise.tech",
-			ReceiverMail:      cycle[0].ReceiverMail,
-			StartDate:         cycle[0].StartDate,
-			EndDate:           cycle[0].EndDate,
-			QuantitativeSkill: cycle[0].QuantitativeSkill,
-			IntuitiveSkill:    cycle[0].IntuitiveSkill,
-			Status:            cycle[0].Status,
-			Comment:           cycle[0].Comment,
+		reqBody := &UpdateCycleRequest{
+			StartDate: cycle[0].StartDate,
+			EndDate:   cycle[0].EndDate,
 		}
 		reqJson, err := json.Marshal(reqBody)
 		require.NoError(t, err)
 
 		mockStorage := &mockCycleStorage{
-			cycles: cycle,
-			err:    nil,
+			newCycles: cycle,
 		}
-		mockStorage.ExpectToCall("UpdateByID")
+		mockStorage.ExpectToCall("GetNewByID")
 		handler := NewCycleHandler(mockStorage)
 		engine := gin.New()
+		engine.Use(func(c *gin.Context) {
+			c.Set("email", "other@arise.tech")
+		})
 
 		engine.POST("/cycles/:id", app.NewGinHandler(handler.UpdateByID, zap.NewNop()))
 		rec := httptest.NewRecorder()
 
 		req, _ := http.NewRequest(http.MethodPost, "/cycles/"+cycle[0].ID.Hex(), strings.NewReader(string(reqJson)))
+		req.Header.Set("If-Match", app.ETag(0))
 
 		engine.ServeHTTP(rec, req)
 
-		assert.Equal(t, 200, rec.Code)
-		mockStorage.Verify(t)
+		assert.Equal(t, 403, rec.Code)
+		assert.False(t, mockStorage.methodsToCall["UpdateNewByID"])
 	})
-	t.Run("Return HTTP status 400 when user put invalid ID field or missing body", func(t *testing.T) {
-		cycleId := "dkls"
+
+	t.Run("Return HTTP status 409 when status move is not allowed", func(t *testing.T) {
+		cycle := randomCycles(1)
+		reqBody := &UpdateCycleRequest{
+			StartDate: cycle[0].StartDate,
+			EndDate:   cycle[0].EndDate,
+			Status:    StatusDone,
+		}
+		reqJson, err := json.Marshal(reqBody)
+		require.NoError(t, err)
 
 		mockStorage := &mockCycleStorage{
-			err: invalidRequestError,
+			newCycles: cycle,
 		}
-		mockStorage.ExpectToCall("UpdateByID")
+		mockStorage.ExpectToCall("GetNewByID")
 		handler := NewCycleHandler(mockStorage)
 		engine := gin.New()
+		engine.Use(func(c *gin.Context) {
+			c.Set("email", cycle[0].TeamLeaderMail)
+		})
 
 		engine.POST("/cycles/:id", app.NewGinHandler(handler.UpdateByID, zap.NewNop()))
 		rec := httptest.NewRecorder()
 
-		req, _ := http.NewRequest(http.MethodPost, "/cycles/"+cycleId, nil)
+		req, _ := http.NewRequest(http.MethodPost, "/cycles/"+cycle[0].ID.Hex(), strings.NewReader(string(reqJson)))
+		req.Header.Set("If-Match", app.ETag(0))
 
 		engine.ServeHTTP(rec, req)
 
-		resp := rec.Body.String()
-		want := `{
-			"status":"error",
-			"message":"invalid request"
-		}`
-		assert.Equal(t, 400, rec.Code)
-		assert.JSONEq(t, want, resp)
+		assert.Equal(t, 409, rec.Code)
+		assert.False(t, mockStorage.methodsToCall["UpdateNewByID"])
 	})
 
-	t.Run("Return HTTP status 500, Internal server error", func(t *testing.T) {
+	t.Run("Return HTTP status 412 or 428 when If-Match is stale or missing", func(t *testing.T) {
 		cycle := randomCycles(1)
-		reqBody := &Cycle{
-			ID:                cycle[0].ID,
-			SenderMail:        "updatetestById@arise.tech",
-			ReceiverMail:      cycle[0].ReceiverMail,
-			StartDate:         cycle[0].StartDate,
-			EndDate:           cycle[0].EndDate,
-			QuantitativeSkill: cycle[0].QuantitativeSkill,
-			IntuitiveSkill:    cycle[0].IntuitiveSkill,
-			Status:            cycle[0].Status,
-			Comment:           cycle[0].Comment,
+		cycle[0].Version = 3
+		reqBody := &UpdateCycleRequest{
+			StartDate: cycle[0].StartDate,
+			EndDate:   cycle[0].EndDate,
 		}
 		reqJson, err := json.Marshal(reqBody)
 		require.NoError(t, err)
 
-		mockStorage := &mockCycleStorage{
-			err: cycleNotFoundError,
-		}
-		mockStorage.ExpectToCall("UpdateByID")
-		handler := NewCycleHandler(mockStorage)
-		engine := gin.New()
+		for ifMatch, expected := range map[string]int{app.ETag(2): http.StatusPreconditionFailed, "": http.StatusPreconditionRequired} {
+			mockStorage := &mockCycleStorage{
+				newCycles:     cycle,
+				methodsToCall: map[string]bool{},
+			}
+			handler := NewCycleHandler(mockStorage)
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", cycle[0].AriserMail)
+			})
 
-		engine.POST("/cycles/:id", app.NewGinHandler(handler.UpdateByID, zap.NewNop()))
-		rec := httptest.NewRecorder()
+			engine.POST("/cycles/save/:id", app.NewGinHandler(handler.UpdateByIDSave, zap.NewNop()))
+			rec := httptest.NewRecorder()
 
-		req, _ := http.NewRequest(http.MethodPost, "/cycles/"+"123", strings.NewReader(string(reqJson)))
+			req, _ := http.NewRequest(http.MethodPost, "/cycles/save/"+cycle[0].ID.Hex(), strings.NewReader(string(reqJson)))
+			if ifMatch != "" {
+				req.Header.Set("If-Match", ifMatch)
+			}
 
-		engine.ServeHTTP(rec, req)
+			engine.ServeHTTP(rec, req)
 
-		resp := rec.Body.String()
-		want := fmt.Sprintf(`{
-			"status":"error",
-			"message":"%s"
-		}`, cycleNotFoundError.Error())
-		assert.Equal(t, 500, rec.Code)
-		assert.JSONEq(t, want, resp)
+			assert.Equal(t, expected, rec.Code)
+			assert.False(t, mockStorage.methodsToCall["UpdateNewByID"])
+		}
 	})
 }
 
 func TestUpdateUserFinalScore(t *testing.T) {
 	cycles := randomCycles(10)
+	cycles[0].TeamLeaderMail = "teamleader@arise.dev"
 	expectedCycles := getExpectCycles(cycles)
 
 	testCases := []struct {
 		name          string
+		email         string
 		dbErr         error
+		finalScoreErr error
 		id            string
 		checkResponse func(t *testing.T, res interface{}, code int, mockStorage *mockCycleStorage)
 	}{
 		{
 			name:  "Should return 200 Status OK when input correct request",
-			dbErr: nil,
+			email: cycles[0].TeamLeaderMail,
 			id:    expectedCycles[0].ID.Hex(),
 			checkResponse: func(t *testing.T, res interface{}, code int, mockStorage *mockCycleStorage) {
 				require.Equal(t, http.StatusOK, code)
@@ -739,16 +632,56 @@ func TestUpdateUserFinalScore(t *testing.T) {
 		},
 		{
 			name:  "Should return 400 Status BadRequest when cannot convert id to objectId",
-			dbErr: nil,
+			email: cycles[0].TeamLeaderMail,
 			id:    "not-valid",
 			checkResponse: func(t *testing.T, res interface{}, code int, mockStorage *mockCycleStorage) {
 				require.Equal(t, http.StatusBadRequest, code)
 			},
 		},
 		{
-			name:  "Should return 450 Status StoreError when db is error",
-			dbErr: mongo.ErrNilDocument,
+			name:  "Should return 403 Status Forbidden when the ariser applies their own final scores",
+			email: cycles[0].AriserMail,
 			id:    expectedCycles[0].ID.Hex(),
+			checkResponse: func(t *testing.T, res interface{}, code int, mockStorage *mockCycleStorage) {
+				require.Equal(t, http.StatusForbidden, code)
+				assert.JSONEq(t, `{"status":"error","message":"only the team lead of this cycle can apply its final scores"}`, res.(string))
+				assert.False(t, mockStorage.methodsToCall["UpdateUserFinalScore"])
+			},
+		},
+		{
+			name:  "Should return 403 Status Forbidden when user is not a member of the cycle",
+			email: "other@arise.tech",
+			id:    expectedCycles[0].ID.Hex(),
+			checkResponse: func(t *testing.T, res interface{}, code int, mockStorage *mockCycleStorage) {
+				require.Equal(t, http.StatusForbidden, code)
+				assert.False(t, mockStorage.methodsToCall["UpdateUserFinalScore"])
+			},
+		},
+		{
+			name:  "Should return 404 Status NotFound when cycle does not exist",
+			email: cycles[0].TeamLeaderMail,
+			dbErr: cycleNotFoundError,
+			id:    expectedCycles[0].ID.Hex(),
+			checkResponse: func(t *testing.T, res interface{}, code int, mockStorage *mockCycleStorage) {
+				require.Equal(t, http.StatusNotFound, code)
+				assert.False(t, mockStorage.methodsToCall["UpdateUserFinalScore"])
+			},
+		},
+		{
+			name:          "Should return 409 Status Conflict when cycle is not done",
+			email:         cycles[0].TeamLeaderMail,
+			finalScoreErr: cycleNotDoneError,
+			id:            expectedCycles[0].ID.Hex(),
+			checkResponse: func(t *testing.T, res interface{}, code int, mockStorage *mockCycleStorage) {
+				require.Equal(t, http.StatusConflict, code)
+				mockStorage.Verify(t)
+			},
+		},
+		{
+			name:          "Should return 450 Status StoreError when db is error",
+			email:         cycles[0].TeamLeaderMail,
+			finalScoreErr: mongo.ErrNilDocument,
+			id:            expectedCycles[0].ID.Hex(),
 			checkResponse: func(t *testing.T, res interface{}, code int, mockStorage *mockCycleStorage) {
 				require.Equal(t, 450, code)
 				mockStorage.Verify(t)
@@ -760,14 +693,18 @@ func TestUpdateUserFinalScore(t *testing.T) {
 		tc := testCases[i]
 		t.Run(tc.name, func(t *testing.T) {
 			mockStorage := &mockCycleStorage{
-				cycles: cycles,
-				err:    tc.dbErr,
+				newCycles:     cycles,
+				err:           tc.dbErr,
+				finalScoreErr: tc.finalScoreErr,
 			}
 			mockStorage.ExpectToCall("UpdateUserFinalScore")
 
 			handler := NewCycleHandler(mockStorage)
 
 			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", tc.email)
+			})
 			engine.POST("/cycles/update/:id", app.NewGinHandler(handler.UpdateUserFinalScore, zap.NewNop()))
 			rec := httptest.NewRecorder()
 			req, _ := http.NewRequest(http.MethodPost, "/cycles/update/"+tc.id, nil)
@@ -818,10 +755,14 @@ func TestGetAllFromUserEmail(t *testing.T) {
 //	}
 
 func TestUpdateNewCycle(t *testing.T) {
+	goals := `{"hardSkills": [{"name":"HTML","description":"html","skillLevels":[{"level":1,"levelDescription":"basic"}],"personalScore":2,"goalScore":3}]}`
+
 	testCases := []struct {
 		name             string
 		reqBody          string
+		ifMatch          string
 		expectedStatus   int
+		expectedETag     string
 		expectedResponse string
 		storageError     error
 	}{
@@ -856,12 +797,14 @@ func TestUpdateNewCycle(t *testing.T) {
 				]
 
 			}`,
+			ifMatch:          `"3"`,
 			expectedStatus:   200,
+			expectedETag:     `"4"`,
 			expectedResponse: `{"status": "success","message": "","data": {}}`,
 			storageError:     nil,
 		},
 		{
-			name: "Return http status 400 when goal-score exceed personal-score more than 1",
+			name: "Return http status 422 when goal-score exceed personal-score more than 1",
 			reqBody: `{
 				"hardSkills": [
 					{
@@ -890,9 +833,13 @@ func TestUpdateNewCycle(t *testing.T) {
 					}
 				]
 			}`,
-			expectedStatus:   400,
-			expectedResponse: `{"status": "error","message": "miss match goal-score"}`,
-			storageError:     nil,
+			ifMatch:          `"3"`,
+			expectedStatus:   422,
+			expectedResponse: `{"status":"error","message":"goals break the goal-setting policy","data":[{"skill":"HTML","rule":"max_level_jump","message":"goal 5 is more than 1 level(s) above the current level 2"},{"skill":"CSS","rule":"max_level_jump","message":"goal 4 is more than 1 level(s) above the current level 1"}]}`,
+			storageError: GoalPolicyError{Violations: []PolicyViolation{
+				{Skill: "HTML", Rule: RuleLevelJump, Message: "goal 5 is more than 1 level(s) above the current level 2"},
+				{Skill: "CSS", Rule: RuleLevelJump, Message: "goal 4 is more than 1 level(s) above the current level 1"},
+			}},
 		},
 		{
 			name:             "Return http status 400 when JSON binding fails",
@@ -931,10 +878,31 @@ func TestUpdateNewCycle(t *testing.T) {
 					}
 				]
 			}`,
+			ifMatch:          `"3"`,
 			expectedStatus:   450,
 			expectedResponse: `{"message":"document is nil", "status":"error"}`,
 			storageError:     mongo.ErrNilDocument,
 		},
+		{
+			name:             "Return http status 412 when the cycle was changed since it was read",
+			reqBody:          goals,
+			ifMatch:          `"2"`,
+			expectedStatus:   412,
+			expectedResponse: `{"status":"error","message":"cycle was changed by someone else, reload it and try again"}`,
+		},
+		{
+			name:             "Return http status 400 when a goal is on a skill the cycle does not have",
+			reqBody:          `{"hardSkills": [{"name":"Rust","goalScore":2}]}`,
+			ifMatch:          `"3"`,
+			expectedStatus:   400,
+			expectedResponse: `{"status":"error","message":"goals can only be set on the hard skills of the cycle"}`,
+		},
+		{
+			name:             "Return http status 428 when If-Match is missing",
+			reqBody:          goals,
+			expectedStatus:   428,
+			expectedResponse: `{"status":"error","message":"If-Match header with the ETag of the current version is required"}`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -946,6 +914,8 @@ func TestUpdateNewCycle(t *testing.T) {
 				StartDate:      time.Now(),
 				EndDate:        time.Now().Add(48 * time.Hour),
 				Status:         "In Progress",
+				HardSkills:     []HardSkill{{Name: "HTML"}, {Name: "CSS"}},
+				Version:        3,
 			}
 			type getBody struct {
 				HardSkill []user.MyHardSkill `json:"hardSkills"`
@@ -977,6 +947,9 @@ func TestUpdateNewCycle(t *testing.T) {
 			rec := httptest.NewRecorder()
 
 			req, _ := http.NewRequest(http.MethodPut, "/cycles/goal", strings.NewReader(tc.reqBody))
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
 			engine.ServeHTTP(rec, req)
 
 			resp := rec.Body.String()
@@ -984,10 +957,40 @@ func TestUpdateNewCycle(t *testing.T) {
 			// fmt.Printf("actual:%v", rec)
 			assert.Equal(t, tc.expectedStatus, rec.Code)
 			assert.JSONEq(t, resp, tc.expectedResponse)
+			assert.Equal(t, tc.expectedETag, rec.Header().Get("ETag"))
 		})
 	}
 }
 
+func TestUpdateNewCycleTakesOnlyGoals(t *testing.T) {
+	t.Run("should ignore the scores other than the goal sent by the ariser", func(t *testing.T) {
+		pinned := []SkillLevel{{Level: 1, LevelDescription: "writes a query"}}
+		storedCycle := NewCycle{
+			ID:         primitive.NewObjectID(),
+			AriserMail: "test.a@ariser.tech",
+			Status:     StatusPending,
+			HardSkills: []HardSkill{{Name: "SQL", SkillLevels: pinned, RubricVersion: 1, PersonalScore: 2}},
+			Version:    3,
+		}
+		mockStorage := &mockNewCycleStorage{newCycle: &storedCycle}
+		handler := NewCycleHandler(mockStorage)
+
+		engine := gin.New()
+		engine.PUT("/cycles/goal", app.NewGinHandler(handler.UpdateHardSkillsByEmail, zap.NewNop()))
+
+		body := `{"hardSkills": [{"name":"SQL","goalScore":3,"leadScore":5,"mutualScore":5,"agreement":"accepted","skillLevels":[{"level":1,"levelDescription":"edited"}]}]}`
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/cycles/goal", strings.NewReader(body))
+		req.Header.Set("If-Match", app.ETag(3))
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, 200, rec.Code)
+		assert.Equal(t, []HardSkill{
+			{Name: "SQL", SkillLevels: pinned, RubricVersion: 1, PersonalScore: 2, GoalScore: 3},
+		}, storedCycle.HardSkills)
+	})
+}
+
 func TestGetLatestCycleFromUserEmail(t *testing.T) {
 	id := primitive.NewObjectID()
 	idHex := id.Hex()
@@ -995,8 +998,8 @@ func TestGetLatestCycleFromUserEmail(t *testing.T) {
 	startTime := time.Now()
 	endTime := time.Now().Add(48 * time.Hour)
 
-	formattedStartTime := startTime.Format("2006-01-02T15:04:05.999999-07:00")
-	formattedEndTime := endTime.Format("2006-01-02T15:04:05.999999-07:00")
+	formattedStartTime := startTime.Format(time.RFC3339Nano)
+	formattedEndTime := endTime.Format(time.RFC3339Nano)
 
 	testCases := []struct {
 		name             string
@@ -1008,7 +1011,7 @@ func TestGetLatestCycleFromUserEmail(t *testing.T) {
 		{
 			name:             "Return http status 200 and latest cycle by email when email is input",
 			expectedStatus:   200,
-			expectedResponse: fmt.Sprintf(`{"status":"success","message":"","data":{"id":"%v","teamLeaderMail":"test.t@arise.tech","ariserMail":"test.a@ariser.tech","comment":"","startDate":"%v","endDate":"%v", "state":"","status":"In Progress","hardSkills":null}}`, idHex, formattedStartTime, formattedEndTime),
+			expectedResponse: fmt.Sprintf(`{"status":"success","message":"","data":{"id":"%v","teamLeaderMail":"test.t@arise.tech","ariserMail":"test.a@ariser.tech","startDate":"%v","endDate":"%v", "state":"","status":"In Progress","hardSkills":null,"periodId":"000000000000000000000000","version":0}}`, idHex, formattedStartTime, formattedEndTime),
 			storageError:     nil,
 		},
 		{
@@ -1025,7 +1028,6 @@ func TestGetLatestCycleFromUserEmail(t *testing.T) {
 				TeamLeaderMail: "test.t@arise.tech",
 				AriserMail:     "test.a@ariser.tech",
 				StartDate:      startTime,
-				Comment:        "",
 				EndDate:        endTime,
 				Status:         "In Progress",
 			}
@@ -1053,3 +1055,220 @@ func TestGetLatestCycleFromUserEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateStatusByID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cycleId := mockObjectId(10)
+
+	testCases := []struct {
+		name           string
+		email          string
+		status         string
+		reqBody        string
+		ifMatch        string
+		err            error
+		expectedStatus int
+		expectedCycle  string
+		expectedETag   string
+	}{
+		{
+			name:           "should return 200 when team lead approves pending cycle",
+			email:          "teamleader@arise.tech",
+			status:         StatusPending,
+			reqBody:        `{"status":"Approved"}`,
+			expectedStatus: http.StatusOK,
+			expectedCycle:  StatusApproved,
+			expectedETag:   `"3"`,
+		},
+		{
+			name:           "should return 409 when ariser approves own cycle",
+			email:          "ariser@arise.tech",
+			status:         StatusPending,
+			reqBody:        `{"status":"Approved"}`,
+			expectedStatus: http.StatusConflict,
+			expectedCycle:  StatusPending,
+		},
+		{
+			name:           "should return 409 when pending cycle jumps to done",
+			email:          "teamleader@arise.tech",
+			status:         StatusPending,
+			reqBody:        `{"status":"Done"}`,
+			expectedStatus: http.StatusConflict,
+			expectedCycle:  StatusPending,
+		},
+		{
+			name:           "should return 400 when status is missing",
+			email:          "teamleader@arise.tech",
+			status:         StatusPending,
+			reqBody:        `{}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCycle:  StatusPending,
+		},
+		{
+			name:           "should return 404 when cycle is not found",
+			email:          "teamleader@arise.tech",
+			reqBody:        `{"status":"Approved"}`,
+			err:            cycleNotFoundError,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "should return 500 when cycle can't be read",
+			email:          "teamleader@arise.tech",
+			reqBody:        `{"status":"Approved"}`,
+			err:            mongo.ErrClientDisconnected,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "should return 412 when the cycle was changed since it was read",
+			email:          "teamleader@arise.tech",
+			status:         StatusPending,
+			reqBody:        `{"status":"Approved"}`,
+			ifMatch:        `"1"`,
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedCycle:  StatusPending,
+		},
+		{
+			name:           "should return 428 when If-Match is missing",
+			email:          "teamleader@arise.tech",
+			status:         StatusPending,
+			reqBody:        `{"status":"Approved"}`,
+			ifMatch:        "-",
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedCycle:  StatusPending,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockStorage := &mockCycleStorage{
+				newCycles: []*NewCycle{
+					{
+						ID:             cycleId.objectId,
+						AriserMail:     "ariser@arise.tech",
+						TeamLeaderMail: "teamleader@arise.tech",
+						Status:         tc.status,
+						Version:        2,
+					},
+				},
+				err: tc.err,
+			}
+			mockStorage.ExpectToCall("GetNewByID")
+			handler := NewCycleHandler(mockStorage)
+
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", tc.email)
+			})
+			engine.PUT("/cycles/:id/status", app.NewGinHandler(handler.UpdateStatusByID, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/cycles/"+cycleId.hexId+"/status", strings.NewReader(tc.reqBody))
+			switch tc.ifMatch {
+			case "":
+				req.Header.Set("If-Match", app.ETag(2))
+			case "-":
+			default:
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedETag, rec.Header().Get("ETag"))
+			if tc.err == nil {
+				assert.Equal(t, tc.expectedCycle, mockStorage.newCycles[0].Status)
+			}
+		})
+	}
+}
+
+func TestHistoryByID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cycleId := mockObjectId(10)
+
+	t.Run("should return 200 and events of the cycle", func(t *testing.T) {
+		at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		mockStorage := &mockCycleStorage{
+			newCycles: []*NewCycle{{ID: cycleId.objectId, AriserMail: "ariser@arise.tech", TeamLeaderMail: "teamleader@arise.tech"}},
+			events: []CycleEvent{
+				{
+					ID:      mockObjectId(1).objectId,
+					CycleID: cycleId.objectId,
+					Type:    EventStatusChanged,
+					Actor:   "teamleader@arise.tech",
+					At:      at,
+					Changes: []FieldChange{{Field: "status", Before: StatusPending, After: StatusApproved}},
+				},
+			},
+		}
+		mockStorage.ExpectToCall("GetNewByID")
+		mockStorage.ExpectToCall("GetEventsByCycleID")
+		handler := NewCycleHandler(mockStorage)
+
+		engine := gin.New()
+		engine.Use(func(c *gin.Context) {
+			c.Set("email", "teamleader@arise.tech")
+		})
+		engine.GET("/cycles/:id/history", app.NewGinHandler(handler.HistoryByID, zap.NewNop()))
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cycles/"+cycleId.hexId+"/history", nil)
+
+		engine.ServeHTTP(rec, req)
+
+		want := `{
+			"status": "success",
+			"message": "",
+			"data": [
+				{
+					"id": "000000000000000000000001",
+					"cycleId": "000000000000000000000010",
+					"type": "status_changed",
+					"actor": "teamleader@arise.tech",
+					"at": "2024-01-02T03:04:05Z",
+					"changes": [{"field": "status", "before": "Pending", "after": "Approved"}]
+				}
+			]
+		}`
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, want, rec.Body.String())
+		mockStorage.Verify(t)
+	})
+
+	t.Run("should return 403 when user is not the ariser or team lead", func(t *testing.T) {
+		mockStorage := &mockCycleStorage{
+			newCycles: []*NewCycle{{ID: cycleId.objectId, AriserMail: "ariser@arise.tech", TeamLeaderMail: "teamleader@arise.tech"}},
+		}
+		mockStorage.ExpectToCall("GetNewByID")
+		handler := NewCycleHandler(mockStorage)
+
+		engine := gin.New()
+		engine.Use(func(c *gin.Context) {
+			c.Set("email", "other@arise.tech")
+		})
+		engine.GET("/cycles/:id/history", app.NewGinHandler(handler.HistoryByID, zap.NewNop()))
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cycles/"+cycleId.hexId+"/history", nil)
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.False(t, mockStorage.methodsToCall["GetEventsByCycleID"])
+	})
+
+	t.Run("should return 404 when cycle is not found", func(t *testing.T) {
+		mockStorage := &mockCycleStorage{
+			err: cycleNotFoundError,
+		}
+		mockStorage.ExpectToCall("GetNewByID")
+		handler := NewCycleHandler(mockStorage)
+
+		engine := gin.New()
+		engine.GET("/cycles/:id/history", app.NewGinHandler(handler.HistoryByID, zap.NewNop()))
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/cycles/"+cycleId.hexId+"/history", nil)
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		mockStorage.Verify(t)
+	})
+}
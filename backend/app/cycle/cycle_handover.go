@@ -0,0 +1,59 @@
+package cycle
+
+import (
+	"fmt"
+	"strings"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app/notification"
+)
+
+type LeadHandoverRequest struct {
+	FromLeadMail string `json:"fromLeadMail" binding:"required"`
+	ToLeadMail   string `json:"toLeadMail" binding:"required"`
+}
+
+// LeadHandoverResult lists the cycles that moved to the new team lead, and
+// the arisers whose cycles stayed because the new lead is the ariser.
+type LeadHandoverResult struct {
+	FromLeadMail string   `json:"fromLeadMail"`
+	ToLeadMail   string   `json:"toLeadMail"`
+	CycleIDs     []string `json:"cycleIds"`
+	Skipped      []string `json:"skipped"`
+}
+
+// handoverNotifications tells both team leads and every ariser of the moved
+// cycles who reviews them from now on.
+func handoverNotifications(from string, to string, moved []NewCycle) []notification.Notification {
+	if len(moved) == 0 {
+		return nil
+	}
+
+	arisers := make([]string, len(moved))
+	notifications := []notification.Notification{}
+	for i := range moved {
+		cy := moved[i]
+		arisers[i] = cy.AriserMail
+		notifications = append(notifications, notification.Notification{
+			Recipient: cy.AriserMail,
+			Kind:      notification.KindLeadHandover,
+			Message: fmt.Sprintf("Your cycle from %s to %s is now reviewed by %s instead of %s",
+				cy.StartDate.Format(reportDateFormat), cy.EndDate.Format(reportDateFormat), to, from),
+			CycleID: &moved[i].ID,
+		})
+	}
+
+	list := strings.Join(arisers, ", ")
+	notifications = append(notifications,
+		notification.Notification{
+			Recipient: from,
+			Kind:      notification.KindLeadHandover,
+			Message:   fmt.Sprintf("%d open cycles you led were handed over to %s: %s", len(moved), to, list),
+		},
+		notification.Notification{
+			Recipient: to,
+			Kind:      notification.KindLeadHandover,
+			Message:   fmt.Sprintf("You now lead %d open cycles handed over from %s: %s", len(moved), from, list),
+		},
+	)
+	return notifications
+}
@@ -0,0 +1,115 @@
+package cycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/notification"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/user"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type HandoverStorage interface {
+	GetUsersHardSkillByEmail(ctx context.Context, email string) (*user.User, error)
+	HandoverCycles(ctx context.Context, from string, to string, actor string) ([]NewCycle, []NewCycle, error)
+}
+
+// Notifier is the part of the notification storage used to tell people
+// about changes to their cycles.
+type Notifier interface {
+	Notify(ctx context.Context, notifications []notification.Notification) error
+}
+
+type handoverHandler struct {
+	storage  HandoverStorage
+	notifier Notifier
+	admins   []string
+}
+
+func NewHandoverHandler(st HandoverStorage, notifier Notifier, admins []string) *handoverHandler {
+	return &handoverHandler{
+		storage:  st,
+		notifier: notifier,
+		admins:   admins,
+	}
+}
+
+var notAdminError = cycleHandlerError{message: "only admins can hand cycles over"}
+var invalidHandoverInputError = cycleHandlerError{message: "invalid handover input"}
+var sameTeamLeadError = cycleHandlerError{message: "new team lead must be someone else"}
+var newTeamLeadNotFoundError = cycleHandlerError{message: "new team lead not found"}
+
+// Handover godoc
+//
+//	@summary		Handover
+//	@description	Move every cycle of a team lead that isn't done to a new team lead, deleted ones included, keeping its reviews, comments and history. Both leads and the arisers are notified.
+//	@tags			cycle
+//	@id				HandoverCycles
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			reqJson	body		LeadHandoverRequest			true	"Old and new team lead"
+//	@response		200		{object}	cycle.LeadHandoverResult	"OK"
+//	@response		400		{object}	app.Response				"Bad Request"
+//	@response		403		{object}	app.Response				"Not an admin"
+//	@response		404		{object}	app.Response				"New team lead not found"
+//	@response		450		{object}	app.Response				"Store Error"
+//	@response		500		{object}	app.Response				"Internal Server Error"
+//	@router			/admin/cycles/handover [post]
+func (h *handoverHandler) Handover(c app.Context) {
+	email := c.GetString("email")
+	if !app.RequireAdmin(c, h.admins, notAdminError) {
+		return
+	}
+
+	var req LeadHandoverRequest
+	if err := c.Bind(&req); err != nil {
+		c.BadRequest(invalidHandoverInputError)
+		return
+	}
+	if err := ValidateEmail(req.ToLeadMail); err != nil {
+		c.BadRequest(err)
+		return
+	}
+	if req.FromLeadMail == req.ToLeadMail {
+		c.BadRequest(sameTeamLeadError)
+		return
+	}
+
+	if _, err := h.storage.GetUsersHardSkillByEmail(c.Ctx(), req.ToLeadMail); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.NotFound(newTeamLeadNotFoundError)
+			return
+		}
+		c.InternalServerError(err)
+		return
+	}
+
+	moved, skipped, err := h.storage.HandoverCycles(c.Ctx(), req.FromLeadMail, req.ToLeadMail, email)
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	if err := h.notifier.Notify(c.Ctx(), handoverNotifications(req.FromLeadMail, req.ToLeadMail, moved)); err != nil {
+		c.InternalServerError(fmt.Errorf("cycles were handed over but the notifications failed: %w", err))
+		return
+	}
+
+	result := LeadHandoverResult{
+		FromLeadMail: req.FromLeadMail,
+		ToLeadMail:   req.ToLeadMail,
+		CycleIDs:     []string{},
+		Skipped:      []string{},
+	}
+	for _, cy := range moved {
+		result.CycleIDs = append(result.CycleIDs, cy.ID.Hex())
+	}
+	for _, cy := range skipped {
+		result.Skipped = append(result.Skipped, cy.AriserMail)
+	}
+
+	c.OK(result)
+}
@@ -0,0 +1,210 @@
+package cycle
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/notification"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/user"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+type mockHandoverStorage struct {
+	cycles  []NewCycle
+	userErr error
+	err     error
+	actor   string
+}
+
+func (m *mockHandoverStorage) GetUsersHardSkillByEmail(ctx context.Context, email string) (*user.User, error) {
+	if m.userErr != nil {
+		return nil, m.userErr
+	}
+	return &user.User{Email: email}, nil
+}
+
+func (m *mockHandoverStorage) HandoverCycles(ctx context.Context, from string, to string, actor string) ([]NewCycle, []NewCycle, error) {
+	if m.err != nil {
+		return nil, nil, m.err
+	}
+	m.actor = actor
+	moved, skipped := []NewCycle{}, []NewCycle{}
+	for _, cy := range m.cycles {
+		if cy.TeamLeaderMail != from || cy.Status == StatusDone {
+			continue
+		}
+		if cy.AriserMail == to {
+			skipped = append(skipped, cy)
+			continue
+		}
+		cy.TeamLeaderMail = to
+		moved = append(moved, cy)
+	}
+	return moved, skipped, nil
+}
+
+type mockNotifier struct {
+	sent []notification.Notification
+	err  error
+}
+
+func (m *mockNotifier) Notify(ctx context.Context, notifications []notification.Notification) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, notifications...)
+	return nil
+}
+
+func handoverFixtures() []NewCycle {
+	return []NewCycle{
+		{ID: mockObjectId(1).objectId, AriserMail: "a@arise.tech", TeamLeaderMail: "old@arise.tech", Status: StatusPending},
+		{ID: mockObjectId(2).objectId, AriserMail: "b@arise.tech", TeamLeaderMail: "old@arise.tech", Status: StatusDone},
+		{ID: mockObjectId(3).objectId, AriserMail: "new@arise.tech", TeamLeaderMail: "old@arise.tech", Status: StatusRunning},
+		{ID: mockObjectId(4).objectId, AriserMail: "c@arise.tech", TeamLeaderMail: "old@arise.tech", Status: StatusRunning},
+	}
+}
+
+func TestHandover(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reqBody := `{"fromLeadMail":"old@arise.tech","toLeadMail":"new@arise.tech"}`
+
+	testCases := []struct {
+		name             string
+		email            string
+		reqBody          string
+		userErr          error
+		storageErr       error
+		notifyErr        error
+		expectedStatus   int
+		expectedResponse string
+		expectedSent     int
+	}{
+		{
+			name:           "should return 200 and move open cycles except the new lead's own",
+			email:          "admin@arise.tech",
+			reqBody:        reqBody,
+			expectedStatus: http.StatusOK,
+			expectedResponse: `{
+				"status": "success",
+				"message": "",
+				"data": {
+					"fromLeadMail": "old@arise.tech",
+					"toLeadMail": "new@arise.tech",
+					"cycleIds": ["000000000000000000000001", "000000000000000000000004"],
+					"skipped": ["new@arise.tech"]
+				}
+			}`,
+			expectedSent: 4,
+		},
+		{
+			name:             "should return 403 when user is not an admin",
+			email:            "old@arise.tech",
+			reqBody:          reqBody,
+			expectedStatus:   http.StatusForbidden,
+			expectedResponse: `{"status":"error","message":"only admins can hand cycles over"}`,
+		},
+		{
+			name:             "should return 400 when both leads are the same",
+			email:            "admin@arise.tech",
+			reqBody:          `{"fromLeadMail":"old@arise.tech","toLeadMail":"old@arise.tech"}`,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"new team lead must be someone else"}`,
+		},
+		{
+			name:             "should return 400 when new lead is missing",
+			email:            "admin@arise.tech",
+			reqBody:          `{"fromLeadMail":"old@arise.tech"}`,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid handover input"}`,
+		},
+		{
+			name:             "should return 404 when new lead is not a user",
+			email:            "admin@arise.tech",
+			reqBody:          reqBody,
+			userErr:          mongo.ErrNoDocuments,
+			expectedStatus:   http.StatusNotFound,
+			expectedResponse: `{"status":"error","message":"new team lead not found"}`,
+		},
+		{
+			name:             "should return 450 when handover is failed",
+			email:            "admin@arise.tech",
+			reqBody:          reqBody,
+			storageErr:       errors.New("transaction error"),
+			expectedStatus:   450,
+			expectedResponse: `{"status":"error","message":"transaction error"}`,
+		},
+		{
+			name:             "should return 500 when notifications are failed",
+			email:            "admin@arise.tech",
+			reqBody:          reqBody,
+			notifyErr:        errors.New("insert error"),
+			expectedStatus:   http.StatusInternalServerError,
+			expectedResponse: `{"status":"error","message":"cycles were handed over but the notifications failed: insert error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockHandoverStorage{cycles: handoverFixtures(), userErr: tc.userErr, err: tc.storageErr}
+			notifier := &mockNotifier{err: tc.notifyErr}
+			handler := NewHandoverHandler(st, notifier, []string{"admin@arise.tech"})
+
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", tc.email)
+			})
+			engine.POST("/admin/cycles/handover", app.NewGinHandler(handler.Handover, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/admin/cycles/handover", strings.NewReader(tc.reqBody))
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+			assert.Len(t, notifier.sent, tc.expectedSent)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, "admin@arise.tech", st.actor)
+			}
+		})
+	}
+}
+
+func TestHandoverNotifications(t *testing.T) {
+	t.Run("should notify each ariser and both leads", func(t *testing.T) {
+		moved := []NewCycle{
+			{
+				ID:         mockObjectId(1).objectId,
+				AriserMail: "a@arise.tech",
+				StartDate:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:    time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+			},
+			{ID: mockObjectId(4).objectId, AriserMail: "c@arise.tech"},
+		}
+
+		got := handoverNotifications("old@arise.tech", "new@arise.tech", moved)
+
+		assert.Len(t, got, 4)
+		assert.Equal(t, "a@arise.tech", got[0].Recipient)
+		assert.Equal(t, "Your cycle from 2024-01-01 to 2024-06-30 is now reviewed by new@arise.tech instead of old@arise.tech", got[0].Message)
+		assert.Equal(t, mockObjectId(1).objectId, *got[0].CycleID)
+		assert.Equal(t, mockObjectId(4).objectId, *got[1].CycleID)
+		assert.Equal(t, "old@arise.tech", got[2].Recipient)
+		assert.Equal(t, "2 open cycles you led were handed over to new@arise.tech: a@arise.tech, c@arise.tech", got[2].Message)
+		assert.Equal(t, "new@arise.tech", got[3].Recipient)
+		assert.Equal(t, "You now lead 2 open cycles handed over from old@arise.tech: a@arise.tech, c@arise.tech", got[3].Message)
+	})
+
+	t.Run("should notify nobody when no cycle moved", func(t *testing.T) {
+		assert.Empty(t, handoverNotifications("old@arise.tech", "new@arise.tech", nil))
+	})
+}
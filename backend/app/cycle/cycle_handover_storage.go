@@ -0,0 +1,65 @@
+package cycle
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// HandoverCycles moves every cycle of a team lead that isn't done yet to
+// another lead. Scores and comments stay with the cycles, and each one gets
+// a history event naming the admin who moved it. Cycles of the new lead
+// themselves are left where they are and returned as skipped. Deleted cycles
+// move too, so restoring one doesn't hand it back to the old lead.
+func (s *storage) HandoverCycles(ctx context.Context, from string, to string, actor string) ([]NewCycle, []NewCycle, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	filter := bson.M{"teamLeaderMail": from, "status": bson.M{"$ne": StatusDone}}
+	var moved, skipped []NewCycle
+	err := s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		moved, skipped = []NewCycle{}, []NewCycle{}
+
+		cursor, err := s.db.Collection(newCycleCollection).Find(sc, filter)
+		if err != nil {
+			return err
+		}
+		var open []NewCycle
+		if err := cursor.All(sc, &open); err != nil {
+			return err
+		}
+
+		for i := range open {
+			before := open[i]
+			if before.AriserMail == to {
+				skipped = append(skipped, before)
+				continue
+			}
+
+			update := bson.M{"$set": bson.M{"teamLeaderMail": to}, "$inc": bson.M{"version": 1}}
+			res, err := s.db.Collection(newCycleCollection).UpdateOne(sc, bson.M{"_id": before.ID, "teamLeaderMail": from}, update)
+			if err != nil {
+				return err
+			}
+			if res.MatchedCount == 0 {
+				return cycleVersionChangedError
+			}
+
+			after := before
+			after.TeamLeaderMail = to
+			after.Version++
+			if err := s.recordEvent(sc, EventLeadChanged, actor, &before, &after); err != nil {
+				return err
+			}
+			moved = append(moved, after)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return moved, skipped, nil
+}
@@ -0,0 +1,125 @@
+package cycle
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app/skill"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const MigrationActor = "migration"
+
+// MigrationRecord keeps the legacy document a new cycle was made from. The
+// new cycle has the same _id, so rolling back only needs this collection.
+type MigrationRecord struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	MigratedAt time.Time          `bson:"migratedAt"`
+	Legacy     Cycle              `bson:"legacy"`
+}
+
+type MigrationProgress struct {
+	Done    int
+	Total   int
+	ID      primitive.ObjectID
+	Skipped bool
+}
+
+// MigrationReport counts the cycles a migration or rollback went through.
+// Applied is what was or, on a dry run, would have been written.
+type MigrationReport struct {
+	Total   int
+	Applied int
+	Skipped int
+}
+
+// stateOfStatus is the state a migrated cycle lands in when the legacy
+// document has none.
+var stateOfStatus = map[string]string{
+	StatusPending:  StateReview,
+	StatusApproved: StateApproved,
+	StatusRejected: StateRevise,
+	StatusRunning:  StateRunning,
+	StatusDone:     StateDone,
+}
+
+// ToNewCycle maps a legacy cycle into the new schema. Quantitative skills
+// become hard skills named after the skills collection, the lead goal wins
+// over the ariser's goal, and the final score only counts as agreed, and as
+// applied to the profile at the end date, once the cycle is done. The legacy
+// comments become comments authored by the migration; intuitive skills have
+// no place in the new model, so they are kept as a comment on the cycle.
+func ToNewCycle(legacy Cycle, skills map[primitive.ObjectID]skill.Skill) (NewCycle, []CycleComment) {
+	comments := []CycleComment{}
+	addComment := func(skill string, body string) {
+		if body == "" {
+			return
+		}
+		comments = append(comments, CycleComment{
+			CycleID:   legacy.ID,
+			Skill:     skill,
+			Author:    MigrationActor,
+			Body:      body,
+			CreatedAt: legacy.StartDate,
+			History:   []CommentRevision{},
+		})
+	}
+
+	addComment("", legacy.Comment)
+
+	hardSkills := []HardSkill{}
+	for _, qs := range legacy.QuantitativeSkill {
+		goal := qs.LeadGoalScore
+		if goal == 0 {
+			goal = qs.GoalScore
+		}
+		hs := HardSkill{
+			ID:            qs.ID,
+			Name:          skills[qs.ID].Name,
+			Description:   skills[qs.ID].Description,
+			SkillLevels:   []SkillLevel{},
+			PersonalScore: qs.PersonalScore,
+			GoalScore:     goal,
+		}
+		if hs.Name == "" {
+			hs.Name = qs.ID.Hex()
+		}
+		if legacy.Status == StatusDone {
+			hs.MutualScore = qs.FinalScore
+		}
+		hardSkills = append(hardSkills, hs)
+		addComment(hs.Name, qs.Comment)
+	}
+
+	if len(legacy.IntuitiveSkill) > 0 {
+		lines := []string{}
+		for _, is := range legacy.IntuitiveSkill {
+			lines = append(lines, strings.TrimSpace(fmt.Sprintf("%s (%s): %s %s", is.Name, is.Status, is.Goal, is.Comment)))
+		}
+		addComment("", strings.Join(lines, "\n"))
+	}
+
+	state := legacy.State
+	if state == "" {
+		state = stateOfStatus[legacy.Status]
+	}
+
+	cy := NewCycle{
+		ID:             legacy.ID,
+		TeamLeaderMail: legacy.ReceiverMail,
+		AriserMail:     legacy.SenderMail,
+		StartDate:      legacy.StartDate,
+		EndDate:        legacy.EndDate,
+		Status:         legacy.Status,
+		HardSkills:     hardSkills,
+		State:          state,
+	}
+	// The legacy flow already put the final scores of a done cycle on the
+	// profile, so they must not be applied a second time.
+	if legacy.Status == StatusDone {
+		appliedAt := legacy.EndDate
+		cy.ScoresAppliedAt = &appliedAt
+	}
+	return cy, comments
+}
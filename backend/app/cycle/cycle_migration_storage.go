@@ -0,0 +1,245 @@
+package cycle
+
+import (
+	"context"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app/skill"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const cycleMigrationCollection = "cycle_migrations"
+
+// MigrateLegacyCycles copies every legacy cycle into new_cycles under the
+// same _id and keeps the original in cycle_migrations. Cycles already in
+// new_cycles are skipped, so the migration can be run again after a failure.
+// With dryRun nothing is written.
+func (s *storage) MigrateLegacyCycles(ctx context.Context, dryRun bool, progress func(MigrationProgress)) (MigrationReport, error) {
+	cursor, err := s.db.Collection(cycleCollection).Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return MigrationReport{}, err
+	}
+	legacy := []Cycle{}
+	if err := cursor.All(ctx, &legacy); err != nil {
+		return MigrationReport{}, err
+	}
+
+	skills, err := s.legacySkills(ctx, legacy)
+	if err != nil {
+		return MigrationReport{}, err
+	}
+
+	report := MigrationReport{Total: len(legacy)}
+	for i, cy := range legacy {
+		n, err := s.db.Collection(newCycleCollection).CountDocuments(ctx, bson.M{"_id": cy.ID})
+		if err != nil {
+			return report, err
+		}
+
+		skipped := n > 0
+		if skipped {
+			report.Skipped++
+		} else {
+			if !dryRun {
+				if err := s.migrateOne(ctx, cy, skills); err != nil {
+					return report, err
+				}
+			}
+			report.Applied++
+		}
+
+		if progress != nil {
+			progress(MigrationProgress{Done: i + 1, Total: len(legacy), ID: cy.ID, Skipped: skipped})
+		}
+	}
+
+	return report, nil
+}
+
+func (s *storage) migrateOne(ctx context.Context, legacy Cycle, skills map[primitive.ObjectID]skill.Skill) error {
+	record := MigrationRecord{ID: legacy.ID, MigratedAt: time.Now(), Legacy: legacy}
+	upsert := options.Replace().SetUpsert(true)
+	if _, err := s.db.Collection(cycleMigrationCollection).ReplaceOne(ctx, bson.M{"_id": legacy.ID}, record, upsert); err != nil {
+		return err
+	}
+
+	cy, comments := ToNewCycle(legacy, skills)
+	if _, err := s.db.Collection(newCycleCollection).InsertOne(ctx, cy); err != nil {
+		return err
+	}
+	if err := s.insertMigratedComments(ctx, comments); err != nil {
+		return err
+	}
+
+	return s.recordEvent(ctx, EventCreated, MigrationActor, &NewCycle{}, &cy)
+}
+
+// MoveInlineComments turns the comment strings still stored on new_cycles,
+// from before comments were threads, into comments authored by the migration
+// and removes them from the cycle. With dryRun nothing is written.
+func (s *storage) MoveInlineComments(ctx context.Context, dryRun bool, progress func(MigrationProgress)) (MigrationReport, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"comment": bson.M{"$exists": true}},
+		bson.M{"hardSkills.comment": bson.M{"$exists": true}},
+	}}
+	cursor, err := s.db.Collection(newCycleCollection).Find(ctx, filter, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return MigrationReport{}, err
+	}
+	cycles := []inlineComments{}
+	if err := cursor.All(ctx, &cycles); err != nil {
+		return MigrationReport{}, err
+	}
+
+	report := MigrationReport{Total: len(cycles)}
+	for i, cy := range cycles {
+		if !dryRun {
+			if err := s.moveInlineComments(ctx, cy); err != nil {
+				return report, err
+			}
+		}
+		report.Applied++
+
+		if progress != nil {
+			progress(MigrationProgress{Done: i + 1, Total: len(cycles), ID: cy.ID})
+		}
+	}
+
+	return report, nil
+}
+
+// inlineComments is the part of a new cycle that held its comments before
+// they moved to cycle_comments.
+type inlineComments struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	StartDate  time.Time          `bson:"startDate"`
+	Comment    string             `bson:"comment"`
+	HardSkills []struct {
+		Name    string `bson:"name"`
+		Comment string `bson:"comment"`
+	} `bson:"hardSkills"`
+}
+
+// moveInlineComments inserts the comments of one cycle and removes them from
+// the cycle in one transaction, so a rerun after a failure can't copy them
+// twice.
+func (s *storage) moveInlineComments(ctx context.Context, cy inlineComments) error {
+	comments := []CycleComment{}
+	add := func(skill string, body string) {
+		if body == "" {
+			return
+		}
+		comments = append(comments, CycleComment{
+			CycleID:   cy.ID,
+			Skill:     skill,
+			Author:    MigrationActor,
+			Body:      body,
+			CreatedAt: cy.StartDate,
+			History:   []CommentRevision{},
+		})
+	}
+	add("", cy.Comment)
+	for _, hs := range cy.HardSkills {
+		add(hs.Name, hs.Comment)
+	}
+
+	unset := bson.M{"comment": ""}
+	if len(cy.HardSkills) > 0 {
+		unset["hardSkills.$[].comment"] = ""
+	}
+	return s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		if err := s.insertMigratedComments(sc, comments); err != nil {
+			return err
+		}
+		_, err := s.db.Collection(newCycleCollection).UpdateOne(sc, bson.M{"_id": cy.ID}, bson.M{"$unset": unset})
+		return err
+	})
+}
+
+func (s *storage) insertMigratedComments(ctx context.Context, comments []CycleComment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+	docs := make([]any, len(comments))
+	for i := range comments {
+		docs[i] = comments[i]
+	}
+	_, err := s.db.Collection(cycleCommentCollection).InsertMany(ctx, docs)
+	return err
+}
+
+// RollbackLegacyMigration removes every migrated cycle and its history from
+// new_cycles and puts the legacy document back if it is gone. Changes made to
+// a migrated cycle after the migration are lost.
+func (s *storage) RollbackLegacyMigration(ctx context.Context, dryRun bool, progress func(MigrationProgress)) (MigrationReport, error) {
+	cursor, err := s.db.Collection(cycleMigrationCollection).Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return MigrationReport{}, err
+	}
+	records := []MigrationRecord{}
+	if err := cursor.All(ctx, &records); err != nil {
+		return MigrationReport{}, err
+	}
+
+	report := MigrationReport{Total: len(records)}
+	for i, record := range records {
+		if !dryRun {
+			if err := s.rollbackOne(ctx, record); err != nil {
+				return report, err
+			}
+		}
+		report.Applied++
+
+		if progress != nil {
+			progress(MigrationProgress{Done: i + 1, Total: len(records), ID: record.ID})
+		}
+	}
+
+	return report, nil
+}
+
+func (s *storage) rollbackOne(ctx context.Context, record MigrationRecord) error {
+	upsert := options.Replace().SetUpsert(true)
+	if _, err := s.db.Collection(cycleCollection).ReplaceOne(ctx, bson.M{"_id": record.ID}, record.Legacy, upsert); err != nil {
+		return err
+	}
+	if _, err := s.db.Collection(newCycleCollection).DeleteOne(ctx, bson.M{"_id": record.ID}); err != nil {
+		return err
+	}
+	if _, err := s.db.Collection(cycleEventCollection).DeleteMany(ctx, bson.M{"cycleId": record.ID}); err != nil {
+		return err
+	}
+	if _, err := s.db.Collection(cycleCommentCollection).DeleteMany(ctx, bson.M{"cycleId": record.ID}); err != nil {
+		return err
+	}
+	_, err := s.db.Collection(cycleMigrationCollection).DeleteOne(ctx, bson.M{"_id": record.ID})
+	return err
+}
+
+// legacySkills loads the skills referenced by the given legacy cycles.
+func (s *storage) legacySkills(ctx context.Context, legacy []Cycle) (map[primitive.ObjectID]skill.Skill, error) {
+	ids := []primitive.ObjectID{}
+	for _, cy := range legacy {
+		for _, qs := range cy.QuantitativeSkill {
+			ids = append(ids, qs.ID)
+		}
+	}
+
+	cursor, err := s.db.Collection(skillCollection).Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	found := []skill.Skill{}
+	if err := cursor.All(ctx, &found); err != nil {
+		return nil, err
+	}
+
+	skills := make(map[primitive.ObjectID]skill.Skill)
+	for _, sk := range found {
+		skills[sk.ID] = sk
+	}
+	return skills, nil
+}
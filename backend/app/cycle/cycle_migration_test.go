@@ -0,0 +1,68 @@
+package cycle
+
+import (
+	"testing"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app/skill"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestToNewCycle(t *testing.T) {
+	css := mockObjectId(2).objectId
+	html := mockObjectId(3).objectId
+	skills := map[primitive.ObjectID]skill.Skill{
+		css: {ID: css, Name: "CSS", Description: "style sheets"},
+	}
+	start := time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)
+	legacy := Cycle{
+		ID:           mockObjectId(1).objectId,
+		SenderMail:   "ariser@arise.tech",
+		ReceiverMail: "lead@arise.tech",
+		StartDate:    start,
+		EndDate:      start.AddDate(0, 1, 0),
+		QuantitativeSkill: []QuantitativeSkill{
+			{ID: css, PersonalScore: 2, GoalScore: 3, LeadGoalScore: 4, FinalScore: 4, Comment: "nice"},
+			{ID: html, PersonalScore: 1, GoalScore: 2, FinalScore: 2},
+		},
+		IntuitiveSkill: []IntuitiveSkill{
+			{Name: "Creativity", Status: "pass", Goal: "ideas"},
+		},
+		Status:  StatusDone,
+		Comment: "good job",
+	}
+
+	t.Run("should map a done legacy cycle with scores and comments", func(t *testing.T) {
+		cy, comments := ToNewCycle(legacy, skills)
+
+		assert.Equal(t, legacy.ID, cy.ID)
+		assert.Equal(t, "ariser@arise.tech", cy.AriserMail)
+		assert.Equal(t, "lead@arise.tech", cy.TeamLeaderMail)
+		assert.Equal(t, StatusDone, cy.Status)
+		assert.Equal(t, StateDone, cy.State)
+		assert.Equal(t, &legacy.EndDate, cy.ScoresAppliedAt)
+		assert.Equal(t, []HardSkill{
+			{ID: css, Name: "CSS", Description: "style sheets", SkillLevels: []SkillLevel{}, PersonalScore: 2, GoalScore: 4, MutualScore: 4},
+			{ID: html, Name: html.Hex(), SkillLevels: []SkillLevel{}, PersonalScore: 1, GoalScore: 2, MutualScore: 2},
+		}, cy.HardSkills)
+		assert.Equal(t, []CycleComment{
+			{CycleID: legacy.ID, Author: MigrationActor, Body: "good job", CreatedAt: start, History: []CommentRevision{}},
+			{CycleID: legacy.ID, Skill: "CSS", Author: MigrationActor, Body: "nice", CreatedAt: start, History: []CommentRevision{}},
+			{CycleID: legacy.ID, Author: MigrationActor, Body: "Creativity (pass): ideas", CreatedAt: start, History: []CommentRevision{}},
+		}, comments)
+	})
+
+	t.Run("should not treat final score as agreed before the cycle is done", func(t *testing.T) {
+		pending := legacy
+		pending.Status = StatusPending
+
+		cy, _ := ToNewCycle(pending, skills)
+
+		assert.Equal(t, StateReview, cy.State)
+		assert.Nil(t, cy.ScoresAppliedAt)
+		for _, hs := range cy.HardSkills {
+			assert.Zero(t, hs.MutualScore)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package cycle
+
+import (
+	"context"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"go.uber.org/zap"
+)
+
+const MonitorActor = "monitor"
+
+type MonitorStorage interface {
+	GetOverdueCandidates(ctx context.Context, now time.Time, submittedBefore time.Time) ([]NewCycle, error)
+	SetOverdue(ctx context.Context, cy *NewCycle, reason string) error
+}
+
+// Monitor periodically flags cycles that are late: past their end date
+// without being done, or pending review for too long after submission.
+type Monitor struct {
+	storage    MonitorStorage
+	clock      app.Clock
+	interval   time.Duration
+	pendingFor time.Duration
+	logger     *zap.Logger
+}
+
+func NewMonitor(st MonitorStorage, clock app.Clock, interval time.Duration, pendingFor time.Duration, logger *zap.Logger) *Monitor {
+	return &Monitor{
+		storage:    st,
+		clock:      clock,
+		interval:   interval,
+		pendingFor: pendingFor,
+		logger:     logger,
+	}
+}
+
+// Run scans every interval until ctx is cancelled.
+func (m *Monitor) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.clock.After(m.interval):
+			flagged, err := m.Scan(ctx)
+			if err != nil {
+				m.logger.Error("cycle monitor scan failed", zap.Error(err))
+				continue
+			}
+			if flagged > 0 {
+				m.logger.Info("cycle monitor updated overdue cycles", zap.Int("count", flagged))
+			}
+		}
+	}
+}
+
+// Scan sets or clears the overdue marker of every cycle whose marker is out of
+// date and returns how many were changed.
+func (m *Monitor) Scan(ctx context.Context) (int, error) {
+	now := m.clock.Now()
+	cycles, err := m.storage.GetOverdueCandidates(ctx, now, now.Add(-m.pendingFor))
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for i := range cycles {
+		reason := OverdueReason(&cycles[i], now, m.pendingFor)
+		if reason == cycles[i].Overdue {
+			continue
+		}
+		if err := m.storage.SetOverdue(ctx, &cycles[i], reason); err != nil {
+			return changed, err
+		}
+		changed++
+	}
+
+	return changed, nil
+}
+
+// OverdueReason tells why a cycle is late at the given time, or "" when it
+// isn't. Being past the end date wins over a slow review.
+func OverdueReason(cy *NewCycle, now time.Time, pendingFor time.Duration) string {
+	if cy.Status == StatusDone {
+		return ""
+	}
+	if now.After(cy.EndDate) {
+		return OverduePastEndDate
+	}
+	if cy.Status == StatusPending && cy.SubmittedAt != nil && now.Sub(*cy.SubmittedAt) > pendingFor {
+		return OverduePendingReview
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package cycle
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// GetOverdueCandidates finds the cycles the monitor has to look at: the ones
+// that may be late and the ones already flagged.
+func (s *storage) GetOverdueCandidates(ctx context.Context, now time.Time, submittedBefore time.Time) ([]NewCycle, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	filter := bson.M{"deletedAt": nil, "$or": []bson.M{
+		{"status": bson.M{"$ne": StatusDone}, "endDate": bson.M{"$lt": now}},
+		{"status": StatusPending, "submittedAt": bson.M{"$lt": submittedBefore}},
+		{"overdue": bson.M{"$exists": true}},
+	}}
+	cursor, err := s.db.Collection(newCycleCollection).Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	cycles := []NewCycle{}
+	if err := cursor.All(ctx, &cycles); err != nil {
+		return nil, err
+	}
+	return cycles, nil
+}
+
+// SetOverdue sets the overdue marker of a cycle, or clears it when reason is
+// empty, and records the change.
+func (s *storage) SetOverdue(ctx context.Context, cy *NewCycle, reason string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"overdue": reason}}
+	if reason == "" {
+		update = bson.M{"$unset": bson.M{"overdue": ""}}
+	}
+	after := *cy
+	after.Overdue = reason
+	return s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		if _, err := s.db.Collection(newCycleCollection).UpdateOne(sc, bson.M{"_id": cy.ID}, update); err != nil {
+			return err
+		}
+		return s.recordEvent(sc, EventOverdue, MonitorActor, cy, &after)
+	})
+}
@@ -0,0 +1,99 @@
+package cycle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type mockMonitorStorage struct {
+	candidates      []NewCycle
+	submittedBefore time.Time
+	flagged         map[string]string
+}
+
+func (m *mockMonitorStorage) GetOverdueCandidates(ctx context.Context, now time.Time, submittedBefore time.Time) ([]NewCycle, error) {
+	m.submittedBefore = submittedBefore
+	return m.candidates, nil
+}
+
+func (m *mockMonitorStorage) SetOverdue(ctx context.Context, cy *NewCycle, reason string) error {
+	m.flagged[cy.AriserMail] = reason
+	return nil
+}
+
+func TestOverdueReason(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	pendingFor := 7 * 24 * time.Hour
+	longAgo := now.Add(-8 * 24 * time.Hour)
+	recently := now.Add(-24 * time.Hour)
+
+	testCases := []struct {
+		name  string
+		cycle NewCycle
+		want  string
+	}{
+		{
+			name:  "should flag a running cycle past its end date",
+			cycle: NewCycle{Status: StatusRunning, EndDate: now.Add(-time.Hour)},
+			want:  OverduePastEndDate,
+		},
+		{
+			name:  "should not flag a done cycle past its end date",
+			cycle: NewCycle{Status: StatusDone, EndDate: now.Add(-time.Hour)},
+			want:  "",
+		},
+		{
+			name:  "should flag a cycle pending review for too long",
+			cycle: NewCycle{Status: StatusPending, EndDate: now.Add(time.Hour), SubmittedAt: &longAgo},
+			want:  OverduePendingReview,
+		},
+		{
+			name:  "should not flag a cycle submitted recently",
+			cycle: NewCycle{Status: StatusPending, EndDate: now.Add(time.Hour), SubmittedAt: &recently},
+			want:  "",
+		},
+		{
+			name:  "should prefer end date over slow review",
+			cycle: NewCycle{Status: StatusPending, EndDate: now.Add(-time.Hour), SubmittedAt: &longAgo},
+			want:  OverduePastEndDate,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, OverdueReason(&tc.cycle, now, pendingFor))
+		})
+	}
+}
+
+func TestMonitorScan(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	clock := app.NewMockClock()
+	clock.On("Now").Return(now)
+
+	st := &mockMonitorStorage{
+		candidates: []NewCycle{
+			{AriserMail: "late@arise.tech", Status: StatusRunning, EndDate: now.Add(-time.Hour)},
+			{AriserMail: "flagged@arise.tech", Status: StatusRunning, EndDate: now.Add(-time.Hour), Overdue: OverduePastEndDate},
+			{AriserMail: "finished@arise.tech", Status: StatusDone, EndDate: now.Add(-time.Hour), Overdue: OverduePastEndDate},
+		},
+		flagged: map[string]string{},
+	}
+	monitor := NewMonitor(st, clock, time.Hour, 48*time.Hour, zap.NewNop())
+
+	changed, err := monitor.Scan(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, changed)
+	assert.Equal(t, now.Add(-48*time.Hour), st.submittedBefore)
+	assert.Equal(t, map[string]string{
+		"late@arise.tech":     OverduePastEndDate,
+		"finished@arise.tech": "",
+	}, st.flagged)
+}
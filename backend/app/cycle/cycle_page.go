@@ -0,0 +1,161 @@
+package cycle
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	SortStartDate = "startDate"
+	SortEndDate   = "endDate"
+	SortStatus    = "status"
+)
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+var invalidPageSizeError = cycleHandlerError{message: "limit must be from 1 to 100"}
+var invalidSortError = cycleHandlerError{message: "sort must be startDate, endDate or status and order asc or desc"}
+var invalidCursorError = cycleHandlerError{message: "invalid cursor"}
+
+// PageRequest asks for the cycles after Cursor in the given order. An empty
+// cursor asks for the first page.
+type PageRequest struct {
+	Cursor string
+	Limit  int
+	Sort   string
+	Desc   bool
+}
+
+// Page is one page of a list, with the number of items over all pages.
+// NextCursor asks for the page after this one while HasMore is true.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Total      int64  `json:"total"`
+	NextCursor string `json:"nextCursor,omitempty"`
+	HasMore    bool   `json:"hasMore"`
+}
+
+// withItems is the same page holding other items, such as the cycles of the
+// page with the details of their arisers.
+func withItems[T any, U any](p *Page[T], items []U) *Page[U] {
+	return &Page[U]{Items: items, Total: p.Total, NextCursor: p.NextCursor, HasMore: p.HasMore}
+}
+
+// pageCursor is where a page ended: the sort value and ID of its last cycle.
+// It is handed out base64 encoded and only fits a request with the same sort.
+type pageCursor struct {
+	Sort string             `json:"s"`
+	Desc bool               `json:"d"`
+	Time time.Time          `json:"t,omitempty"`
+	Text string             `json:"x,omitempty"`
+	ID   primitive.ObjectID `json:"id"`
+}
+
+// NewPageRequest reads a page request from the cursor, limit, sort and order
+// query parameters, newest start date first by default.
+func NewPageRequest(cursor string, limit string, sort string, order string) (PageRequest, error) {
+	page := PageRequest{Cursor: cursor, Limit: defaultPageSize, Sort: SortStartDate, Desc: true}
+	if limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 1 || n > maxPageSize {
+			return page, invalidPageSizeError
+		}
+		page.Limit = n
+	}
+
+	switch sort {
+	case "":
+	case SortStartDate, SortEndDate, SortStatus:
+		page.Sort = sort
+	default:
+		return page, invalidSortError
+	}
+
+	switch order {
+	case "", "desc":
+	case "asc":
+		page.Desc = false
+	default:
+		return page, invalidSortError
+	}
+
+	if cursor != "" {
+		if _, err := page.after(); err != nil {
+			return page, err
+		}
+	}
+	return page, nil
+}
+
+// pageRequest reads the page asked for by the query of the request,
+// answering it with 400 when the page is invalid.
+func pageRequest(c app.Context) (PageRequest, bool) {
+	page, err := NewPageRequest(c.Query("cursor"), c.Query("limit"), c.Query("sort"), c.Query("order"))
+	if err != nil {
+		c.BadRequest(err)
+		return page, false
+	}
+	return page, true
+}
+
+// after is the filter for the cycles that come after the cursor.
+func (p PageRequest) after() (bson.M, error) {
+	if p.Cursor == "" {
+		return bson.M{}, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(p.Cursor)
+	if err != nil {
+		return nil, invalidCursorError
+	}
+	var cur pageCursor
+	if err := json.Unmarshal(raw, &cur); err != nil || cur.Sort != p.Sort || cur.Desc != p.Desc {
+		return nil, invalidCursorError
+	}
+
+	var value any = cur.Time
+	if p.Sort == SortStatus {
+		value = cur.Text
+	}
+	op := "$gt"
+	if p.Desc {
+		op = "$lt"
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{p.Sort: bson.M{op: value}},
+		bson.M{p.Sort: value, "_id": bson.M{op: cur.ID}},
+	}}, nil
+}
+
+// sort orders by the sort field, then by ID so cycles with the same value
+// keep their place between pages.
+func (p PageRequest) sort() bson.D {
+	dir := 1
+	if p.Desc {
+		dir = -1
+	}
+	return bson.D{{Key: p.Sort, Value: dir}, {Key: "_id", Value: dir}}
+}
+
+// cursorAt is the cursor for the page after the given cycle.
+func (p PageRequest) cursorAt(cy *NewCycle) string {
+	cur := pageCursor{Sort: p.Sort, Desc: p.Desc, ID: cy.ID}
+	switch p.Sort {
+	case SortStartDate:
+		cur.Time = cy.StartDate
+	case SortEndDate:
+		cur.Time = cy.EndDate
+	case SortStatus:
+		cur.Text = cy.Status
+	}
+	raw, _ := json.Marshal(cur)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
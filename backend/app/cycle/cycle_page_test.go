@@ -0,0 +1,123 @@
+package cycle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestNewPageRequest(t *testing.T) {
+	testCases := []struct {
+		name     string
+		limit    string
+		sort     string
+		order    string
+		expected PageRequest
+		err      error
+	}{
+		{
+			name:     "should default to 10 cycles with the newest start date first",
+			expected: PageRequest{Limit: 10, Sort: SortStartDate, Desc: true},
+		},
+		{
+			name:     "should read limit, sort and order",
+			limit:    "25",
+			sort:     SortStatus,
+			order:    "asc",
+			expected: PageRequest{Limit: 25, Sort: SortStatus},
+		},
+		{
+			name:  "should fail when limit is over 100",
+			limit: "101",
+			err:   invalidPageSizeError,
+		},
+		{
+			name:  "should fail when limit is not a number",
+			limit: "ten",
+			err:   invalidPageSizeError,
+		},
+		{
+			name: "should fail when sort is unknown",
+			sort: "ariserMail",
+			err:  invalidSortError,
+		},
+		{
+			name:  "should fail when order is unknown",
+			order: "up",
+			err:   invalidSortError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			page, err := NewPageRequest("", tc.limit, tc.sort, tc.order)
+
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				assert.Equal(t, tc.expected, page)
+			}
+		})
+	}
+}
+
+func TestPageCursor(t *testing.T) {
+	cy := &NewCycle{
+		ID:        mockObjectId(5).objectId,
+		StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Status:    StatusPending,
+	}
+
+	t.Run("should continue after the last cycle in descending order", func(t *testing.T) {
+		first, _ := NewPageRequest("", "", SortStartDate, "desc")
+		next, err := NewPageRequest(first.cursorAt(cy), "", SortStartDate, "desc")
+		require.NoError(t, err)
+
+		filter, err := next.after()
+
+		require.NoError(t, err)
+		assert.Equal(t, bson.M{"$or": bson.A{
+			bson.M{"startDate": bson.M{"$lt": cy.StartDate}},
+			bson.M{"startDate": cy.StartDate, "_id": bson.M{"$lt": cy.ID}},
+		}}, filter)
+		assert.Equal(t, bson.D{{Key: "startDate", Value: -1}, {Key: "_id", Value: -1}}, next.sort())
+	})
+
+	t.Run("should continue after the last cycle by status in ascending order", func(t *testing.T) {
+		first, _ := NewPageRequest("", "", SortStatus, "asc")
+		next, err := NewPageRequest(first.cursorAt(cy), "", SortStatus, "asc")
+		require.NoError(t, err)
+
+		filter, err := next.after()
+
+		require.NoError(t, err)
+		assert.Equal(t, bson.M{"$or": bson.A{
+			bson.M{"status": bson.M{"$gt": StatusPending}},
+			bson.M{"status": StatusPending, "_id": bson.M{"$gt": cy.ID}},
+		}}, filter)
+	})
+
+	t.Run("should fail when the cursor was made for another sort", func(t *testing.T) {
+		first, _ := NewPageRequest("", "", SortStartDate, "desc")
+
+		_, err := NewPageRequest(first.cursorAt(cy), "", SortEndDate, "desc")
+
+		assert.Equal(t, invalidCursorError, err)
+	})
+
+	t.Run("should fail when the cursor is not base64", func(t *testing.T) {
+		_, err := NewPageRequest("%%%", "", "", "")
+
+		assert.Equal(t, invalidCursorError, err)
+	})
+}
+
+func TestWithItems(t *testing.T) {
+	page := &Page[*NewCycle]{Items: []*NewCycle{{AriserMail: "a@arise.tech"}}, Total: 12, NextCursor: "next", HasMore: true}
+
+	got := withItems(page, []string{"a@arise.tech"})
+
+	assert.Equal(t, &Page[string]{Items: []string{"a@arise.tech"}, Total: 12, NextCursor: "next", HasMore: true}, got)
+}
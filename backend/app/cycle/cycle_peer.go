@@ -0,0 +1,136 @@
+package cycle
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app/notification"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PeerFeedback is a peer invited to score some hard skills of a cycle, with
+// the scores they gave once submitted. Only the team lead sees the scores.
+type PeerFeedback struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	CycleID     primitive.ObjectID `json:"cycleId" bson:"cycleId"`
+	PeerMail    string             `json:"peerMail" bson:"peerMail"`
+	Skills      []string           `json:"skills" bson:"skills"`
+	InvitedBy   string             `json:"invitedBy" bson:"invitedBy"`
+	InvitedAt   time.Time          `json:"invitedAt" bson:"invitedAt"`
+	Scores      []PeerScore        `json:"scores" bson:"scores"`
+	SubmittedAt *time.Time         `json:"submittedAt,omitempty" bson:"submittedAt,omitempty"`
+}
+
+type PeerScore struct {
+	Skill   string `json:"skill" bson:"skill" binding:"required"`
+	Score   int    `json:"score" bson:"score" binding:"required,min=1"`
+	Comment string `json:"comment" bson:"comment"`
+}
+
+type InvitePeerRequest struct {
+	PeerMail string   `json:"peerMail" binding:"required"`
+	Skills   []string `json:"skills" binding:"required,min=1"`
+}
+
+type PeerFeedbackRequest struct {
+	Scores []PeerScore `json:"scores" binding:"required,min=1,dive"`
+}
+
+// PeerScoreSummary sums up the peer scores of one hard skill.
+type PeerScoreSummary struct {
+	Skill   string  `json:"skill"`
+	Count   int     `json:"count"`
+	Average float64 `json:"average"`
+	Min     int     `json:"min"`
+	Max     int     `json:"max"`
+}
+
+// PeerFeedbackView is the peer feedback of a cycle as one member sees it.
+// Scores is only filled in for the team lead.
+type PeerFeedbackView struct {
+	Feedback []PeerFeedback     `json:"feedback"`
+	Scores   []PeerScoreSummary `json:"scores,omitempty"`
+}
+
+// SummarizePeerScores sums up the submitted peer scores of each hard skill of
+// the cycle, in the order of its skills. Skills nobody scored are left out.
+func SummarizePeerScores(hardSkills []HardSkill, feedback []PeerFeedback) []PeerScoreSummary {
+	scores := make(map[string][]int)
+	for _, f := range feedback {
+		for _, s := range f.Scores {
+			scores[s.Skill] = append(scores[s.Skill], s.Score)
+		}
+	}
+
+	summaries := []PeerScoreSummary{}
+	for _, hs := range hardSkills {
+		given := scores[hs.Name]
+		if len(given) == 0 {
+			continue
+		}
+		summary := PeerScoreSummary{Skill: hs.Name, Count: len(given), Min: given[0], Max: given[0]}
+		total := 0
+		for _, s := range given {
+			total += s
+			summary.Min = min(summary.Min, s)
+			summary.Max = max(summary.Max, s)
+		}
+		summary.Average = math.Round(float64(total)/float64(len(given))*100) / 100
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
+
+// withoutScores hides the scores of the feedback from everyone but the lead.
+func withoutScores(feedback []PeerFeedback) []PeerFeedback {
+	hidden := make([]PeerFeedback, len(feedback))
+	for i, f := range feedback {
+		f.Scores = []PeerScore{}
+		hidden[i] = f
+	}
+	return hidden
+}
+
+// checkPeerSkills returns a CycleScoreError for the first skill that isn't a
+// hard skill of the cycle.
+func checkPeerSkills(cy *NewCycle, skills []string) error {
+	for _, name := range skills {
+		if _, err := findSkill(cy.HardSkills, name, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkPeerScores returns a CycleScoreError for the first score on a skill the
+// peer wasn't invited to, given twice or above the top level of the skill.
+func checkPeerScores(cy *NewCycle, invite *PeerFeedback, scores []PeerScore) error {
+	invited := make(map[string]bool)
+	for _, name := range invite.Skills {
+		invited[name] = true
+	}
+	seen := make(map[string]bool)
+	for _, s := range scores {
+		if !invited[s.Skill] {
+			return CycleScoreError{Skill: s.Skill, Reason: "you were not invited to score this skill"}
+		}
+		if seen[s.Skill] {
+			return CycleScoreError{Skill: s.Skill, Reason: "skill is scored more than once"}
+		}
+		seen[s.Skill] = true
+		if _, err := findSkill(cy.HardSkills, s.Skill, s.Score); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func peerInviteNotification(cy *NewCycle, invite *PeerFeedback) notification.Notification {
+	return notification.Notification{
+		Recipient: invite.PeerMail,
+		Kind:      notification.KindPeerFeedback,
+		Message:   fmt.Sprintf("%s asks for your feedback on the cycle of %s", invite.InvitedBy, cy.AriserMail),
+		CycleID:   &invite.CycleID,
+	}
+}
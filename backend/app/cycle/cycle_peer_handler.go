@@ -0,0 +1,269 @@
+package cycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/notification"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/user"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type PeerStorage interface {
+	GetNewByID(id string) (*NewCycle, error)
+	GetUsersHardSkillByEmail(ctx context.Context, email string) (*user.User, error)
+	InvitePeer(ctx context.Context, invite PeerFeedback) (*PeerFeedback, error)
+	GetPeerFeedback(ctx context.Context, cycleID primitive.ObjectID) ([]PeerFeedback, error)
+	GetPeerInvite(ctx context.Context, cycleID primitive.ObjectID, peerMail string) (*PeerFeedback, error)
+	SubmitPeerFeedback(ctx context.Context, cycleID primitive.ObjectID, peerMail string, scores []PeerScore) (*PeerFeedback, error)
+	GetPeerRequests(ctx context.Context, peerMail string, pendingOnly bool) ([]PeerFeedback, error)
+}
+
+type peerHandler struct {
+	storage  PeerStorage
+	notifier Notifier
+}
+
+func NewPeerHandler(st PeerStorage, notifier Notifier) *peerHandler {
+	return &peerHandler{
+		storage:  st,
+		notifier: notifier,
+	}
+}
+
+var notPeerInviterError = cycleHandlerError{message: "only the ariser and team lead of this cycle can invite peers"}
+var notPeerViewerError = cycleHandlerError{message: "only the members of this cycle and its invited peers can see its peer feedback"}
+var invalidPeerInviteError = cycleHandlerError{message: "invalid peer invite, peerMail and at least one skill are required"}
+var invalidPeerFeedbackError = cycleHandlerError{message: "invalid peer feedback, at least one score is required"}
+var peerIsMemberError = cycleHandlerError{message: "peer must be someone other than the ariser and team lead"}
+var peerNotFoundError = cycleHandlerError{message: "peer not found"}
+var cycleDoneError = cycleHandlerError{message: "cycle is done"}
+
+// InvitePeer godoc
+//
+//	@summary		InvitePeer
+//	@description	Invite a peer to score some hard skills of a cycle. Inviting the same peer again adds the skills to their invite. The peer is notified. Only the ariser and team lead can do it, while the cycle isn't done.
+//	@tags			cycle
+//	@id				InvitePeer
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			id		path		string				true	"Cycle ID"
+//	@param			reqJson	body		InvitePeerRequest	true	"Peer and hard skills"
+//	@response		200		{object}	cycle.PeerFeedback	"OK"
+//	@response		400		{object}	app.Response		"Bad Request"
+//	@response		403		{object}	app.Response		"Not a member of the cycle"
+//	@response		404		{object}	app.Response		"Cycle or peer not found"
+//	@response		409		{object}	app.Response		"Cycle is done"
+//	@response		450		{object}	app.Response		"Store Error"
+//	@response		500		{object}	app.Response		"Internal Server Error"
+//	@router			/cycles/{id}/peers [post]
+func (h *peerHandler) InvitePeer(c app.Context) {
+	email := c.GetString("email")
+
+	var req InvitePeerRequest
+	if err := c.Bind(&req); err != nil {
+		c.BadRequest(invalidPeerInviteError)
+		return
+	}
+	if err := ValidateEmail(req.PeerMail); err != nil {
+		c.BadRequest(err)
+		return
+	}
+
+	cy, ok := h.cycle(c)
+	if !ok {
+		return
+	}
+	if ActorOf(cy, email) == "" {
+		c.Forbidden(notPeerInviterError)
+		return
+	}
+	if ActorOf(cy, req.PeerMail) != "" {
+		c.BadRequest(peerIsMemberError)
+		return
+	}
+	if cy.Status == StatusDone {
+		c.Conflict(cycleDoneError)
+		return
+	}
+	if err := checkPeerSkills(cy, req.Skills); err != nil {
+		c.BadRequest(err)
+		return
+	}
+
+	if _, err := h.storage.GetUsersHardSkillByEmail(c.Ctx(), req.PeerMail); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.NotFound(peerNotFoundError)
+			return
+		}
+		c.InternalServerError(err)
+		return
+	}
+
+	invite, err := h.storage.InvitePeer(c.Ctx(), PeerFeedback{
+		CycleID:   cy.ID,
+		PeerMail:  req.PeerMail,
+		Skills:    req.Skills,
+		InvitedBy: email,
+		InvitedAt: time.Now(),
+	})
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	if err := h.notifier.Notify(c.Ctx(), []notification.Notification{peerInviteNotification(cy, invite)}); err != nil {
+		c.InternalServerError(fmt.Errorf("peer was invited but the notification failed: %w", err))
+		return
+	}
+
+	c.OK(invite)
+}
+
+// Peers godoc
+//
+//	@summary		Peers
+//	@description	List the peers invited on a cycle. The team lead sees their scores and the average peer score of each hard skill, the ariser only who was invited on which skills, and an invited peer only their own feedback.
+//	@tags			cycle
+//	@id				Peers
+//	@security		BearerAuth
+//	@produce		json
+//	@param			id	path		string					true	"Cycle ID"
+//	@response		200	{object}	cycle.PeerFeedbackView	"OK"
+//	@response		400	{object}	app.Response			"Bad Request"
+//	@response		403	{object}	app.Response			"Not a member of the cycle nor an invited peer"
+//	@response		404	{object}	app.Response			"Cycle not found"
+//	@response		450	{object}	app.Response			"Store Error"
+//	@router			/cycles/{id}/peers [get]
+func (h *peerHandler) Peers(c app.Context) {
+	email := c.GetString("email")
+	cy, ok := h.cycle(c)
+	if !ok {
+		return
+	}
+
+	actor := ActorOf(cy, email)
+	if actor == "" {
+		invite, err := h.storage.GetPeerInvite(c.Ctx(), cy.ID, email)
+		if err != nil {
+			if err == peerInviteNotFoundError {
+				c.Forbidden(notPeerViewerError)
+				return
+			}
+			c.StoreError(err)
+			return
+		}
+		c.OK(PeerFeedbackView{Feedback: []PeerFeedback{*invite}})
+		return
+	}
+
+	feedback, err := h.storage.GetPeerFeedback(c.Ctx(), cy.ID)
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+	if actor != ActorTeamLeader {
+		c.OK(PeerFeedbackView{Feedback: withoutScores(feedback)})
+		return
+	}
+
+	c.OK(PeerFeedbackView{Feedback: feedback, Scores: SummarizePeerScores(cy.HardSkills, feedback)})
+}
+
+// SubmitPeerFeedback godoc
+//
+//	@summary		SubmitPeerFeedback
+//	@description	Score the hard skills of a cycle you were invited on, with a comment on each. Submitting again replaces your scores. Only the team lead sees them. It can't be done once the cycle is done.
+//	@tags			cycle
+//	@id				SubmitPeerFeedback
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			id		path		string				true	"Cycle ID"
+//	@param			reqJson	body		PeerFeedbackRequest	true	"Peer scores"
+//	@response		200		{object}	cycle.PeerFeedback	"OK"
+//	@response		400		{object}	app.Response		"Bad Request"
+//	@response		403		{object}	app.Response		"Not an invited peer"
+//	@response		404		{object}	app.Response		"Cycle not found"
+//	@response		409		{object}	app.Response		"Cycle is done"
+//	@response		450		{object}	app.Response		"Store Error"
+//	@router			/cycles/{id}/peer-feedback [put]
+func (h *peerHandler) SubmitPeerFeedback(c app.Context) {
+	email := c.GetString("email")
+
+	var req PeerFeedbackRequest
+	if err := c.Bind(&req); err != nil {
+		c.BadRequest(invalidPeerFeedbackError)
+		return
+	}
+
+	cy, ok := h.cycle(c)
+	if !ok {
+		return
+	}
+	invite, err := h.storage.GetPeerInvite(c.Ctx(), cy.ID, email)
+	if err != nil {
+		if err == peerInviteNotFoundError {
+			c.Forbidden(err)
+			return
+		}
+		c.StoreError(err)
+		return
+	}
+	if cy.Status == StatusDone {
+		c.Conflict(cycleDoneError)
+		return
+	}
+	if err := checkPeerScores(cy, invite, req.Scores); err != nil {
+		c.BadRequest(err)
+		return
+	}
+
+	feedback, err := h.storage.SubmitPeerFeedback(c.Ctx(), cy.ID, email, req.Scores)
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(feedback)
+}
+
+// PeerRequests godoc
+//
+//	@summary		PeerRequests
+//	@description	List the cycles you were invited to give feedback on, newest first. Use pending=true to only get the ones you haven't submitted yet.
+//	@tags			cycle
+//	@id				PeerRequests
+//	@security		BearerAuth
+//	@produce		json
+//	@param			pending	query		bool				false	"Only the feedback not submitted yet"
+//	@response		200		{array}		cycle.PeerFeedback	"OK"
+//	@response		450		{object}	app.Response		"Store Error"
+//	@router			/peer-feedback [get]
+func (h *peerHandler) PeerRequests(c app.Context) {
+	requests, err := h.storage.GetPeerRequests(c.Ctx(), c.GetString("email"), c.Query("pending") == "true")
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(requests)
+}
+
+func (h *peerHandler) cycle(c app.Context) (*NewCycle, bool) {
+	cy, err := h.storage.GetNewByID(c.Param("id"))
+	if err != nil {
+		if err == cycleNotFoundError {
+			c.NotFound(err)
+			return nil, false
+		}
+		c.BadRequest(err)
+		return nil, false
+	}
+	return cy, true
+}
@@ -0,0 +1,446 @@
+package cycle
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/user"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+type mockPeerStorage struct {
+	cycle    *NewCycle
+	feedback []PeerFeedback
+	userErr  error
+	err      error
+	invited  *PeerFeedback
+	pending  bool
+}
+
+func (m *mockPeerStorage) GetNewByID(id string) (*NewCycle, error) {
+	if m.cycle == nil || id != m.cycle.ID.Hex() {
+		return nil, cycleNotFoundError
+	}
+	return m.cycle, nil
+}
+
+func (m *mockPeerStorage) GetUsersHardSkillByEmail(ctx context.Context, email string) (*user.User, error) {
+	if m.userErr != nil {
+		return nil, m.userErr
+	}
+	return &user.User{Email: email}, nil
+}
+
+func (m *mockPeerStorage) InvitePeer(ctx context.Context, invite PeerFeedback) (*PeerFeedback, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	invite.Scores = []PeerScore{}
+	m.invited = &invite
+	return &invite, nil
+}
+
+func (m *mockPeerStorage) GetPeerFeedback(ctx context.Context, cycleID primitive.ObjectID) ([]PeerFeedback, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.feedback, nil
+}
+
+func (m *mockPeerStorage) GetPeerInvite(ctx context.Context, cycleID primitive.ObjectID, peerMail string) (*PeerFeedback, error) {
+	for _, f := range m.feedback {
+		if f.CycleID == cycleID && f.PeerMail == peerMail {
+			return &f, nil
+		}
+	}
+	return nil, peerInviteNotFoundError
+}
+
+func (m *mockPeerStorage) SubmitPeerFeedback(ctx context.Context, cycleID primitive.ObjectID, peerMail string, scores []PeerScore) (*PeerFeedback, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	f, err := m.GetPeerInvite(ctx, cycleID, peerMail)
+	if err != nil {
+		return nil, err
+	}
+	f.Scores = scores
+	return f, nil
+}
+
+func (m *mockPeerStorage) GetPeerRequests(ctx context.Context, peerMail string, pendingOnly bool) ([]PeerFeedback, error) {
+	m.pending = pendingOnly
+	requests := []PeerFeedback{}
+	for _, f := range m.feedback {
+		if f.PeerMail == peerMail {
+			requests = append(requests, f)
+		}
+	}
+	return requests, nil
+}
+
+func peerFixtures() []PeerFeedback {
+	invitedAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	return []PeerFeedback{
+		{
+			CycleID:   mockObjectId(1).objectId,
+			PeerMail:  "a@arise.tech",
+			Skills:    []string{"Go", "CSS"},
+			InvitedBy: "ariser@arise.tech",
+			InvitedAt: invitedAt,
+			Scores:    []PeerScore{{Skill: "Go", Score: 3, Comment: "good"}},
+		},
+		{
+			CycleID:   mockObjectId(1).objectId,
+			PeerMail:  "b@arise.tech",
+			Skills:    []string{"Go"},
+			InvitedBy: "lead@arise.tech",
+			InvitedAt: invitedAt,
+			Scores:    []PeerScore{{Skill: "Go", Score: 4}},
+		},
+	}
+}
+
+func TestInvitePeer(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reqBody := `{"peerMail":"peer@arise.tech","skills":["Go"]}`
+
+	testCases := []struct {
+		name             string
+		email            string
+		id               string
+		reqBody          string
+		status           string
+		userErr          error
+		storageErr       error
+		notifyErr        error
+		expectedStatus   int
+		expectedResponse string
+		expectedSent     int
+	}{
+		{
+			name:           "should return 200 and notify the peer when the ariser invites",
+			email:          "ariser@arise.tech",
+			reqBody:        reqBody,
+			expectedStatus: http.StatusOK,
+			expectedSent:   1,
+		},
+		{
+			name:           "should return 200 when the lead invites",
+			email:          "lead@arise.tech",
+			reqBody:        reqBody,
+			expectedStatus: http.StatusOK,
+			expectedSent:   1,
+		},
+		{
+			name:             "should return 400 when skills are missing",
+			email:            "ariser@arise.tech",
+			reqBody:          `{"peerMail":"peer@arise.tech","skills":[]}`,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid peer invite, peerMail and at least one skill are required"}`,
+		},
+		{
+			name:             "should return 400 when the peer is the lead",
+			email:            "ariser@arise.tech",
+			reqBody:          `{"peerMail":"lead@arise.tech","skills":["Go"]}`,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"peer must be someone other than the ariser and team lead"}`,
+		},
+		{
+			name:             "should return 400 when a skill is not in the cycle",
+			email:            "ariser@arise.tech",
+			reqBody:          `{"peerMail":"peer@arise.tech","skills":["Rust"]}`,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"cannot score \"Rust\": skill is not in this cycle"}`,
+		},
+		{
+			name:             "should return 403 when user is not a member of the cycle",
+			email:            "other@arise.tech",
+			reqBody:          reqBody,
+			expectedStatus:   http.StatusForbidden,
+			expectedResponse: `{"status":"error","message":"only the ariser and team lead of this cycle can invite peers"}`,
+		},
+		{
+			name:             "should return 404 when cycle is not found",
+			email:            "ariser@arise.tech",
+			id:               mockObjectId(9).hexId,
+			reqBody:          reqBody,
+			expectedStatus:   http.StatusNotFound,
+			expectedResponse: `{"status":"error","message":"cycle not found"}`,
+		},
+		{
+			name:             "should return 404 when the peer is not a user",
+			email:            "ariser@arise.tech",
+			reqBody:          reqBody,
+			userErr:          mongo.ErrNoDocuments,
+			expectedStatus:   http.StatusNotFound,
+			expectedResponse: `{"status":"error","message":"peer not found"}`,
+		},
+		{
+			name:             "should return 409 when the cycle is done",
+			email:            "ariser@arise.tech",
+			reqBody:          reqBody,
+			status:           StatusDone,
+			expectedStatus:   http.StatusConflict,
+			expectedResponse: `{"status":"error","message":"cycle is done"}`,
+		},
+		{
+			name:             "should return 450 when invite is failed",
+			email:            "ariser@arise.tech",
+			reqBody:          reqBody,
+			storageErr:       errors.New("update error"),
+			expectedStatus:   450,
+			expectedResponse: `{"status":"error","message":"update error"}`,
+		},
+		{
+			name:             "should return 500 when the notification is failed",
+			email:            "ariser@arise.tech",
+			reqBody:          reqBody,
+			notifyErr:        errors.New("insert error"),
+			expectedStatus:   http.StatusInternalServerError,
+			expectedResponse: `{"status":"error","message":"peer was invited but the notification failed: insert error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cy := peerCycle()
+			if tc.status != "" {
+				cy.Status = tc.status
+			}
+			st := &mockPeerStorage{cycle: cy, userErr: tc.userErr, err: tc.storageErr}
+			notifier := &mockNotifier{err: tc.notifyErr}
+			handler := NewPeerHandler(st, notifier)
+			id := tc.id
+			if id == "" {
+				id = mockObjectId(1).hexId
+			}
+
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", tc.email)
+			})
+			engine.POST("/cycles/:id/peers", app.NewGinHandler(handler.InvitePeer, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/cycles/"+id+"/peers", strings.NewReader(tc.reqBody))
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedResponse != "" {
+				assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+			}
+			assert.Len(t, notifier.sent, tc.expectedSent)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, tc.email, st.invited.InvitedBy)
+				assert.Equal(t, "peer@arise.tech", notifier.sent[0].Recipient)
+				assert.Equal(t, tc.email+" asks for your feedback on the cycle of ariser@arise.tech", notifier.sent[0].Message)
+			}
+		})
+	}
+}
+
+func TestPeers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name             string
+		email            string
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:           "should return every score and the summary to the lead",
+			email:          "lead@arise.tech",
+			expectedStatus: http.StatusOK,
+			expectedResponse: `{
+				"status": "success",
+				"message": "",
+				"data": {
+					"feedback": [
+						{"id":"000000000000000000000000","cycleId":"000000000000000000000001","peerMail":"a@arise.tech","skills":["Go","CSS"],"invitedBy":"ariser@arise.tech","invitedAt":"2024-03-01T00:00:00Z","scores":[{"skill":"Go","score":3,"comment":"good"}]},
+						{"id":"000000000000000000000000","cycleId":"000000000000000000000001","peerMail":"b@arise.tech","skills":["Go"],"invitedBy":"lead@arise.tech","invitedAt":"2024-03-01T00:00:00Z","scores":[{"skill":"Go","score":4,"comment":""}]}
+					],
+					"scores": [{"skill":"Go","count":2,"average":3.5,"min":3,"max":4}]
+				}
+			}`,
+		},
+		{
+			name:           "should hide the scores from the ariser",
+			email:          "ariser@arise.tech",
+			expectedStatus: http.StatusOK,
+			expectedResponse: `{
+				"status": "success",
+				"message": "",
+				"data": {
+					"feedback": [
+						{"id":"000000000000000000000000","cycleId":"000000000000000000000001","peerMail":"a@arise.tech","skills":["Go","CSS"],"invitedBy":"ariser@arise.tech","invitedAt":"2024-03-01T00:00:00Z","scores":[]},
+						{"id":"000000000000000000000000","cycleId":"000000000000000000000001","peerMail":"b@arise.tech","skills":["Go"],"invitedBy":"lead@arise.tech","invitedAt":"2024-03-01T00:00:00Z","scores":[]}
+					]
+				}
+			}`,
+		},
+		{
+			name:           "should return only their own feedback to an invited peer",
+			email:          "b@arise.tech",
+			expectedStatus: http.StatusOK,
+			expectedResponse: `{
+				"status": "success",
+				"message": "",
+				"data": {
+					"feedback": [
+						{"id":"000000000000000000000000","cycleId":"000000000000000000000001","peerMail":"b@arise.tech","skills":["Go"],"invitedBy":"lead@arise.tech","invitedAt":"2024-03-01T00:00:00Z","scores":[{"skill":"Go","score":4,"comment":""}]}
+					]
+				}
+			}`,
+		},
+		{
+			name:             "should return 403 when user is neither a member nor an invited peer",
+			email:            "other@arise.tech",
+			expectedStatus:   http.StatusForbidden,
+			expectedResponse: `{"status":"error","message":"only the members of this cycle and its invited peers can see its peer feedback"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockPeerStorage{cycle: peerCycle(), feedback: peerFixtures()}
+			handler := NewPeerHandler(st, &mockNotifier{})
+
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", tc.email)
+			})
+			engine.GET("/cycles/:id/peers", app.NewGinHandler(handler.Peers, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/cycles/"+mockObjectId(1).hexId+"/peers", nil)
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestSubmitPeerFeedback(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reqBody := `{"scores":[{"skill":"Go","score":4,"comment":"great reviews"},{"skill":"CSS","score":2}]}`
+
+	testCases := []struct {
+		name             string
+		email            string
+		reqBody          string
+		status           string
+		storageErr       error
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:           "should return 200 when an invited peer submits",
+			email:          "a@arise.tech",
+			reqBody:        reqBody,
+			expectedStatus: http.StatusOK,
+			expectedResponse: `{
+				"status": "success",
+				"message": "",
+				"data": {"id":"000000000000000000000000","cycleId":"000000000000000000000001","peerMail":"a@arise.tech","skills":["Go","CSS"],"invitedBy":"ariser@arise.tech","invitedAt":"2024-03-01T00:00:00Z","scores":[{"skill":"Go","score":4,"comment":"great reviews"},{"skill":"CSS","score":2,"comment":""}]}
+			}`,
+		},
+		{
+			name:             "should return 400 when scores are missing",
+			email:            "a@arise.tech",
+			reqBody:          `{"scores":[]}`,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid peer feedback, at least one score is required"}`,
+		},
+		{
+			name:             "should return 400 when a skill was not invited",
+			email:            "b@arise.tech",
+			reqBody:          reqBody,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"cannot score \"CSS\": you were not invited to score this skill"}`,
+		},
+		{
+			name:             "should return 403 when user was not invited",
+			email:            "lead@arise.tech",
+			reqBody:          reqBody,
+			expectedStatus:   http.StatusForbidden,
+			expectedResponse: `{"status":"error","message":"you were not invited to give feedback on this cycle"}`,
+		},
+		{
+			name:             "should return 409 when the cycle is done",
+			email:            "a@arise.tech",
+			reqBody:          reqBody,
+			status:           StatusDone,
+			expectedStatus:   http.StatusConflict,
+			expectedResponse: `{"status":"error","message":"cycle is done"}`,
+		},
+		{
+			name:             "should return 450 when submit is failed",
+			email:            "a@arise.tech",
+			reqBody:          reqBody,
+			storageErr:       errors.New("update error"),
+			expectedStatus:   450,
+			expectedResponse: `{"status":"error","message":"update error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cy := peerCycle()
+			if tc.status != "" {
+				cy.Status = tc.status
+			}
+			st := &mockPeerStorage{cycle: cy, feedback: peerFixtures(), err: tc.storageErr}
+			handler := NewPeerHandler(st, &mockNotifier{})
+
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", tc.email)
+			})
+			engine.PUT("/cycles/:id/peer-feedback", app.NewGinHandler(handler.SubmitPeerFeedback, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/cycles/"+mockObjectId(1).hexId+"/peer-feedback", strings.NewReader(tc.reqBody))
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
+
+func TestPeerRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should return the caller's requests and pass the pending filter", func(t *testing.T) {
+		st := &mockPeerStorage{cycle: peerCycle(), feedback: peerFixtures()}
+		handler := NewPeerHandler(st, &mockNotifier{})
+
+		engine := gin.New()
+		engine.Use(func(c *gin.Context) {
+			c.Set("email", "b@arise.tech")
+		})
+		engine.GET("/peer-feedback", app.NewGinHandler(handler.PeerRequests, zap.NewNop()))
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/peer-feedback?pending=true", nil)
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, st.pending)
+		assert.Contains(t, rec.Body.String(), `"peerMail":"b@arise.tech"`)
+		assert.NotContains(t, rec.Body.String(), `"peerMail":"a@arise.tech"`)
+	})
+}
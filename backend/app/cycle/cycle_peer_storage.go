@@ -0,0 +1,157 @@
+package cycle
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const peerFeedbackCollection = "cycle_peer_feedback"
+
+var peerInviteNotFoundError = CycleStorageError{message: "you were not invited to give feedback on this cycle"}
+
+// InvitePeer invites a peer to score hard skills of a cycle. Inviting the same
+// peer again adds the new skills to their invite and keeps their scores. The
+// invite and its event are written in one transaction.
+func (s *storage) InvitePeer(ctx context.Context, invite PeerFeedback) (*PeerFeedback, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"cycleId": invite.CycleID, "peerMail": invite.PeerMail}
+	update := bson.M{
+		"$addToSet":    bson.M{"skills": bson.M{"$each": invite.Skills}},
+		"$setOnInsert": bson.M{"invitedBy": invite.InvitedBy, "invitedAt": invite.InvitedAt, "scores": []PeerScore{}},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.Before)
+
+	var after PeerFeedback
+	err := s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		var before PeerFeedback
+		skills := []string{}
+		err := s.db.Collection(peerFeedbackCollection).FindOneAndUpdate(sc, filter, update, opts).Decode(&before)
+		switch {
+		case err == mongo.ErrNoDocuments:
+		case err != nil:
+			return err
+		default:
+			skills = before.Skills
+		}
+
+		if err := s.db.Collection(peerFeedbackCollection).FindOne(sc, filter).Decode(&after); err != nil {
+			return err
+		}
+
+		event := CycleEvent{
+			CycleID: after.CycleID,
+			Type:    EventPeerInvited,
+			Actor:   invite.InvitedBy,
+			At:      time.Now(),
+			Changes: []FieldChange{{Field: "peers." + after.PeerMail, Before: skills, After: after.Skills}},
+		}
+		_, err = s.db.Collection(cycleEventCollection).InsertOne(sc, event)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &after, nil
+}
+
+// GetPeerFeedback lists the peers invited on a cycle, first invited first.
+func (s *storage) GetPeerFeedback(ctx context.Context, cycleID primitive.ObjectID) ([]PeerFeedback, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "invitedAt", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := s.db.Collection(peerFeedbackCollection).Find(ctx, bson.M{"cycleId": cycleID}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	feedback := []PeerFeedback{}
+	if err := cursor.All(ctx, &feedback); err != nil {
+		return nil, err
+	}
+	return feedback, nil
+}
+
+func (s *storage) GetPeerInvite(ctx context.Context, cycleID primitive.ObjectID, peerMail string) (*PeerFeedback, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var invite PeerFeedback
+	filter := bson.M{"cycleId": cycleID, "peerMail": peerMail}
+	if err := s.db.Collection(peerFeedbackCollection).FindOne(ctx, filter).Decode(&invite); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, peerInviteNotFoundError
+		}
+		return nil, err
+	}
+	return &invite, nil
+}
+
+// SubmitPeerFeedback replaces the scores a peer gave on a cycle. The event
+// only records when the peer submitted, the scores are for the team lead.
+func (s *storage) SubmitPeerFeedback(ctx context.Context, cycleID primitive.ObjectID, peerMail string, scores []PeerScore) (*PeerFeedback, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"cycleId": cycleID, "peerMail": peerMail}
+	update := bson.M{"$set": bson.M{"scores": scores, "submittedAt": now}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var feedback PeerFeedback
+	err := s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		if err := s.db.Collection(peerFeedbackCollection).FindOneAndUpdate(sc, filter, update, opts).Decode(&feedback); err != nil {
+			if err == mongo.ErrNoDocuments {
+				return peerInviteNotFoundError
+			}
+			return err
+		}
+
+		event := CycleEvent{
+			CycleID: cycleID,
+			Type:    EventPeerSubmitted,
+			Actor:   peerMail,
+			At:      now,
+			Changes: []FieldChange{{Field: "peers." + peerMail + ".submittedAt", Before: feedback.SubmittedAt, After: now}},
+		}
+		_, err := s.db.Collection(cycleEventCollection).InsertOne(sc, event)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	feedback.Scores = scores
+	feedback.SubmittedAt = &now
+	return &feedback, nil
+}
+
+// GetPeerRequests lists the cycles a peer was invited to give feedback on,
+// newest first, optionally only the ones not submitted yet.
+func (s *storage) GetPeerRequests(ctx context.Context, peerMail string, pendingOnly bool) ([]PeerFeedback, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"peerMail": peerMail}
+	if pendingOnly {
+		filter["submittedAt"] = nil
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "invitedAt", Value: -1}, {Key: "_id", Value: -1}})
+	cursor, err := s.db.Collection(peerFeedbackCollection).Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	requests := []PeerFeedback{}
+	if err := cursor.All(ctx, &requests); err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
@@ -0,0 +1,89 @@
+package cycle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func peerCycle() *NewCycle {
+	levels := []SkillLevel{{Level: 1}, {Level: 2}, {Level: 3}, {Level: 4}, {Level: 5}}
+	return &NewCycle{
+		ID:             mockObjectId(1).objectId,
+		AriserMail:     "ariser@arise.tech",
+		TeamLeaderMail: "lead@arise.tech",
+		Status:         StatusRunning,
+		HardSkills: []HardSkill{
+			{Name: "Go", SkillLevels: levels},
+			{Name: "CSS", SkillLevels: levels},
+			{Name: "SQL", SkillLevels: levels},
+		},
+	}
+}
+
+func TestSummarizePeerScores(t *testing.T) {
+	t.Run("should sum up the submitted scores of each skill in the cycle's order", func(t *testing.T) {
+		feedback := []PeerFeedback{
+			{PeerMail: "a@arise.tech", Scores: []PeerScore{{Skill: "CSS", Score: 2}, {Skill: "Go", Score: 3}}},
+			{PeerMail: "b@arise.tech", Scores: []PeerScore{{Skill: "Go", Score: 4}}},
+			{PeerMail: "c@arise.tech", Scores: []PeerScore{{Skill: "Go", Score: 4}}},
+			{PeerMail: "d@arise.tech", Scores: []PeerScore{}},
+		}
+
+		got := SummarizePeerScores(peerCycle().HardSkills, feedback)
+
+		assert.Equal(t, []PeerScoreSummary{
+			{Skill: "Go", Count: 3, Average: 3.67, Min: 3, Max: 4},
+			{Skill: "CSS", Count: 1, Average: 2, Min: 2, Max: 2},
+		}, got)
+	})
+
+	t.Run("should return an empty list when nobody submitted", func(t *testing.T) {
+		got := SummarizePeerScores(peerCycle().HardSkills, []PeerFeedback{{PeerMail: "a@arise.tech"}})
+
+		assert.Equal(t, []PeerScoreSummary{}, got)
+	})
+}
+
+func TestCheckPeerScores(t *testing.T) {
+	invite := &PeerFeedback{PeerMail: "peer@arise.tech", Skills: []string{"Go", "CSS"}}
+
+	testCases := []struct {
+		name    string
+		scores  []PeerScore
+		wantErr error
+	}{
+		{
+			name:   "should accept scores on invited skills",
+			scores: []PeerScore{{Skill: "Go", Score: 3, Comment: "solid"}, {Skill: "CSS", Score: 5}},
+		},
+		{
+			name:    "should reject a skill the peer was not invited to",
+			scores:  []PeerScore{{Skill: "SQL", Score: 3}},
+			wantErr: CycleScoreError{Skill: "SQL", Reason: "you were not invited to score this skill"},
+		},
+		{
+			name:    "should reject a skill scored twice",
+			scores:  []PeerScore{{Skill: "Go", Score: 3}, {Skill: "Go", Score: 4}},
+			wantErr: CycleScoreError{Skill: "Go", Reason: "skill is scored more than once"},
+		},
+		{
+			name:    "should reject a score above the top level",
+			scores:  []PeerScore{{Skill: "Go", Score: 6}},
+			wantErr: CycleScoreError{Skill: "Go", Reason: "score must be at most 5"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkPeerScores(peerCycle(), invite, tc.scores)
+
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestCheckPeerSkills(t *testing.T) {
+	assert.NoError(t, checkPeerSkills(peerCycle(), []string{"Go", "SQL"}))
+	assert.Equal(t, CycleScoreError{Skill: "Rust", Reason: "skill is not in this cycle"}, checkPeerSkills(peerCycle(), []string{"Go", "Rust"}))
+}
@@ -0,0 +1,61 @@
+package cycle
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReviewPeriod is a company-wide round of cycles such as "2024 H1". Cycles
+// are opened between OpenDate and CloseDate, and their scores are calibrated
+// by CalibrationDate.
+type ReviewPeriod struct {
+	ID              primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name            string             `json:"name" bson:"name"`
+	OpenDate        time.Time          `json:"openDate" bson:"openDate"`
+	CloseDate       time.Time          `json:"closeDate" bson:"closeDate"`
+	CalibrationDate time.Time          `json:"calibrationDate" bson:"calibrationDate"`
+	CreatedBy       string             `json:"createdBy" bson:"createdBy"`
+	CreatedAt       time.Time          `json:"createdAt" bson:"createdAt"`
+}
+
+type ReviewPeriodInput struct {
+	Name            string    `json:"name" binding:"required"`
+	OpenDate        time.Time `json:"openDate" binding:"required"`
+	CloseDate       time.Time `json:"closeDate" binding:"required"`
+	CalibrationDate time.Time `json:"calibrationDate" binding:"required"`
+}
+
+// PeriodAriser is someone who has no cycle in a review period yet.
+type PeriodAriser struct {
+	Email     string `json:"email" bson:"email"`
+	FirstName string `json:"givenName" bson:"given_name"`
+	LastName  string `json:"familyName" bson:"family_name"`
+	JobRole   string `json:"jobRole" bson:"job_role"`
+	Level     string `json:"level" bson:"level"`
+}
+
+var cycleOutsidePeriodError = cycleHandlerError{message: "cycle dates must be within the review period"}
+
+// IsOpen tells whether cycles can be opened in the period at the given time.
+func (p *ReviewPeriod) IsOpen(at time.Time) bool {
+	return !at.Before(p.OpenDate) && !at.After(p.CloseDate)
+}
+
+// CycleDates fills in a start or end date left empty with the open or close
+// date of the period and checks the cycle fits in it.
+func (p *ReviewPeriod) CycleDates(start time.Time, end time.Time) (time.Time, time.Time, error) {
+	if start.IsZero() {
+		start = p.OpenDate
+	}
+	if end.IsZero() {
+		end = p.CloseDate
+	}
+	if !end.After(start) {
+		return start, end, invalidCycleDateError
+	}
+	if start.Before(p.OpenDate) || end.After(p.CloseDate) {
+		return start, end, cycleOutsidePeriodError
+	}
+	return start, end, nil
+}
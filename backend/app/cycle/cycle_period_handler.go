@@ -0,0 +1,315 @@
+package cycle
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/squad"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PeriodLookup is the part of the storage a new cycle finds its review
+// period with.
+type PeriodLookup interface {
+	GetPeriodByID(ctx context.Context, id string) (*ReviewPeriod, error)
+	GetOpenPeriod(ctx context.Context, at time.Time) (*ReviewPeriod, error)
+}
+
+type PeriodStorage interface {
+	PeriodLookup
+	InsertPeriod(ctx context.Context, period ReviewPeriod) (*ReviewPeriod, error)
+	GetPeriods(ctx context.Context) ([]ReviewPeriod, error)
+	GetPeriodCycles(ctx context.Context, periodID primitive.ObjectID, status string, teamLeaderMail string, member string, page PageRequest) (*Page[*NewCycle], error)
+	GetArisersWithoutCycle(ctx context.Context, periodID primitive.ObjectID, squadID *primitive.ObjectID) ([]PeriodAriser, error)
+}
+
+type periodHandler struct {
+	storage PeriodStorage
+	squads  SquadMembers
+	admins  []string
+}
+
+func NewPeriodHandler(st PeriodStorage, squads SquadMembers, admins []string) *periodHandler {
+	return &periodHandler{
+		storage: st,
+		squads:  squads,
+		admins:  admins,
+	}
+}
+
+var invalidPeriodInputError = cycleHandlerError{message: "invalid review period input"}
+var invalidPeriodDateError = cycleHandlerError{message: "close date must be after open date and calibration date must not be before close date"}
+var notPeriodAdminError = cycleHandlerError{message: "only admins can add review periods"}
+var notStartedForbiddenError = cycleHandlerError{message: "only admins, or the team lead of the squad asked for, can list who has no cycle"}
+
+// InsertPeriod godoc
+//
+//	@summary		InsertPeriod
+//	@description	Add a review period. Cycles without a period that start inside it are linked to it.
+//	@tags			cycle
+//	@id				InsertReviewPeriod
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			reqJson	body		ReviewPeriodInput	true	"Review period"
+//	@response		200		{object}	cycle.ReviewPeriod	"OK"
+//	@response		400		{object}	app.Response		"Bad Request"
+//	@response		403		{object}	app.Response		"Not an admin"
+//	@response		409		{object}	app.Response		"Overlaps another period"
+//	@response		500		{object}	app.Response		"Internal Server Error"
+//	@router			/review-periods [post]
+func (h *periodHandler) InsertPeriod(c app.Context) {
+	if !app.RequireAdmin(c, h.admins, notPeriodAdminError) {
+		return
+	}
+
+	var input ReviewPeriodInput
+	if err := c.Bind(&input); err != nil {
+		c.BadRequest(invalidPeriodInputError)
+		return
+	}
+	if !input.CloseDate.After(input.OpenDate) || input.CalibrationDate.Before(input.CloseDate) {
+		c.BadRequest(invalidPeriodDateError)
+		return
+	}
+
+	period := ReviewPeriod{
+		Name:            input.Name,
+		OpenDate:        input.OpenDate,
+		CloseDate:       input.CloseDate,
+		CalibrationDate: input.CalibrationDate,
+		CreatedBy:       c.GetString("email"),
+		CreatedAt:       time.Now(),
+	}
+	res, err := h.storage.InsertPeriod(c.Ctx(), period)
+	if err != nil {
+		if err == periodOverlapError {
+			c.Conflict(err)
+			return
+		}
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(res)
+}
+
+// GetPeriods godoc
+//
+//	@summary		GetPeriods
+//	@description	List the review periods, latest first
+//	@tags			cycle
+//	@id				GetReviewPeriods
+//	@security		BearerAuth
+//	@produce		json
+//	@response		200	{array}		cycle.ReviewPeriod	"OK"
+//	@response		450	{object}	app.Response		"Store Error"
+//	@router			/review-periods [get]
+func (h *periodHandler) GetPeriods(c app.Context) {
+	periods, err := h.storage.GetPeriods(c.Ctx())
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(periods)
+}
+
+// GetOpenPeriod godoc
+//
+//	@summary		GetOpenPeriod
+//	@description	Get the review period cycles are opened in now
+//	@tags			cycle
+//	@id				GetOpenReviewPeriod
+//	@security		BearerAuth
+//	@produce		json
+//	@response		200	{object}	cycle.ReviewPeriod	"OK"
+//	@response		404	{object}	app.Response		"No period is open"
+//	@response		500	{object}	app.Response		"Internal Server Error"
+//	@router			/review-periods/open [get]
+func (h *periodHandler) GetOpenPeriod(c app.Context) {
+	period, err := h.storage.GetOpenPeriod(c.Ctx(), time.Now())
+	if err != nil {
+		if err == noOpenPeriodError {
+			c.NotFound(err)
+			return
+		}
+		c.InternalServerError(err)
+		return
+	}
+
+	c.OK(period)
+}
+
+// GetPeriodByID godoc
+//
+//	@summary		GetPeriodByID
+//	@description	Get a review period
+//	@tags			cycle
+//	@id				GetReviewPeriodByID
+//	@security		BearerAuth
+//	@produce		json
+//	@param			periodID	path		string				true	"Review period ID"
+//	@response		200			{object}	cycle.ReviewPeriod	"OK"
+//	@response		400			{object}	app.Response		"Bad Request"
+//	@response		404			{object}	app.Response		"Review period not found"
+//	@response		500			{object}	app.Response		"Internal Server Error"
+//	@router			/review-periods/{periodID} [get]
+func (h *periodHandler) GetPeriodByID(c app.Context) {
+	period, ok := h.period(c)
+	if !ok {
+		return
+	}
+
+	c.OK(period)
+}
+
+// GetPeriodCycles godoc
+//
+//	@summary		GetPeriodCycles
+//	@description	Get a page of the cycles of a review period. Admins get every cycle, anyone else only those they are the ariser or team lead of.
+//	@tags			cycle
+//	@id				GetReviewPeriodCycles
+//	@security		BearerAuth
+//	@produce		json
+//	@param			periodID		path		string						true	"Review period ID"
+//	@param			status			query		string						false	"Only cycles with this status"
+//	@param			teamLeaderMail	query		string						false	"Only cycles of this team lead"
+//	@param			cursor			query		string						false	"nextCursor of the previous page"
+//	@param			limit			query		int							false	"Page size, 10 by default and 100 at most"
+//	@param			sort			query		string						false	"startDate (default), endDate or status"
+//	@param			order			query		string						false	"desc (default) or asc"
+//	@response		200				{object}	cycle.Page[cycle.NewCycle]	"OK"
+//	@response		400				{object}	app.Response				"Bad Request"
+//	@response		404				{object}	app.Response				"Review period not found"
+//	@response		450				{object}	app.Response				"Store Error"
+//	@router			/review-periods/{periodID}/cycles [get]
+func (h *periodHandler) GetPeriodCycles(c app.Context) {
+	page, ok := pageRequest(c)
+	if !ok {
+		return
+	}
+	period, ok := h.period(c)
+	if !ok {
+		return
+	}
+
+	member := ""
+	if email := c.GetString("email"); !app.IsAdmin(h.admins, email) {
+		member = email
+	}
+	cycles, err := h.storage.GetPeriodCycles(c.Ctx(), period.ID, c.Query("status"), c.Query("teamLeaderMail"), member, page)
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(cycles)
+}
+
+// GetNotStarted godoc
+//
+//	@summary		GetNotStarted
+//	@description	List the users who have no cycle in a review period yet. Team leads list the members of a squad they lead.
+//	@tags			cycle
+//	@id				GetReviewPeriodNotStarted
+//	@security		BearerAuth
+//	@produce		json
+//	@param			periodID	path		string				true	"Review period ID"
+//	@param			squadID		query		string				false	"Only members of this squad, required unless admin"
+//	@response		200			{array}		cycle.PeriodAriser	"OK"
+//	@response		400			{object}	app.Response		"Bad Request"
+//	@response		403			{object}	app.Response		"Neither an admin nor the squad team lead"
+//	@response		404			{object}	app.Response		"Review period or squad not found"
+//	@response		450			{object}	app.Response		"Store Error"
+//	@router			/review-periods/{periodID}/not-started [get]
+func (h *periodHandler) GetNotStarted(c app.Context) {
+	var squadID *primitive.ObjectID
+	if id := c.Query("squadID"); id != "" {
+		objId, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			c.BadRequest(invalidSquadIdError)
+			return
+		}
+		squadID = &objId
+	}
+	if !h.leadsSquad(c, squadID) {
+		return
+	}
+
+	period, ok := h.period(c)
+	if !ok {
+		return
+	}
+
+	arisers, err := h.storage.GetArisersWithoutCycle(c.Ctx(), period.ID, squadID)
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(arisers)
+}
+
+// leadsSquad makes sure the caller is an admin or the team lead of the squad,
+// answering 403 or 404 otherwise.
+func (h *periodHandler) leadsSquad(c app.Context, squadID *primitive.ObjectID) bool {
+	email := c.GetString("email")
+	if app.IsAdmin(h.admins, email) {
+		return true
+	}
+	if squadID == nil {
+		c.Forbidden(notStartedForbiddenError)
+		return false
+	}
+
+	sq, err := h.squads.GetOneByID(squadID.Hex())
+	if err != nil {
+		if errors.As(err, &squad.SquadStorageError{}) {
+			c.NotFound(err)
+			return false
+		}
+		c.InternalServerError(err)
+		return false
+	}
+	if sq.TeamleadMail != email {
+		c.Forbidden(notStartedForbiddenError)
+		return false
+	}
+	return true
+}
+
+// period loads the review period of the request, answering it when the
+// period can't be found.
+func (h *periodHandler) period(c app.Context) (*ReviewPeriod, bool) {
+	period, err := h.storage.GetPeriodByID(c.Ctx(), c.Param("periodID"))
+	if err != nil {
+		periodError(c, err)
+		return nil, false
+	}
+	return period, true
+}
+
+// findPeriod finds the review period a new cycle goes into: the given one,
+// or else the one open now.
+func findPeriod(ctx context.Context, st PeriodLookup, id string) (*ReviewPeriod, error) {
+	if id != "" {
+		return st.GetPeriodByID(ctx, id)
+	}
+	return st.GetOpenPeriod(ctx, time.Now())
+}
+
+func periodError(c app.Context, err error) {
+	switch err {
+	case invalidRequestError:
+		c.BadRequest(err)
+	case periodNotFoundError:
+		c.NotFound(err)
+	case noOpenPeriodError:
+		c.Conflict(err)
+	default:
+		c.InternalServerError(err)
+	}
+}
@@ -0,0 +1,360 @@
+package cycle
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/squad"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+type mockPeriodStorage struct {
+	mockDraftStorage
+	inserted  *ReviewPeriod
+	insertErr error
+	cycles    []*NewCycle
+	status    string
+	lead      string
+	member    string
+	arisers   []PeriodAriser
+	squadID   *primitive.ObjectID
+}
+
+func (m *mockPeriodStorage) InsertPeriod(ctx context.Context, period ReviewPeriod) (*ReviewPeriod, error) {
+	if m.insertErr != nil {
+		return nil, m.insertErr
+	}
+	period.ID = mockObjectId(9).objectId
+	m.inserted = &period
+	return &period, nil
+}
+
+func (m *mockPeriodStorage) GetPeriods(ctx context.Context) ([]ReviewPeriod, error) {
+	if m.period == nil {
+		return []ReviewPeriod{}, nil
+	}
+	return []ReviewPeriod{*m.period}, nil
+}
+
+func (m *mockPeriodStorage) GetPeriodCycles(ctx context.Context, periodID primitive.ObjectID, status string, teamLeaderMail string, member string, page PageRequest) (*Page[*NewCycle], error) {
+	m.status = status
+	m.lead = teamLeaderMail
+	m.member = member
+	return &Page[*NewCycle]{Items: m.cycles, Total: int64(len(m.cycles))}, nil
+}
+
+func (m *mockPeriodStorage) GetArisersWithoutCycle(ctx context.Context, periodID primitive.ObjectID, squadID *primitive.ObjectID) ([]PeriodAriser, error) {
+	m.squadID = squadID
+	return m.arisers, nil
+}
+
+func TestInsertPeriod(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name             string
+		email            string
+		reqBody          string
+		insertErr        error
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:           "should return 200 and the new period",
+			reqBody:        `{"name":"2024 H1","openDate":"2024-01-01T00:00:00Z","closeDate":"2024-06-30T00:00:00Z","calibrationDate":"2024-07-15T00:00:00Z"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:             "should return 403 when user is not an admin",
+			email:            "ariser@arise.tech",
+			reqBody:          `{"name":"2024 H1","openDate":"2024-01-01T00:00:00Z","closeDate":"2024-06-30T00:00:00Z","calibrationDate":"2024-07-15T00:00:00Z"}`,
+			expectedStatus:   http.StatusForbidden,
+			expectedResponse: `{"status":"error","message":"only admins can add review periods"}`,
+		},
+		{
+			name:             "should return 400 when close date is before open date",
+			reqBody:          `{"name":"2024 H1","openDate":"2024-06-30T00:00:00Z","closeDate":"2024-01-01T00:00:00Z","calibrationDate":"2024-07-15T00:00:00Z"}`,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"close date must be after open date and calibration date must not be before close date"}`,
+		},
+		{
+			name:             "should return 400 when calibration date is before close date",
+			reqBody:          `{"name":"2024 H1","openDate":"2024-01-01T00:00:00Z","closeDate":"2024-06-30T00:00:00Z","calibrationDate":"2024-06-01T00:00:00Z"}`,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"close date must be after open date and calibration date must not be before close date"}`,
+		},
+		{
+			name:             "should return 400 when name is missing",
+			reqBody:          `{"openDate":"2024-01-01T00:00:00Z","closeDate":"2024-06-30T00:00:00Z","calibrationDate":"2024-07-15T00:00:00Z"}`,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid review period input"}`,
+		},
+		{
+			name:             "should return 409 when period overlaps another",
+			reqBody:          `{"name":"2024 H1","openDate":"2024-01-01T00:00:00Z","closeDate":"2024-06-30T00:00:00Z","calibrationDate":"2024-07-15T00:00:00Z"}`,
+			insertErr:        periodOverlapError,
+			expectedStatus:   http.StatusConflict,
+			expectedResponse: `{"status":"error","message":"review period overlaps another one"}`,
+		},
+		{
+			name:           "should return 450 when storage is failed",
+			reqBody:        `{"name":"2024 H1","openDate":"2024-01-01T00:00:00Z","closeDate":"2024-06-30T00:00:00Z","calibrationDate":"2024-07-15T00:00:00Z"}`,
+			insertErr:      errors.New("insert error"),
+			expectedStatus: 450,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockPeriodStorage{insertErr: tc.insertErr}
+			handler := NewPeriodHandler(st, &mockSquadMembers{}, []string{"hr@arise.tech"})
+
+			email := "hr@arise.tech"
+			if tc.email != "" {
+				email = tc.email
+			}
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", email)
+			})
+			engine.POST("/review-periods", app.NewGinHandler(handler.InsertPeriod, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/review-periods", strings.NewReader(tc.reqBody))
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedResponse != "" {
+				assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+			}
+			if tc.expectedStatus == http.StatusForbidden {
+				assert.Nil(t, st.inserted)
+			}
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, "2024 H1", st.inserted.Name)
+				assert.Equal(t, "hr@arise.tech", st.inserted.CreatedBy)
+				assert.Equal(t, time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC), st.inserted.CalibrationDate)
+			}
+		})
+	}
+}
+
+func TestGetOpenPeriod(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should return 200 and the open period", func(t *testing.T) {
+		handler := NewPeriodHandler(&mockPeriodStorage{mockDraftStorage: mockDraftStorage{period: h1Period()}}, &mockSquadMembers{}, []string{"hr@arise.tech"})
+
+		engine := gin.New()
+		engine.GET("/review-periods/open", app.NewGinHandler(handler.GetOpenPeriod, zap.NewNop()))
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/review-periods/open", nil)
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{
+			"status": "success",
+			"message": "",
+			"data": {
+				"id": "000000000000000000000009",
+				"name": "2024 H1",
+				"openDate": "2024-01-01T00:00:00Z",
+				"closeDate": "2024-06-30T00:00:00Z",
+				"calibrationDate": "2024-07-15T00:00:00Z",
+				"createdBy": "",
+				"createdAt": "0001-01-01T00:00:00Z"
+			}
+		}`, rec.Body.String())
+	})
+
+	t.Run("should return 404 when no period is open", func(t *testing.T) {
+		handler := NewPeriodHandler(&mockPeriodStorage{}, &mockSquadMembers{}, []string{"hr@arise.tech"})
+
+		engine := gin.New()
+		engine.GET("/review-periods/open", app.NewGinHandler(handler.GetOpenPeriod, zap.NewNop()))
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/review-periods/open", nil)
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestGetPeriodCycles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name                 string
+		email                string
+		periodID             string
+		query                string
+		expectedStatus       int
+		expectedStatusFilter string
+		expectedLead         string
+		expectedMember       string
+	}{
+		{
+			name:                 "should return 200 and cycles of the period with the filters",
+			email:                "hr@arise.tech",
+			periodID:             mockObjectId(9).hexId,
+			query:                "?status=Pending&teamLeaderMail=lead@arise.tech",
+			expectedStatus:       http.StatusOK,
+			expectedStatusFilter: StatusPending,
+			expectedLead:         "lead@arise.tech",
+		},
+		{
+			name:           "should return 200 and only the cycles of the user when not an admin",
+			email:          "lead@arise.tech",
+			periodID:       mockObjectId(9).hexId,
+			expectedStatus: http.StatusOK,
+			expectedMember: "lead@arise.tech",
+		},
+		{
+			name:           "should return 400 when period id is invalid",
+			email:          "hr@arise.tech",
+			periodID:       "not-valid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should return 404 when period is not found",
+			email:          "hr@arise.tech",
+			periodID:       mockObjectId(8).hexId,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockPeriodStorage{
+				mockDraftStorage: mockDraftStorage{period: h1Period()},
+				cycles:           []*NewCycle{{AriserMail: "ariser@arise.tech", PeriodID: mockObjectId(9).objectId}},
+			}
+			handler := NewPeriodHandler(st, &mockSquadMembers{}, []string{"hr@arise.tech"})
+
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", tc.email)
+			})
+			engine.GET("/review-periods/:periodID/cycles", app.NewGinHandler(handler.GetPeriodCycles, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/review-periods/"+tc.periodID+"/cycles"+tc.query, nil)
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedStatusFilter, st.status)
+			assert.Equal(t, tc.expectedLead, st.lead)
+			assert.Equal(t, tc.expectedMember, st.member)
+		})
+	}
+}
+
+func TestGetNotStarted(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	squadId := mockObjectId(7)
+
+	testCases := []struct {
+		name             string
+		email            string
+		query            string
+		squads           *mockSquadMembers
+		expectedStatus   int
+		expectedSquadID  *primitive.ObjectID
+		expectedResponse string
+	}{
+		{
+			name:           "should return 200 and users without a cycle",
+			email:          "hr@arise.tech",
+			expectedStatus: http.StatusOK,
+			expectedResponse: `{
+				"status": "success",
+				"message": "",
+				"data": [{"email": "late@arise.tech", "givenName": "Late", "familyName": "Comer", "jobRole": "frontend", "level": "Junior"}]
+			}`,
+		},
+		{
+			name:            "should return 200 and filter by squad",
+			email:           "hr@arise.tech",
+			query:           "?squadID=" + squadId.hexId,
+			expectedStatus:  http.StatusOK,
+			expectedSquadID: &squadId.objectId,
+		},
+		{
+			name:            "should return 200 when the team lead asks for their squad",
+			email:           "lead@arise.tech",
+			query:           "?squadID=" + squadId.hexId,
+			squads:          &mockSquadMembers{squad: &squad.Squad{TeamleadMail: "lead@arise.tech"}},
+			expectedStatus:  http.StatusOK,
+			expectedSquadID: &squadId.objectId,
+		},
+		{
+			name:             "should return 403 when user is not an admin and asks for no squad",
+			email:            "lead@arise.tech",
+			expectedStatus:   http.StatusForbidden,
+			expectedResponse: `{"status":"error","message":"only admins, or the team lead of the squad asked for, can list who has no cycle"}`,
+		},
+		{
+			name:             "should return 403 when user does not lead the squad",
+			email:            "ariser@arise.tech",
+			query:            "?squadID=" + squadId.hexId,
+			squads:           &mockSquadMembers{squad: &squad.Squad{TeamleadMail: "lead@arise.tech"}},
+			expectedStatus:   http.StatusForbidden,
+			expectedResponse: `{"status":"error","message":"only admins, or the team lead of the squad asked for, can list who has no cycle"}`,
+		},
+		{
+			name:           "should return 404 when squad is not found",
+			email:          "lead@arise.tech",
+			query:          "?squadID=" + squadId.hexId,
+			squads:         &mockSquadMembers{squadErr: squad.SquadStorageError{}},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:             "should return 400 when squad id is invalid",
+			email:            "hr@arise.tech",
+			query:            "?squadID=not-valid",
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid squad id"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockPeriodStorage{
+				mockDraftStorage: mockDraftStorage{period: h1Period()},
+				arisers:          []PeriodAriser{{Email: "late@arise.tech", FirstName: "Late", LastName: "Comer", JobRole: "frontend", Level: "Junior"}},
+			}
+			squads := tc.squads
+			if squads == nil {
+				squads = &mockSquadMembers{}
+			}
+			handler := NewPeriodHandler(st, squads, []string{"hr@arise.tech"})
+
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", tc.email)
+			})
+			engine.GET("/review-periods/:periodID/not-started", app.NewGinHandler(handler.GetNotStarted, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/review-periods/"+mockObjectId(9).hexId+"/not-started"+tc.query, nil)
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedSquadID, st.squadID)
+			if tc.expectedResponse != "" {
+				assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package cycle
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const reviewPeriodCollection = "review_periods"
+
+var periodNotFoundError = CycleStorageError{message: "review period not found"}
+var noOpenPeriodError = CycleStorageError{message: "no review period is open, pick one with periodId"}
+var periodOverlapError = CycleStorageError{message: "review period overlaps another one"}
+
+// InsertPeriod adds a review period that doesn't overlap any other, then
+// links the cycles without a period that start inside it. Each linked cycle
+// gets a new version and an event, in the same transaction as the period.
+func (s *storage) InsertPeriod(ctx context.Context, period ReviewPeriod) (*ReviewPeriod, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	overlap := bson.M{
+		"openDate":  bson.M{"$lte": period.CloseDate},
+		"closeDate": bson.M{"$gte": period.OpenDate},
+	}
+	count, err := s.db.Collection(reviewPeriodCollection).CountDocuments(ctx, overlap)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, periodOverlapError
+	}
+
+	unlinked := bson.M{
+		"periodId":  bson.M{"$exists": false},
+		"startDate": bson.M{"$gte": period.OpenDate, "$lte": period.CloseDate},
+	}
+	err = s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		res, err := s.db.Collection(reviewPeriodCollection).InsertOne(sc, period)
+		if err != nil {
+			return err
+		}
+		period.ID = res.InsertedID.(primitive.ObjectID)
+
+		cursor, err := s.db.Collection(newCycleCollection).Find(sc, unlinked, options.Find().SetProjection(bson.M{"_id": 1}))
+		if err != nil {
+			return err
+		}
+		var linked []struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.All(sc, &linked); err != nil {
+			return err
+		}
+		if len(linked) == 0 {
+			return nil
+		}
+
+		ids := bson.A{}
+		events := []any{}
+		now := time.Now()
+		for _, cy := range linked {
+			ids = append(ids, cy.ID)
+			events = append(events, CycleEvent{
+				CycleID: cy.ID,
+				Type:    EventPeriodLinked,
+				Actor:   period.CreatedBy,
+				At:      now,
+				Changes: []FieldChange{{Field: "periodId", Before: nil, After: period.ID}},
+			})
+		}
+		update := bson.M{"$set": bson.M{"periodId": period.ID}, "$inc": bson.M{"version": 1}}
+		if _, err := s.db.Collection(newCycleCollection).UpdateMany(sc, bson.M{"_id": bson.M{"$in": ids}}, update); err != nil {
+			return err
+		}
+		_, err = s.db.Collection(cycleEventCollection).InsertMany(sc, events)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &period, nil
+}
+
+// GetPeriods lists the review periods, latest first.
+func (s *storage) GetPeriods(ctx context.Context) ([]ReviewPeriod, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	findOptions := options.Find().SetSort(bson.M{"openDate": -1})
+	cursor, err := s.db.Collection(reviewPeriodCollection).Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	periods := []ReviewPeriod{}
+	if err := cursor.All(ctx, &periods); err != nil {
+		return nil, err
+	}
+	return periods, nil
+}
+
+func (s *storage) GetPeriodByID(ctx context.Context, id string) (*ReviewPeriod, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, invalidRequestError
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var period ReviewPeriod
+	if err := s.db.Collection(reviewPeriodCollection).FindOne(ctx, bson.M{"_id": objId}).Decode(&period); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, periodNotFoundError
+		}
+		return nil, err
+	}
+	return &period, nil
+}
+
+// GetOpenPeriod finds the review period open at the given time.
+func (s *storage) GetOpenPeriod(ctx context.Context, at time.Time) (*ReviewPeriod, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"openDate":  bson.M{"$lte": at},
+		"closeDate": bson.M{"$gte": at},
+	}
+	var period ReviewPeriod
+	if err := s.db.Collection(reviewPeriodCollection).FindOne(ctx, filter).Decode(&period); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, noOpenPeriodError
+		}
+		return nil, err
+	}
+	return &period, nil
+}
+
+// GetPeriodCycles lists the cycles of a review period one page at a time,
+// optionally only those with the given status or team lead, or those member
+// is the ariser or team lead of.
+func (s *storage) GetPeriodCycles(ctx context.Context, periodID primitive.ObjectID, status string, teamLeaderMail string, member string, page PageRequest) (*Page[*NewCycle], error) {
+	filter := bson.M{"periodId": periodID}
+	if status != "" && status != StatusAll {
+		filter["status"] = status
+	}
+	if teamLeaderMail != "" {
+		filter["teamLeaderMail"] = teamLeaderMail
+	}
+	if member != "" {
+		filter["$or"] = memberFilter(member)
+	}
+	return s.findPage(ctx, filter, page)
+}
+
+// memberFilter matches the cycles the user is the ariser or team lead of.
+func memberFilter(email string) bson.A {
+	return bson.A{bson.M{"ariserMail": email}, bson.M{"teamLeaderMail": email}}
+}
+
+// GetArisersWithCycle lists the email of every ariser with a cycle, not
+// deleted, in a review period.
+func (s *storage) GetArisersWithCycle(ctx context.Context, periodID primitive.ObjectID) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	values, err := s.db.Collection(newCycleCollection).Distinct(ctx, "ariserMail", bson.M{"periodId": periodID, "deletedAt": nil})
+	if err != nil {
+		return nil, err
+	}
+	emails := []string{}
+	for _, v := range values {
+		if email, ok := v.(string); ok {
+			emails = append(emails, email)
+		}
+	}
+	return emails, nil
+}
+
+// GetArisersWithoutCycle lists the users who have no cycle in a review
+// period, optionally only the members of one squad.
+func (s *storage) GetArisersWithoutCycle(ctx context.Context, periodID primitive.ObjectID, squadID *primitive.ObjectID) ([]PeriodAriser, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	started, err := s.GetArisersWithCycle(ctx, periodID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := bson.M{"email": bson.M{"$nin": started}}
+	if squadID != nil {
+		filter["my_squad.sqid"] = *squadID
+	}
+	findOptions := options.Find().SetSort(bson.M{"email": 1})
+	cursor, err := s.db.Collection(userCollection).Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	arisers := []PeriodAriser{}
+	if err := cursor.All(ctx, &arisers); err != nil {
+		return nil, err
+	}
+	return arisers, nil
+}
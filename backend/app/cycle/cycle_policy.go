@@ -0,0 +1,126 @@
+package cycle
+
+import (
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Rules a goal policy checks the hard skills of a cycle against.
+const (
+	RuleLevelJump     = "max_level_jump"
+	RuleBelowLevel    = "goal_below_level"
+	RuleMaxGoalSkills = "max_goal_skills"
+	RuleMandatory     = "mandatory_skill"
+)
+
+const defaultMaxLevelJump = 1
+
+// GoalPolicy is how far arisers of a job role and level may set their goals.
+// A policy with an empty level covers every level of the job role that has no
+// policy of its own.
+type GoalPolicy struct {
+	ID      primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	JobRole string             `json:"jobRole" bson:"jobRole"`
+	Level   string             `json:"level" bson:"level"`
+	// MaxLevelJump is how many levels a goal may be above the current level.
+	MaxLevelJump int `json:"maxLevelJump" bson:"maxLevelJump"`
+	// MaxGoalSkills is how many skills may have a goal above the current
+	// level, 0 for no limit.
+	MaxGoalSkills int `json:"maxGoalSkills" bson:"maxGoalSkills"`
+	// MandatorySkills must be among the hard skills of every cycle.
+	MandatorySkills []string  `json:"mandatorySkills" bson:"mandatorySkills"`
+	UpdatedBy       string    `json:"updatedBy,omitempty" bson:"updatedBy,omitempty"`
+	UpdatedAt       time.Time `json:"updatedAt,omitempty" bson:"updatedAt,omitempty"`
+}
+
+type GoalPolicyInput struct {
+	JobRole         string   `json:"jobRole" binding:"required"`
+	Level           string   `json:"level"`
+	MaxLevelJump    int      `json:"maxLevelJump" binding:"required,min=1"`
+	MaxGoalSkills   int      `json:"maxGoalSkills" binding:"min=0"`
+	MandatorySkills []string `json:"mandatorySkills"`
+}
+
+// PolicyViolation is one rule of a goal policy the goals break. Skill is
+// empty for the rules about the goals as a whole.
+type PolicyViolation struct {
+	Skill   string `json:"skill,omitempty"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// GoalPolicyError lists every rule of the goal policy the goals break.
+type GoalPolicyError struct {
+	Violations []PolicyViolation
+}
+
+func (e GoalPolicyError) Error() string {
+	return "goals break the goal-setting policy"
+}
+
+// DefaultGoalPolicy is the policy of the job roles and levels without one: a
+// goal is the current level or the one above, on any number of skills.
+func DefaultGoalPolicy(jobRole string, level string) *GoalPolicy {
+	return &GoalPolicy{
+		JobRole:         jobRole,
+		Level:           level,
+		MaxLevelJump:    defaultMaxLevelJump,
+		MandatorySkills: []string{},
+	}
+}
+
+// Check lists the rules the hard skills of a cycle break, none when the goals
+// follow the policy. Personal scores must already be the ariser's levels.
+func (p *GoalPolicy) Check(hardSkills []HardSkill) []PolicyViolation {
+	violations := []PolicyViolation{}
+	names := make(map[string]bool)
+	goals := 0
+	for _, hs := range hardSkills {
+		names[hs.Name] = true
+		if hs.GoalScore > hs.PersonalScore {
+			goals++
+		}
+		switch {
+		case hs.GoalScore < hs.PersonalScore:
+			violations = append(violations, PolicyViolation{
+				Skill:   hs.Name,
+				Rule:    RuleBelowLevel,
+				Message: fmt.Sprintf("goal %d is below the current level %d", hs.GoalScore, hs.PersonalScore),
+			})
+		case hs.GoalScore > hs.PersonalScore+p.MaxLevelJump:
+			violations = append(violations, PolicyViolation{
+				Skill:   hs.Name,
+				Rule:    RuleLevelJump,
+				Message: fmt.Sprintf("goal %d is more than %d level(s) above the current level %d", hs.GoalScore, p.MaxLevelJump, hs.PersonalScore),
+			})
+		}
+	}
+
+	if p.MaxGoalSkills > 0 && goals > p.MaxGoalSkills {
+		violations = append(violations, PolicyViolation{
+			Rule:    RuleMaxGoalSkills,
+			Message: fmt.Sprintf("%d skills have a goal above the current level, at most %d may", goals, p.MaxGoalSkills),
+		})
+	}
+
+	for _, name := range p.MandatorySkills {
+		if !names[name] {
+			violations = append(violations, PolicyViolation{
+				Skill:   name,
+				Rule:    RuleMandatory,
+				Message: "skill is mandatory for this job role and level",
+			})
+		}
+	}
+	return violations
+}
+
+// checkGoals returns a GoalPolicyError when the hard skills break the policy.
+func (p *GoalPolicy) checkGoals(hardSkills []HardSkill) error {
+	if violations := p.Check(hardSkills); len(violations) > 0 {
+		return GoalPolicyError{Violations: violations}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package cycle
+
+import (
+	"context"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+)
+
+type GoalPolicyStorage interface {
+	SaveGoalPolicy(ctx context.Context, policy GoalPolicy) (*GoalPolicy, error)
+	GetGoalPolicies(ctx context.Context) ([]GoalPolicy, error)
+	DeleteGoalPolicy(ctx context.Context, id string) error
+}
+
+type policyHandler struct {
+	storage GoalPolicyStorage
+	admins  []string
+}
+
+func NewGoalPolicyHandler(st GoalPolicyStorage, admins []string) *policyHandler {
+	return &policyHandler{
+		storage: st,
+		admins:  admins,
+	}
+}
+
+var notPolicyAdminError = cycleHandlerError{message: "only admins can manage goal policies"}
+var invalidGoalPolicyInputError = cycleHandlerError{message: "invalid goal policy input, jobRole is required and maxLevelJump must be at least 1"}
+
+// GetGoalPolicies godoc
+//
+//	@summary		GetGoalPolicies
+//	@description	List the goal policies of each job role and level. Job roles and levels without one follow the default: a goal at most one level above the current one, on any number of skills.
+//	@tags			cycle
+//	@id				GetGoalPolicies
+//	@security		BearerAuth
+//	@produce		json
+//	@response		200	{array}		cycle.GoalPolicy	"OK"
+//	@response		403	{object}	app.Response		"Not an admin"
+//	@response		450	{object}	app.Response		"Store Error"
+//	@router			/admin/goal-policies [get]
+func (h *policyHandler) GetGoalPolicies(c app.Context) {
+	if !app.RequireAdmin(c, h.admins, notPolicyAdminError) {
+		return
+	}
+
+	policies, err := h.storage.GetGoalPolicies(c.Ctx())
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(policies)
+}
+
+// SaveGoalPolicy godoc
+//
+//	@summary		SaveGoalPolicy
+//	@description	Set the goal policy of a job role and level, replacing the one it has. An empty level sets the policy of every level of the job role without its own.
+//	@tags			cycle
+//	@id				SaveGoalPolicy
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			reqJson	body		GoalPolicyInput		true	"Goal policy"
+//	@response		200		{object}	cycle.GoalPolicy	"OK"
+//	@response		400		{object}	app.Response		"Bad Request"
+//	@response		403		{object}	app.Response		"Not an admin"
+//	@response		450		{object}	app.Response		"Store Error"
+//	@router			/admin/goal-policies [put]
+func (h *policyHandler) SaveGoalPolicy(c app.Context) {
+	email := c.GetString("email")
+	if !app.RequireAdmin(c, h.admins, notPolicyAdminError) {
+		return
+	}
+
+	var input GoalPolicyInput
+	if err := c.Bind(&input); err != nil {
+		c.BadRequest(invalidGoalPolicyInputError)
+		return
+	}
+
+	mandatory := input.MandatorySkills
+	if mandatory == nil {
+		mandatory = []string{}
+	}
+	policy := GoalPolicy{
+		JobRole:         input.JobRole,
+		Level:           input.Level,
+		MaxLevelJump:    input.MaxLevelJump,
+		MaxGoalSkills:   input.MaxGoalSkills,
+		MandatorySkills: mandatory,
+		UpdatedBy:       email,
+		UpdatedAt:       time.Now(),
+	}
+	res, err := h.storage.SaveGoalPolicy(c.Ctx(), policy)
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(res)
+}
+
+// DeleteGoalPolicy godoc
+//
+//	@summary		DeleteGoalPolicy
+//	@description	Remove a goal policy, so its job role and level fall back to the policy of the job role or the default.
+//	@tags			cycle
+//	@id				DeleteGoalPolicy
+//	@security		BearerAuth
+//	@produce		json
+//	@param			policyID	path		string			true	"Goal policy ID"
+//	@response		200			{object}	app.Response	"OK"
+//	@response		400			{object}	app.Response	"Bad Request"
+//	@response		403			{object}	app.Response	"Not an admin"
+//	@response		404			{object}	app.Response	"Goal policy not found"
+//	@response		450			{object}	app.Response	"Store Error"
+//	@router			/admin/goal-policies/{policyID} [delete]
+func (h *policyHandler) DeleteGoalPolicy(c app.Context) {
+	if !app.RequireAdmin(c, h.admins, notPolicyAdminError) {
+		return
+	}
+
+	if err := h.storage.DeleteGoalPolicy(c.Ctx(), c.Param("policyID")); err != nil {
+		switch err {
+		case invalidRequestError:
+			c.BadRequest(err)
+		case goalPolicyNotFoundError:
+			c.NotFound(err)
+		default:
+			c.StoreError(err)
+		}
+		return
+	}
+
+	c.OK(map[string]string{})
+}
@@ -0,0 +1,149 @@
+package cycle
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type mockGoalPolicyStorage struct {
+	policies []GoalPolicy
+	saved    *GoalPolicy
+	deleted  string
+}
+
+func (m *mockGoalPolicyStorage) SaveGoalPolicy(ctx context.Context, policy GoalPolicy) (*GoalPolicy, error) {
+	policy.ID = mockObjectId(5).objectId
+	m.saved = &policy
+	return &policy, nil
+}
+
+func (m *mockGoalPolicyStorage) GetGoalPolicies(ctx context.Context) ([]GoalPolicy, error) {
+	return m.policies, nil
+}
+
+func (m *mockGoalPolicyStorage) DeleteGoalPolicy(ctx context.Context, id string) error {
+	if id != mockObjectId(5).hexId {
+		return goalPolicyNotFoundError
+	}
+	m.deleted = id
+	return nil
+}
+
+func TestSaveGoalPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		email          string
+		reqBody        string
+		expectedStatus int
+		expectedSaved  *GoalPolicy
+	}{
+		{
+			name:           "should return 200 and save the policy",
+			email:          "admin@arise.tech",
+			reqBody:        `{"jobRole":"backend","level":"Senior","maxLevelJump":2,"maxGoalSkills":3}`,
+			expectedStatus: http.StatusOK,
+			expectedSaved: &GoalPolicy{
+				ID:              mockObjectId(5).objectId,
+				JobRole:         "backend",
+				Level:           "Senior",
+				MaxLevelJump:    2,
+				MaxGoalSkills:   3,
+				MandatorySkills: []string{},
+				UpdatedBy:       "admin@arise.tech",
+			},
+		},
+		{
+			name:           "should return 400 when max level jump is missing",
+			email:          "admin@arise.tech",
+			reqBody:        `{"jobRole":"backend","level":"Senior"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should return 403 when not an admin",
+			email:          "ariser@arise.tech",
+			reqBody:        `{"jobRole":"backend","level":"Senior","maxLevelJump":2}`,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockGoalPolicyStorage{}
+			handler := NewGoalPolicyHandler(st, []string{"admin@arise.tech"})
+
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", tc.email)
+			})
+			engine.PUT("/admin/goal-policies", app.NewGinHandler(handler.SaveGoalPolicy, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/admin/goal-policies", strings.NewReader(tc.reqBody))
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedSaved != nil {
+				tc.expectedSaved.UpdatedAt = st.saved.UpdatedAt
+			}
+			assert.Equal(t, tc.expectedSaved, st.saved)
+		})
+	}
+}
+
+func TestDeleteGoalPolicy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		email          string
+		policyID       string
+		expectedStatus int
+	}{
+		{
+			name:           "should return 200 when the policy is deleted",
+			email:          "admin@arise.tech",
+			policyID:       mockObjectId(5).hexId,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "should return 404 when the policy is not found",
+			email:          "admin@arise.tech",
+			policyID:       mockObjectId(6).hexId,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "should return 403 when not an admin",
+			email:          "ariser@arise.tech",
+			policyID:       mockObjectId(5).hexId,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewGoalPolicyHandler(&mockGoalPolicyStorage{}, []string{"admin@arise.tech"})
+
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", tc.email)
+			})
+			engine.DELETE("/admin/goal-policies/:policyID", app.NewGinHandler(handler.DeleteGoalPolicy, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodDelete, "/admin/goal-policies/"+tc.policyID, nil)
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
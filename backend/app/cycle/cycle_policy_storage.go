@@ -0,0 +1,86 @@
+package cycle
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const goalPolicyCollection = "goal_policies"
+
+var goalPolicyNotFoundError = CycleStorageError{message: "goal policy not found"}
+
+// SaveGoalPolicy adds the policy of a job role and level, or replaces the one
+// it already has.
+func (s *storage) SaveGoalPolicy(ctx context.Context, policy GoalPolicy) (*GoalPolicy, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"jobRole": policy.JobRole, "level": policy.Level}
+	opts := options.FindOneAndReplace().SetUpsert(true).SetReturnDocument(options.After)
+
+	var saved GoalPolicy
+	if err := s.db.Collection(goalPolicyCollection).FindOneAndReplace(ctx, filter, policy, opts).Decode(&saved); err != nil {
+		return nil, err
+	}
+	return &saved, nil
+}
+
+// GetGoalPolicies lists the goal policies by job role and level.
+func (s *storage) GetGoalPolicies(ctx context.Context) ([]GoalPolicy, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	findOptions := options.Find().SetSort(bson.D{{Key: "jobRole", Value: 1}, {Key: "level", Value: 1}})
+	cursor, err := s.db.Collection(goalPolicyCollection).Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := []GoalPolicy{}
+	if err := cursor.All(ctx, &policies); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+func (s *storage) DeleteGoalPolicy(ctx context.Context, id string) error {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return invalidRequestError
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	res, err := s.db.Collection(goalPolicyCollection).DeleteOne(ctx, bson.M{"_id": objId})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return goalPolicyNotFoundError
+	}
+	return nil
+}
+
+// GetGoalPolicy finds the policy of a job role and level, falling back to the
+// one for every level of the job role and then to the default policy.
+func (s *storage) GetGoalPolicy(ctx context.Context, jobRole string, level string) (*GoalPolicy, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"jobRole": jobRole, "level": bson.M{"$in": bson.A{level, ""}}}
+	findOptions := options.FindOne().SetSort(bson.M{"level": -1})
+
+	var policy GoalPolicy
+	if err := s.db.Collection(goalPolicyCollection).FindOne(ctx, filter, findOptions).Decode(&policy); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return DefaultGoalPolicy(jobRole, level), nil
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
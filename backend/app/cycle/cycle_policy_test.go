@@ -0,0 +1,74 @@
+package cycle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoalPolicyCheck(t *testing.T) {
+	testCases := []struct {
+		name       string
+		policy     *GoalPolicy
+		hardSkills []HardSkill
+		expected   []PolicyViolation
+	}{
+		{
+			name:   "should allow goals up to one level above by default",
+			policy: DefaultGoalPolicy("frontend", "Junior"),
+			hardSkills: []HardSkill{
+				{Name: "CSS", PersonalScore: 2, GoalScore: 3},
+				{Name: "HTML", PersonalScore: 2, GoalScore: 2},
+			},
+			expected: []PolicyViolation{},
+		},
+		{
+			name:   "should name each skill jumping too far or going down",
+			policy: DefaultGoalPolicy("frontend", "Junior"),
+			hardSkills: []HardSkill{
+				{Name: "CSS", PersonalScore: 2, GoalScore: 4},
+				{Name: "HTML", PersonalScore: 3, GoalScore: 2},
+			},
+			expected: []PolicyViolation{
+				{Skill: "CSS", Rule: RuleLevelJump, Message: "goal 4 is more than 1 level(s) above the current level 2"},
+				{Skill: "HTML", Rule: RuleBelowLevel, Message: "goal 2 is below the current level 3"},
+			},
+		},
+		{
+			name:   "should allow a two-level jump when the policy does",
+			policy: &GoalPolicy{JobRole: "backend", Level: "Senior", MaxLevelJump: 2},
+			hardSkills: []HardSkill{
+				{Name: "Go", PersonalScore: 2, GoalScore: 4},
+			},
+			expected: []PolicyViolation{},
+		},
+		{
+			name:   "should limit the number of skills with a goal above the current level",
+			policy: &GoalPolicy{MaxLevelJump: 1, MaxGoalSkills: 1},
+			hardSkills: []HardSkill{
+				{Name: "CSS", PersonalScore: 2, GoalScore: 3},
+				{Name: "HTML", PersonalScore: 2, GoalScore: 3},
+				{Name: "JS", PersonalScore: 2, GoalScore: 2},
+			},
+			expected: []PolicyViolation{
+				{Rule: RuleMaxGoalSkills, Message: "2 skills have a goal above the current level, at most 1 may"},
+			},
+		},
+		{
+			name:   "should name each mandatory skill left out",
+			policy: &GoalPolicy{MaxLevelJump: 1, MandatorySkills: []string{"CSS", "Security"}},
+			hardSkills: []HardSkill{
+				{Name: "CSS", PersonalScore: 2, GoalScore: 2},
+			},
+			expected: []PolicyViolation{
+				{Skill: "Security", Rule: RuleMandatory, Message: "skill is mandatory for this job role and level"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.policy.Check(tc.hardSkills))
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package cycle
+
+import (
+	"fmt"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app/skill"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RulePrerequisite is broken by a goal that needs a level of another hard
+// skill the ariser neither has nor aims for.
+const RulePrerequisite = "prerequisite"
+
+// CheckPrerequisites lists the goals of a cycle that need a level of another
+// hard skill the ariser neither has nor aims for in the same cycle. Only the
+// levels between the current level and the goal are checked. levels are the
+// current levels of the ariser by skill name.
+func CheckPrerequisites(hardSkills []HardSkill, levels map[string]int, catalog []skill.HardSkill) []PolicyViolation {
+	byName := make(map[string]skill.HardSkill)
+	byID := make(map[primitive.ObjectID]skill.HardSkill)
+	for _, hs := range catalog {
+		byName[hs.Name] = hs
+		byID[hs.ID] = hs
+	}
+
+	reach := make(map[string]int)
+	for name, level := range levels {
+		reach[name] = level
+	}
+	for _, hs := range hardSkills {
+		reach[hs.Name] = max(reach[hs.Name], hs.PersonalScore, hs.GoalScore)
+	}
+
+	violations := []PolicyViolation{}
+	for _, hs := range hardSkills {
+		for _, p := range byName[hs.Name].Prerequisites {
+			if p.Level <= hs.PersonalScore || p.Level > hs.GoalScore {
+				continue
+			}
+			required, ok := byID[p.SkillID]
+			if !ok || reach[required.Name] >= p.RequiredLevel {
+				continue
+			}
+			violations = append(violations, PolicyViolation{
+				Skill:   hs.Name,
+				Rule:    RulePrerequisite,
+				Message: fmt.Sprintf("level %d needs %s level %d", p.Level, required.Name, p.RequiredLevel),
+			})
+		}
+	}
+	return violations
+}
@@ -0,0 +1,86 @@
+package cycle
+
+import (
+	"context"
+	"errors"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/skill"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/user"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type PrerequisiteStorage interface {
+	GetNewByID(id string) (*NewCycle, error)
+	GetUsersHardSkillByEmail(ctx context.Context, email string) (*user.User, error)
+}
+
+// PrerequisiteCatalog is the part of skill.Storage prerequisites are read
+// from.
+type PrerequisiteCatalog interface {
+	GetAllHardSkills(ctx context.Context) ([]skill.HardSkill, error)
+}
+
+type prerequisiteHandler struct {
+	storage PrerequisiteStorage
+	catalog PrerequisiteCatalog
+}
+
+func NewPrerequisiteHandler(st PrerequisiteStorage, catalog PrerequisiteCatalog) *prerequisiteHandler {
+	return &prerequisiteHandler{
+		storage: st,
+		catalog: catalog,
+	}
+}
+
+// CheckPrerequisites godoc
+//
+//	@summary		CheckPrerequisites
+//	@description	List the goals of a cycle that need a level of another hard skill, such as Docker level 3 for Kubernetes level 3, that the ariser neither has nor aims for in the cycle. An empty list means every prerequisite is met. Only the ariser and team lead of the cycle can do it.
+//	@tags			cycle
+//	@id				CheckPrerequisites
+//	@security		BearerAuth
+//	@produce		json
+//	@param			id	path		string					true	"Cycle ID"
+//	@response		200	{array}		cycle.PolicyViolation	"OK"
+//	@response		400	{object}	app.Response			"Bad Request"
+//	@response		403	{object}	app.Response			"Not a member of the cycle"
+//	@response		404	{object}	app.Response			"Cycle or ariser not found"
+//	@response		500	{object}	app.Response			"Internal Server Error"
+//	@router			/cycles/{id}/prerequisites [get]
+func (h *prerequisiteHandler) CheckPrerequisites(c app.Context) {
+	cy, err := h.storage.GetNewByID(c.Param("id"))
+	if err != nil {
+		if err == cycleNotFoundError {
+			c.NotFound(err)
+			return
+		}
+		c.BadRequest(err)
+		return
+	}
+	if ActorOf(cy, c.GetString("email")) == "" {
+		c.Forbidden(notCycleOwnerError)
+		return
+	}
+
+	ariser, err := h.storage.GetUsersHardSkillByEmail(c.Ctx(), cy.AriserMail)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.NotFound(err)
+			return
+		}
+		c.InternalServerError(err)
+		return
+	}
+	catalog, err := h.catalog.GetAllHardSkills(c.Ctx())
+	if err != nil {
+		c.InternalServerError(err)
+		return
+	}
+
+	levels := make(map[string]int)
+	for _, hs := range ariser.HardSkills {
+		levels[hs.Name] = hs.CurrentLevel
+	}
+	c.OK(CheckPrerequisites(cy.HardSkills, levels, catalog))
+}
@@ -0,0 +1,136 @@
+package cycle
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/skill"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/user"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+type mockPrerequisiteStorage struct {
+	cycle   *NewCycle
+	ariser  *user.User
+	userErr error
+}
+
+func (m *mockPrerequisiteStorage) GetNewByID(id string) (*NewCycle, error) {
+	if m.cycle == nil || id != m.cycle.ID.Hex() {
+		return nil, cycleNotFoundError
+	}
+	return m.cycle, nil
+}
+
+func (m *mockPrerequisiteStorage) GetUsersHardSkillByEmail(ctx context.Context, email string) (*user.User, error) {
+	if m.userErr != nil {
+		return nil, m.userErr
+	}
+	return m.ariser, nil
+}
+
+type mockPrerequisiteCatalog struct {
+	skills []skill.HardSkill
+	err    error
+}
+
+func (m *mockPrerequisiteCatalog) GetAllHardSkills(ctx context.Context) ([]skill.HardSkill, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.skills, nil
+}
+
+func TestCheckPrerequisitesHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name             string
+		email            string
+		id               string
+		userErr          error
+		catalogErr       error
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "should return 200 and the unmet prerequisites to the ariser",
+			email:            "ariser@arise.tech",
+			id:               mockObjectId(1).hexId,
+			expectedStatus:   http.StatusOK,
+			expectedResponse: `{"status":"success","message":"","data":[{"skill":"Kubernetes","rule":"prerequisite","message":"level 3 needs Docker level 3"}]}`,
+		},
+		{
+			name:           "should return 200 to the team lead",
+			email:          "lead@arise.tech",
+			id:             mockObjectId(1).hexId,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:             "should return 403 when user is not a member of the cycle",
+			email:            "other@arise.tech",
+			id:               mockObjectId(1).hexId,
+			expectedStatus:   http.StatusForbidden,
+			expectedResponse: `{"status":"error","message":"only the ariser and team lead of this cycle can access it"}`,
+		},
+		{
+			name:           "should return 404 when cycle is not found",
+			email:          "ariser@arise.tech",
+			id:             mockObjectId(2).hexId,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "should return 404 when ariser is not found",
+			email:          "ariser@arise.tech",
+			id:             mockObjectId(1).hexId,
+			userErr:        mongo.ErrNoDocuments,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "should return 500 when catalog is failed",
+			email:          "ariser@arise.tech",
+			id:             mockObjectId(1).hexId,
+			catalogErr:     errors.New("catalog error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cy := &NewCycle{
+				ID:             mockObjectId(1).objectId,
+				AriserMail:     "ariser@arise.tech",
+				TeamLeaderMail: "lead@arise.tech",
+				HardSkills: []HardSkill{
+					{Name: "Kubernetes", PersonalScore: 2, GoalScore: 3},
+					{Name: "Go", PersonalScore: 1, GoalScore: 2},
+				},
+			}
+			ariser := &user.User{Email: "ariser@arise.tech", HardSkills: []user.MyHardSkill{{Name: "Docker", CurrentLevel: 2}}}
+			st := &mockPrerequisiteStorage{cycle: cy, ariser: ariser, userErr: tc.userErr}
+			handler := NewPrerequisiteHandler(st, &mockPrerequisiteCatalog{skills: prerequisiteCatalog(), err: tc.catalogErr})
+
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", tc.email)
+			})
+			engine.GET("/cycles/:id/prerequisites", app.NewGinHandler(handler.CheckPrerequisites, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/cycles/"+tc.id+"/prerequisites", nil)
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedResponse != "" {
+				assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package cycle
+
+import (
+	"testing"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app/skill"
+	"github.com/stretchr/testify/assert"
+)
+
+func prerequisiteCatalog() []skill.HardSkill {
+	levels := []skill.SkillLevel{{Level: 1}, {Level: 2}, {Level: 3}, {Level: 4}, {Level: 5}}
+	return []skill.HardSkill{
+		{ID: mockObjectId(11).objectId, Name: "Docker", SkillLevel: levels},
+		{
+			ID:         mockObjectId(12).objectId,
+			Name:       "Kubernetes",
+			SkillLevel: levels,
+			Prerequisites: []skill.Prerequisite{
+				{Level: 3, SkillID: mockObjectId(11).objectId, RequiredLevel: 3},
+				{Level: 4, SkillID: mockObjectId(11).objectId, RequiredLevel: 4},
+			},
+		},
+		{ID: mockObjectId(13).objectId, Name: "Go", SkillLevel: levels},
+	}
+}
+
+func TestCheckPrerequisites(t *testing.T) {
+	testCases := []struct {
+		name       string
+		hardSkills []HardSkill
+		levels     map[string]int
+		expected   []PolicyViolation
+	}{
+		{
+			name:       "should pass when the ariser already has the required level",
+			hardSkills: []HardSkill{{Name: "Kubernetes", PersonalScore: 2, GoalScore: 3}},
+			levels:     map[string]int{"Docker": 3},
+			expected:   []PolicyViolation{},
+		},
+		{
+			name: "should pass when the ariser aims for the required level in the same cycle",
+			hardSkills: []HardSkill{
+				{Name: "Kubernetes", PersonalScore: 2, GoalScore: 3},
+				{Name: "Docker", PersonalScore: 2, GoalScore: 3},
+			},
+			levels:   map[string]int{"Docker": 2},
+			expected: []PolicyViolation{},
+		},
+		{
+			name:       "should list a goal that needs a level the ariser doesn't have",
+			hardSkills: []HardSkill{{Name: "Kubernetes", PersonalScore: 2, GoalScore: 3}},
+			levels:     map[string]int{"Docker": 2},
+			expected: []PolicyViolation{
+				{Skill: "Kubernetes", Rule: RulePrerequisite, Message: "level 3 needs Docker level 3"},
+			},
+		},
+		{
+			name:       "should only check the levels above the current one",
+			hardSkills: []HardSkill{{Name: "Kubernetes", PersonalScore: 3, GoalScore: 4}},
+			levels:     map[string]int{"Docker": 2},
+			expected: []PolicyViolation{
+				{Skill: "Kubernetes", Rule: RulePrerequisite, Message: "level 4 needs Docker level 4"},
+			},
+		},
+		{
+			name:       "should list every level up to the goal",
+			hardSkills: []HardSkill{{Name: "Kubernetes", PersonalScore: 1, GoalScore: 4}},
+			levels:     map[string]int{},
+			expected: []PolicyViolation{
+				{Skill: "Kubernetes", Rule: RulePrerequisite, Message: "level 3 needs Docker level 3"},
+				{Skill: "Kubernetes", Rule: RulePrerequisite, Message: "level 4 needs Docker level 4"},
+			},
+		},
+		{
+			name: "should pass skills without prerequisites or not in the catalog",
+			hardSkills: []HardSkill{
+				{Name: "Go", PersonalScore: 1, GoalScore: 2},
+				{Name: "Rust", PersonalScore: 1, GoalScore: 2},
+			},
+			levels:   map[string]int{},
+			expected: []PolicyViolation{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := CheckPrerequisites(tc.hardSkills, tc.levels, prerequisiteCatalog())
+
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package cycle
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ReportFormatPDF = "pdf"
+	ReportFormatCSV = "csv"
+)
+
+const reportDateFormat = "2006-01-02"
+
+// CycleReport is a finished cycle as handed to the promotion committee: the
+// ariser, the scores of every hard skill with what their levels mean, and the
+// comment threads.
+type CycleReport struct {
+	Cycle       NewCycleWithUserDetail
+	Comments    []CycleComment
+	GeneratedAt time.Time
+}
+
+var reportCSVHeader = []string{
+	"ariserMail", "givenName", "familyName", "jobRole", "level", "teamLeaderMail",
+	"startDate", "endDate", "status", "skill", "description",
+	"personalScore", "personalLevel", "goalScore", "goalLevel",
+	"leadScore", "leadLevel", "mutualScore", "mutualLevel", "comments",
+}
+
+// WriteCSV writes one row per hard skill, followed by a row without a skill
+// for the comments on the cycle itself.
+func (r *CycleReport) WriteCSV(w io.Writer) error {
+	cy := r.Cycle
+	cycleColumns := []string{
+		cy.AriserMail, cy.FirstName, cy.LastName, cy.JobRole, cy.Level, cy.TeamLeaderMail,
+		cy.StartDate.Format(reportDateFormat), cy.EndDate.Format(reportDateFormat), cy.Status,
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(reportCSVHeader); err != nil {
+		return err
+	}
+	for _, hs := range cy.HardSkills {
+		row := append([]string{}, cycleColumns...)
+		row = append(row, hs.Name, hs.Description)
+		for _, score := range []int{hs.PersonalScore, hs.GoalScore, hs.LeadScore, hs.MutualScore} {
+			row = append(row, strconv.Itoa(score), levelDescription(hs.SkillLevels, score))
+		}
+		row = append(row, strings.Join(r.commentLines(hs.Name), "\n"))
+		if err := cw.Write(csvCells(row)); err != nil {
+			return err
+		}
+	}
+	if lines := r.commentLines(""); len(lines) > 0 {
+		row := append([]string{}, cycleColumns...)
+		row = append(row, make([]string, len(reportCSVHeader)-len(cycleColumns)-1)...)
+		row = append(row, strings.Join(lines, "\n"))
+		if err := cw.Write(csvCells(row)); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvCells quotes the cells of row that a spreadsheet would take as a
+// formula, so text typed by users is shown rather than run.
+func csvCells(row []string) []string {
+	for i, cell := range row {
+		if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+			row[i] = "'" + cell
+		}
+	}
+	return row
+}
+
+// WritePDF lays the report out on A4 pages and ends it with lines for the
+// ariser and team lead to sign. The standard fonts only cover Latin-1, so
+// other text, Thai names included, comes out as '?'.
+func (r *CycleReport) WritePDF(w io.Writer) error {
+	cy := r.Cycle
+	doc := newPDFDocument()
+
+	doc.Text("Skill cycle report", pdfFontBold, 16, 0)
+	doc.Space(6)
+	doc.Text(fmt.Sprintf("Ariser: %s %s <%s>", cy.FirstName, cy.LastName, cy.AriserMail), pdfFontRegular, 10, 0)
+	doc.Text(fmt.Sprintf("Job role: %s    Level: %s", cy.JobRole, cy.Level), pdfFontRegular, 10, 0)
+	doc.Text("Team lead: "+cy.TeamLeaderMail, pdfFontRegular, 10, 0)
+	doc.Text(fmt.Sprintf("Period: %s to %s", cy.StartDate.Format(reportDateFormat), cy.EndDate.Format(reportDateFormat)), pdfFontRegular, 10, 0)
+	doc.Text("Status: "+cy.Status, pdfFontRegular, 10, 0)
+
+	for _, hs := range cy.HardSkills {
+		doc.Space(10)
+		doc.Text(hs.Name, pdfFontBold, 12, 0)
+		if hs.Description != "" {
+			doc.Text(hs.Description, pdfFontRegular, 9, 0)
+		}
+		for _, score := range []struct {
+			name  string
+			score int
+		}{
+			{"Personal", hs.PersonalScore},
+			{"Goal", hs.GoalScore},
+			{"Lead", hs.LeadScore},
+			{"Mutual", hs.MutualScore},
+		} {
+			line := fmt.Sprintf("%s score: %d", score.name, score.score)
+			if desc := levelDescription(hs.SkillLevels, score.score); desc != "" {
+				line += " - " + desc
+			}
+			doc.Text(line, pdfFontRegular, 10, 12)
+		}
+		r.writePDFComments(doc, hs.Name)
+	}
+
+	if len(r.commentLines("")) > 0 {
+		doc.Space(10)
+		doc.Text("Cycle comments", pdfFontBold, 12, 0)
+		r.writePDFComments(doc, "")
+	}
+
+	doc.Space(30)
+	doc.Text("Ariser: ______________________________    Date: ______________", pdfFontRegular, 10, 0)
+	doc.Space(20)
+	doc.Text("Team lead: ___________________________    Date: ______________", pdfFontRegular, 10, 0)
+	doc.Space(20)
+	doc.Text("Generated "+r.GeneratedAt.Format(time.RFC1123), pdfFontRegular, 8, 0)
+
+	_, err := doc.WriteTo(w)
+	return err
+}
+
+func (r *CycleReport) writePDFComments(doc *pdfDocument, skill string) {
+	lines := r.commentLines(skill)
+	if len(lines) == 0 {
+		return
+	}
+	if skill != "" {
+		doc.Text("Comments:", pdfFontBold, 10, 12)
+	}
+	for _, line := range lines {
+		doc.Text(line, pdfFontRegular, 9, 24)
+	}
+}
+
+// commentLines prints the threads on a skill, or on the cycle itself for an
+// empty skill, each reply right under the comment it answers.
+func (r *CycleReport) commentLines(skill string) []string {
+	lines := []string{}
+	for _, root := range r.Comments {
+		if root.Skill != skill || root.ParentID != nil {
+			continue
+		}
+		line := commentLine(root)
+		if root.Resolved {
+			line += " [resolved]"
+		}
+		lines = append(lines, line)
+		for _, reply := range r.Comments {
+			if reply.ParentID != nil && *reply.ParentID == root.ID {
+				lines = append(lines, "  > "+commentLine(reply))
+			}
+		}
+	}
+	return lines
+}
+
+func commentLine(c CycleComment) string {
+	line := fmt.Sprintf("%s %s: %s", c.CreatedAt.Format(reportDateFormat), c.Author, c.Body)
+	if c.EditedAt != nil {
+		line += " (edited)"
+	}
+	return line
+}
+
+// levelDescription is what a score means for a skill, or "" when the skill
+// has no such level.
+func levelDescription(levels []SkillLevel, score int) string {
+	for _, level := range levels {
+		if level.Level == score {
+			return level.LevelDescription
+		}
+	}
+	return ""
+}
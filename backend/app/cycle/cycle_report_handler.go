@@ -0,0 +1,112 @@
+package cycle
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+)
+
+type ReportStorage interface {
+	GetNewByID(id string) (*NewCycle, error)
+	ToNewUserDetailFormat(cy *NewCycle) (*NewCycleWithUserDetail, error)
+	GetComments(ctx context.Context, cycleID string, skill *string) ([]CycleComment, error)
+}
+
+type reportHandler struct {
+	storage ReportStorage
+	clock   app.Clock
+}
+
+func NewReportHandler(st ReportStorage, clock app.Clock) *reportHandler {
+	return &reportHandler{
+		storage: st,
+		clock:   clock,
+	}
+}
+
+var invalidReportFormatError = cycleHandlerError{message: "format must be csv or pdf"}
+var reportNotDoneError = cycleHandlerError{message: "a report is only available once the cycle is done"}
+
+var reportContentTypes = map[string]string{
+	ReportFormatPDF: "application/pdf",
+	ReportFormatCSV: "text/csv; charset=utf-8",
+}
+
+// Report godoc
+//
+//	@summary		Report
+//	@description	Download the report of a done cycle with the scores, level descriptions and comments of every hard skill, for its ariser or team lead
+//	@tags			cycle
+//	@id				CycleReport
+//	@security		BearerAuth
+//	@produce		text/csv
+//	@produce		application/pdf
+//	@param			id		path		string			true	"Cycle ID"
+//	@param			format	query		string			false	"csv (default) or pdf, which only shows Latin-1 text"
+//	@response		200		{file}		file			"OK"
+//	@response		400		{object}	app.Response	"Bad Request"
+//	@response		403		{object}	app.Response	"Not the ariser or team lead of the cycle"
+//	@response		404		{object}	app.Response	"Cycle not found"
+//	@response		409		{object}	app.Response	"Cycle is not done"
+//	@response		500		{object}	app.Response	"Internal Server Error"
+//	@router			/cycles/{id}/report [get]
+func (h *reportHandler) Report(c app.Context) {
+	id := c.Param("id")
+	format := c.Query("format")
+	if format == "" {
+		format = ReportFormatCSV
+	}
+	contentType, ok := reportContentTypes[format]
+	if !ok {
+		c.BadRequest(invalidReportFormatError)
+		return
+	}
+
+	cy, err := h.storage.GetNewByID(id)
+	if err != nil {
+		if err == cycleNotFoundError {
+			c.NotFound(err)
+			return
+		}
+		c.BadRequest(err)
+		return
+	}
+	if ActorOf(cy, c.GetString("email")) == "" {
+		c.Forbidden(notCycleOwnerError)
+		return
+	}
+	if cy.Status != StatusDone {
+		c.Conflict(reportNotDoneError)
+		return
+	}
+
+	detail, err := h.storage.ToNewUserDetailFormat(cy)
+	if err != nil {
+		c.InternalServerError(err)
+		return
+	}
+	comments, err := h.storage.GetComments(c.Ctx(), id, nil)
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	report := CycleReport{Cycle: *detail, Comments: comments, GeneratedAt: h.clock.Now()}
+	var buf bytes.Buffer
+	if format == ReportFormatCSV {
+		err = report.WriteCSV(&buf)
+	} else {
+		err = report.WritePDF(&buf)
+	}
+	if err != nil {
+		c.InternalServerError(err)
+		return
+	}
+
+	filename := fmt.Sprintf("cycle-report-%s-%s.%s", cy.ID.Hex(), cy.EndDate.Format(reportDateFormat), format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
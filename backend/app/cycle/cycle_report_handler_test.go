@@ -0,0 +1,134 @@
+package cycle
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type mockReportStorage struct {
+	cycle *NewCycle
+}
+
+func (m *mockReportStorage) GetNewByID(id string) (*NewCycle, error) {
+	if m.cycle == nil {
+		return nil, cycleNotFoundError
+	}
+	return m.cycle, nil
+}
+
+func (m *mockReportStorage) ToNewUserDetailFormat(cy *NewCycle) (*NewCycleWithUserDetail, error) {
+	return &NewCycleWithUserDetail{
+		ID:         cy.ID,
+		AriserMail: cy.AriserMail,
+		Status:     cy.Status,
+		EndDate:    cy.EndDate,
+		HardSkills: toHardSkillDisplay(&cy.HardSkills),
+	}, nil
+}
+
+func (m *mockReportStorage) GetComments(ctx context.Context, cycleID string, skill *string) ([]CycleComment, error) {
+	return []CycleComment{}, nil
+}
+
+func TestReportHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	done := &NewCycle{
+		ID:         mockObjectId(1).objectId,
+		AriserMail: "ariser@arise.tech",
+		Status:     StatusDone,
+		HardSkills: []HardSkill{{Name: "CSS", LeadScore: 3, MutualScore: 3}},
+	}
+	running := *done
+	running.Status = StatusRunning
+	clock := app.NewMockClock()
+	clock.On("Now").Return(time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC))
+
+	testCases := []struct {
+		name                string
+		email               string
+		query               string
+		cycle               *NewCycle
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "should return a csv by default",
+			cycle:               done,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody:        "ariserMail,givenName",
+		},
+		{
+			name:                "should return a pdf when asked for",
+			query:               "?format=pdf",
+			cycle:               done,
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/pdf",
+			expectedBody:        "%PDF-1.4",
+		},
+		{
+			name:           "should return 403 when user is not the ariser or team lead of the cycle",
+			email:          "other@arise.tech",
+			cycle:          done,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"status":"error","message":"only the ariser and team lead of this cycle can access it"}`,
+		},
+		{
+			name:           "should return 400 when format is unknown",
+			query:          "?format=docx",
+			cycle:          done,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should return 409 when cycle is not done",
+			cycle:          &running,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "should return 404 when cycle is not found",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewReportHandler(&mockReportStorage{cycle: tc.cycle}, clock)
+
+			email := "ariser@arise.tech"
+			if tc.email != "" {
+				email = tc.email
+			}
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", email)
+			})
+			engine.GET("/cycles/:id/report", app.NewGinHandler(handler.Report, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/cycles/"+mockObjectId(1).hexId+"/report"+tc.query, nil)
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, tc.expectedContentType, rec.Header().Get("Content-Type"))
+				assert.Contains(t, rec.Header().Get("Content-Disposition"), "cycle-report-"+mockObjectId(1).hexId+"-")
+				assert.NotContains(t, rec.Header().Get("Content-Disposition"), "ariser@arise.tech")
+				assert.True(t, strings.HasPrefix(rec.Body.String(), tc.expectedBody))
+			}
+			if tc.expectedStatus == http.StatusForbidden {
+				assert.JSONEq(t, tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package cycle
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reportFixture() CycleReport {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	thread := mockObjectId(10).objectId
+	edited := start.AddDate(0, 0, 2)
+	return CycleReport{
+		Cycle: NewCycleWithUserDetail{
+			FirstName:      "Somchai",
+			LastName:       "Jaidee",
+			JobRole:        "full-stack",
+			Level:          "junior",
+			AriserMail:     "ariser@arise.tech",
+			TeamLeaderMail: "lead@arise.tech",
+			StartDate:      start,
+			EndDate:        start.AddDate(0, 6, 0),
+			Status:         StatusDone,
+			HardSkills: []HardSkillDisplay{
+				{
+					Name:          "CSS",
+					Description:   "style sheets",
+					SkillLevels:   []SkillLevel{{Level: 2, LevelDescription: "can style a page"}, {Level: 3, LevelDescription: "can build a design system"}},
+					PersonalScore: 2,
+					GoalScore:     3,
+					LeadScore:     3,
+					MutualScore:   3,
+				},
+			},
+		},
+		Comments: []CycleComment{
+			{ID: thread, Skill: "CSS", Author: "ariser@arise.tech", Body: "Built the (new) theme", CreatedAt: start, EditedAt: &edited, Resolved: true},
+			{ID: mockObjectId(11).objectId, Skill: "CSS", ParentID: &thread, Author: "lead@arise.tech", Body: "Agreed", CreatedAt: start.AddDate(0, 0, 1)},
+			{ID: mockObjectId(12).objectId, Author: "lead@arise.tech", Body: "Great cycle", CreatedAt: start.AddDate(0, 0, 3)},
+		},
+		GeneratedAt: start.AddDate(0, 6, 1),
+	}
+}
+
+func TestCycleReportCSV(t *testing.T) {
+	report := reportFixture()
+	var buf bytes.Buffer
+
+	require.NoError(t, report.WriteCSV(&buf))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, reportCSVHeader, rows[0])
+	assert.Equal(t, []string{
+		"ariser@arise.tech", "Somchai", "Jaidee", "full-stack", "junior", "lead@arise.tech",
+		"2024-01-01", "2024-07-01", StatusDone, "CSS", "style sheets",
+		"2", "can style a page", "3", "can build a design system",
+		"3", "can build a design system", "3", "can build a design system",
+		"2024-01-01 ariser@arise.tech: Built the (new) theme (edited) [resolved]\n  > 2024-01-02 lead@arise.tech: Agreed",
+	}, rows[1])
+	assert.Equal(t, "", rows[2][9])
+	assert.Equal(t, "2024-01-04 lead@arise.tech: Great cycle", rows[2][len(rows[2])-1])
+}
+
+func TestCycleReportCSVFormulas(t *testing.T) {
+	report := reportFixture()
+	report.Cycle.FirstName = "=HYPERLINK(\"http://evil.example\")"
+	report.Cycle.HardSkills[0].Name = "+CSS"
+	report.Cycle.HardSkills[0].Description = "@SUM(A1)"
+	report.Comments = []CycleComment{{Skill: "+CSS", Author: "lead@arise.tech", Body: "-1 point"}}
+	var buf bytes.Buffer
+
+	require.NoError(t, report.WriteCSV(&buf))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, "'=HYPERLINK(\"http://evil.example\")", rows[1][1])
+	assert.Equal(t, "'+CSS", rows[1][9])
+	assert.Equal(t, "'@SUM(A1)", rows[1][10])
+	assert.Equal(t, "2", rows[1][11])
+	assert.Contains(t, rows[1][len(rows[1])-1], "-1 point")
+}
+
+func TestCycleReportPDF(t *testing.T) {
+	report := reportFixture()
+	var buf bytes.Buffer
+
+	require.NoError(t, report.WritePDF(&buf))
+
+	pdf := buf.String()
+	assert.True(t, strings.HasPrefix(pdf, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(pdf, "%%EOF\n"))
+	assert.Contains(t, pdf, "(Mutual score: 3 - can build a design system) Tj")
+	assert.Contains(t, pdf, `Built the \(new\) theme`)
+	assert.Contains(t, pdf, "(Cycle comments) Tj")
+}
+
+func TestWrapText(t *testing.T) {
+	assert.Equal(t, []string{"one two", "three", "abcdefg", "hij"}, wrapText("one two three abcdefghij", 7))
+	assert.Equal(t, []string{"first", "", "second"}, wrapText("first\n\nsecond", 10))
+}
@@ -0,0 +1,58 @@
+package cycle
+
+import (
+	"context"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"go.uber.org/zap"
+)
+
+type RetentionStorage interface {
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
+
+// Retention periodically purges the cycles that have been in the trash for
+// longer than keepFor.
+type Retention struct {
+	storage  RetentionStorage
+	clock    app.Clock
+	interval time.Duration
+	keepFor  time.Duration
+	logger   *zap.Logger
+}
+
+func NewRetention(st RetentionStorage, clock app.Clock, interval time.Duration, keepFor time.Duration, logger *zap.Logger) *Retention {
+	return &Retention{
+		storage:  st,
+		clock:    clock,
+		interval: interval,
+		keepFor:  keepFor,
+		logger:   logger,
+	}
+}
+
+// Run purges every interval until ctx is cancelled.
+func (r *Retention) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.clock.After(r.interval):
+			purged, err := r.Purge(ctx)
+			if err != nil {
+				r.logger.Error("cycle retention purge failed", zap.Error(err))
+				continue
+			}
+			if purged > 0 {
+				r.logger.Info("cycle retention purged deleted cycles", zap.Int64("count", purged))
+			}
+		}
+	}
+}
+
+// Purge removes the cycles deleted more than keepFor ago and returns how many
+// it removed.
+func (r *Retention) Purge(ctx context.Context) (int64, error) {
+	return r.storage.PurgeDeleted(ctx, r.clock.Now().Add(-r.keepFor))
+}
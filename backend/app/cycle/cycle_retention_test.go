@@ -0,0 +1,34 @@
+package cycle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type mockRetentionStorage struct {
+	deletedBefore time.Time
+}
+
+func (m *mockRetentionStorage) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.deletedBefore = deletedBefore
+	return 2, nil
+}
+
+func TestRetentionPurge(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	clock := app.NewMockClock()
+	clock.On("Now").Return(now)
+	st := &mockRetentionStorage{}
+	retention := NewRetention(st, clock, time.Hour, 90*24*time.Hour, zap.NewNop())
+
+	purged, err := retention.Purge(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
+	assert.Equal(t, time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC), st.deletedBefore)
+}
@@ -0,0 +1,57 @@
+package cycle
+
+import (
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app/user"
+)
+
+// RolloverInput picks the review period and dates of the next cycle like
+// NewCycleInput.
+type RolloverInput struct {
+	PeriodID  string    `json:"periodId"`
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+}
+
+var cycleNotFinishedError = cycleHandlerError{message: "only a done cycle can be rolled over"}
+var samePeriodRolloverError = cycleHandlerError{message: "the next cycle must be in another review period"}
+
+// NextCycle builds the cycle that follows a done one, for the same ariser and
+// team lead. Every hard skill starts at its finalised level: the ariser's
+// profile level with the mutual scores of prev applied. Goals prev didn't
+// meet are carried over, at most maxLevelJump levels above the new level;
+// the other skills start with their goal at their level.
+func NextCycle(prev *NewCycle, ariserSkills []user.MyHardSkill, maxLevelJump int) *NewCycle {
+	levels := make(map[string]int)
+	for _, hs := range ApplyMutualScores(ariserSkills, prev.HardSkills) {
+		levels[hs.Name] = hs.CurrentLevel
+	}
+
+	hardSkills := []HardSkill{}
+	for _, hs := range prev.HardSkills {
+		level := levels[hs.Name]
+		goal := level
+		if hs.MutualScore < hs.GoalScore && hs.GoalScore > level {
+			goal = min(hs.GoalScore, level+maxLevelJump)
+		}
+		hardSkills = append(hardSkills, HardSkill{
+			ID:            hs.ID,
+			Name:          hs.Name,
+			Description:   hs.Description,
+			SkillLevels:   hs.SkillLevels,
+			PersonalScore: level,
+			GoalScore:     goal,
+		})
+	}
+
+	rolledFrom := prev.ID
+	return &NewCycle{
+		TeamLeaderMail: prev.TeamLeaderMail,
+		AriserMail:     prev.AriserMail,
+		Status:         StatusPending,
+		State:          StateReview,
+		HardSkills:     hardSkills,
+		RolledFrom:     &rolledFrom,
+	}
+}
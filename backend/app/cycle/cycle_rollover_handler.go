@@ -0,0 +1,137 @@
+package cycle
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/user"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RolloverStorage interface {
+	PeriodLookup
+	GetNewByID(id string) (*NewCycle, error)
+	GetUsersHardSkillByEmail(ctx context.Context, email string) (*user.User, error)
+	GetGoalPolicy(ctx context.Context, jobRole string, level string) (*GoalPolicy, error)
+	RolloverCycle(ctx context.Context, next NewCycle, actor string) (*NewCycle, error)
+}
+
+type rolloverHandler struct {
+	storage RolloverStorage
+	catalog HardSkillCatalog
+}
+
+func NewRolloverHandler(st RolloverStorage, catalog HardSkillCatalog) *rolloverHandler {
+	return &rolloverHandler{
+		storage: st,
+		catalog: catalog,
+	}
+}
+
+var invalidRolloverInputError = cycleHandlerError{message: "invalid rollover input"}
+var notRolloverMemberError = cycleHandlerError{message: "only the ariser and team lead of this cycle can roll it over"}
+
+// Rollover godoc
+//
+//	@summary		Rollover
+//	@description	Start the next cycle of a done one for the same ariser and team lead, in a review period that defaults to the open one. Each hard skill starts at its finalised level and unmet goals are carried over, as far as the goal policy of the ariser's job role and level allows. The body can be left out.
+//	@tags			cycle
+//	@id				RolloverCycle
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			id		path		string			true	"Done cycle ID"
+//	@param			reqJson	body		RolloverInput	false	"Review period and dates of the next cycle"
+//	@response		200		{object}	cycle.NewCycle	"OK"
+//	@response		400		{object}	app.Response	"Bad Request"
+//	@response		403		{object}	app.Response	"Not the ariser or team lead of the cycle"
+//	@response		404		{object}	app.Response	"Cycle, ariser or review period not found"
+//	@response		409		{object}	app.Response	"Cycle not done, already rolled over, in the same period or no period is open"
+//	@response		422		{object}	app.Response	"Next cycle breaks the goal policy, data lists each cycle.PolicyViolation"
+//	@response		450		{object}	app.Response	"Store Error"
+//	@response		500		{object}	app.Response	"Internal Server Error"
+//	@router			/cycles/{id}/rollover [post]
+func (h *rolloverHandler) Rollover(c app.Context) {
+	email := c.GetString("email")
+
+	var input RolloverInput
+	if err := c.Bind(&input); err != nil && !errors.Is(err, io.EOF) {
+		c.BadRequest(invalidRolloverInputError)
+		return
+	}
+
+	prev, err := h.storage.GetNewByID(c.Param("id"))
+	if err != nil {
+		if err == cycleNotFoundError {
+			c.NotFound(err)
+			return
+		}
+		c.BadRequest(err)
+		return
+	}
+	if ActorOf(prev, email) == "" {
+		c.Forbidden(notRolloverMemberError)
+		return
+	}
+	if prev.Status != StatusDone {
+		c.Conflict(cycleNotFinishedError)
+		return
+	}
+
+	period, err := findPeriod(c.Ctx(), h.storage, input.PeriodID)
+	if err != nil {
+		periodError(c, err)
+		return
+	}
+	if period.ID == prev.PeriodID {
+		c.Conflict(samePeriodRolloverError)
+		return
+	}
+	start, end, err := period.CycleDates(input.StartDate, input.EndDate)
+	if err != nil {
+		c.BadRequest(err)
+		return
+	}
+
+	ariser, err := h.storage.GetUsersHardSkillByEmail(c.Ctx(), prev.AriserMail)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			c.NotFound(err)
+			return
+		}
+		c.InternalServerError(err)
+		return
+	}
+	policy, err := h.storage.GetGoalPolicy(c.Ctx(), ariser.JobRole, ariser.Level)
+	if err != nil {
+		c.InternalServerError(err)
+		return
+	}
+
+	next := NextCycle(prev, ariser.HardSkills, policy.MaxLevelJump)
+	if violations := policy.Check(next.HardSkills); len(violations) > 0 {
+		c.UnprocessableEntity(violations, GoalPolicyError{Violations: violations})
+		return
+	}
+	next.PeriodID = period.ID
+	next.StartDate = start
+	next.EndDate = end
+	if next.HardSkills, err = pinCatalogRubrics(c.Ctx(), h.catalog, next.HardSkills, start); err != nil {
+		c.InternalServerError(err)
+		return
+	}
+
+	res, err := h.storage.RolloverCycle(c.Ctx(), *next, email)
+	if err != nil {
+		if err == cycleRolledOverError {
+			c.Conflict(err)
+			return
+		}
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(res)
+}
@@ -0,0 +1,159 @@
+package cycle
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type mockRolloverStorage struct {
+	mockDraftStorage
+	cycle      *NewCycle
+	rolledOver *NewCycle
+	err        error
+}
+
+func (m *mockRolloverStorage) GetNewByID(id string) (*NewCycle, error) {
+	if m.cycle == nil || m.cycle.ID.Hex() != id {
+		return nil, cycleNotFoundError
+	}
+	return m.cycle, nil
+}
+
+func (m *mockRolloverStorage) RolloverCycle(ctx context.Context, next NewCycle, actor string) (*NewCycle, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	next.ID = mockObjectId(2).objectId
+	m.rolledOver = &next
+	return &next, nil
+}
+
+func TestRollover(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		email          string
+		status         string
+		periodID       string
+		reqBody        string
+		policy         *GoalPolicy
+		err            error
+		expectedStatus int
+		checkNext      func(t *testing.T, cy *NewCycle)
+	}{
+		{
+			name:           "should return 200 and roll over into the open period",
+			email:          "ariser@arise.tech",
+			status:         StatusDone,
+			expectedStatus: http.StatusOK,
+			checkNext: func(t *testing.T, cy *NewCycle) {
+				assert.Equal(t, "lead@arise.tech", cy.TeamLeaderMail)
+				assert.Equal(t, mockObjectId(9).objectId, cy.PeriodID)
+				assert.Equal(t, h1Period().OpenDate, cy.StartDate)
+				assert.Equal(t, mockObjectId(1).objectId, *cy.RolledFrom)
+				assert.Equal(t, []HardSkill{{Name: "CSS", PersonalScore: 3, GoalScore: 4}}, cy.HardSkills)
+			},
+		},
+		{
+			name:           "should return 200 and carry over a two-level goal when the policy allows it",
+			email:          "lead@arise.tech",
+			status:         StatusDone,
+			reqBody:        `{"periodId":"` + mockObjectId(9).hexId + `"}`,
+			policy:         &GoalPolicy{MaxLevelJump: 2},
+			expectedStatus: http.StatusOK,
+			checkNext: func(t *testing.T, cy *NewCycle) {
+				assert.Equal(t, 5, cy.HardSkills[0].GoalScore)
+			},
+		},
+		{
+			name:           "should return 422 when the next cycle breaks the goal policy",
+			email:          "ariser@arise.tech",
+			status:         StatusDone,
+			policy:         &GoalPolicy{MaxLevelJump: 1, MandatorySkills: []string{"Go"}},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "should return 403 when not the ariser or team lead",
+			email:          "other@arise.tech",
+			status:         StatusDone,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "should return 409 when the cycle is not done",
+			email:          "ariser@arise.tech",
+			status:         StatusRunning,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "should return 409 when the next period is the same",
+			email:          "ariser@arise.tech",
+			status:         StatusDone,
+			periodID:       mockObjectId(9).hexId,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "should return 409 when already rolled over",
+			email:          "ariser@arise.tech",
+			status:         StatusDone,
+			err:            cycleRolledOverError,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "should return 400 when the body is invalid",
+			email:          "ariser@arise.tech",
+			status:         StatusDone,
+			reqBody:        `{"startDate":"tomorrow"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			u, _ := draftFixtures()
+			prev := &NewCycle{
+				ID:             mockObjectId(1).objectId,
+				TeamLeaderMail: "lead@arise.tech",
+				AriserMail:     "ariser@arise.tech",
+				Status:         tc.status,
+				HardSkills: []HardSkill{
+					{Name: "CSS", PersonalScore: 2, GoalScore: 5, MutualScore: 3},
+				},
+			}
+			if tc.periodID != "" {
+				prev.PeriodID = mockObjectId(9).objectId
+			}
+			st := &mockRolloverStorage{
+				mockDraftStorage: mockDraftStorage{user: u, period: h1Period(), policy: tc.policy},
+				cycle:            prev,
+				err:              tc.err,
+			}
+			handler := NewRolloverHandler(st, &mockHardSkillCatalog{})
+
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", tc.email)
+			})
+			engine.POST("/cycles/:id/rollover", app.NewGinHandler(handler.Rollover, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/cycles/"+mockObjectId(1).hexId+"/rollover", strings.NewReader(tc.reqBody))
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.checkNext != nil {
+				tc.checkNext(t, st.rolledOver)
+			} else {
+				assert.Nil(t, st.rolledOver)
+			}
+		})
+	}
+}
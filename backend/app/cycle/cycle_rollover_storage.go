@@ -0,0 +1,45 @@
+package cycle
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var cycleRolledOverError = CycleStorageError{message: "cycle was already rolled over"}
+
+// RolloverCycle inserts the cycle rolled over from a done one. A cycle can
+// only be rolled over once. The carried over goals are not submitted until
+// the ariser sends them, so the cycle has no SubmittedAt.
+func (s *storage) RolloverCycle(ctx context.Context, next NewCycle, actor string) (*NewCycle, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	err := s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		count, err := s.db.Collection(newCycleCollection).CountDocuments(sc, bson.M{"rolledFrom": next.RolledFrom})
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return cycleRolledOverError
+		}
+
+		res, err := s.db.Collection(newCycleCollection).InsertOne(sc, next)
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return cycleRolledOverError
+			}
+			return err
+		}
+		next.ID = res.InsertedID.(primitive.ObjectID)
+		return s.recordEvent(sc, EventRolledOver, actor, &NewCycle{}, &next)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &next, nil
+}
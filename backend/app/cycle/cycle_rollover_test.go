@@ -0,0 +1,59 @@
+package cycle
+
+import (
+	"testing"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextCycle(t *testing.T) {
+	prev := &NewCycle{
+		ID:             mockObjectId(1).objectId,
+		TeamLeaderMail: "lead@arise.tech",
+		AriserMail:     "ariser@arise.tech",
+		Status:         StatusDone,
+		State:          StateDone,
+		HardSkills: []HardSkill{
+			{Name: "CSS", PersonalScore: 2, GoalScore: 3, LeadScore: 3, Agreement: AgreementAgreed, MutualScore: 3},
+			{Name: "HTML", PersonalScore: 1, GoalScore: 2, LeadScore: 1, Agreement: AgreementAgreed, MutualScore: 1},
+			{Name: "JS", PersonalScore: 1, GoalScore: 2},
+			{Name: "Go", PersonalScore: 3, GoalScore: 3, MutualScore: 3},
+		},
+	}
+	ariserSkills := []user.MyHardSkill{
+		{Name: "CSS", CurrentLevel: 2},
+		{Name: "HTML", CurrentLevel: 1},
+		{Name: "JS", CurrentLevel: 1},
+	}
+
+	t.Run("should start each skill at its finalised level and carry over unmet goals", func(t *testing.T) {
+		next := NextCycle(prev, ariserSkills, 1)
+
+		rolledFrom := mockObjectId(1).objectId
+		assert.Equal(t, &NewCycle{
+			TeamLeaderMail: "lead@arise.tech",
+			AriserMail:     "ariser@arise.tech",
+			Status:         StatusPending,
+			State:          StateReview,
+			RolledFrom:     &rolledFrom,
+			HardSkills: []HardSkill{
+				{Name: "CSS", PersonalScore: 3, GoalScore: 3},
+				{Name: "HTML", PersonalScore: 1, GoalScore: 2},
+				{Name: "JS", PersonalScore: 1, GoalScore: 2},
+				{Name: "Go", PersonalScore: 3, GoalScore: 3},
+			},
+		}, next)
+	})
+
+	t.Run("should cap carried over goals at the level jump allowed", func(t *testing.T) {
+		dropped := &NewCycle{HardSkills: []HardSkill{
+			{Name: "CSS", PersonalScore: 3, GoalScore: 4, MutualScore: 2},
+		}}
+
+		next := NextCycle(dropped, nil, 1)
+
+		assert.Equal(t, 2, next.HardSkills[0].PersonalScore)
+		assert.Equal(t, 3, next.HardSkills[0].GoalScore)
+	})
+}
@@ -0,0 +1,52 @@
+package cycle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app/skill"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PinRubrics gives each hard skill the skill levels of the rubric version in
+// effect at the given time, usually the start of the cycle. Skills without a
+// version in effect keep the skill levels they have.
+func PinRubrics(hardSkills []HardSkill, versions []skill.RubricVersion, at time.Time) []HardSkill {
+	bySkill := make(map[primitive.ObjectID][]skill.RubricVersion)
+	for _, v := range versions {
+		bySkill[v.SkillID] = append(bySkill[v.SkillID], v)
+	}
+
+	for i := range hardSkills {
+		rubric := skill.RubricAt(bySkill[hardSkills[i].ID], at)
+		if rubric == nil {
+			continue
+		}
+		skillLevels := []SkillLevel{}
+		for _, l := range rubric.SkillLevel {
+			skillLevels = append(skillLevels, SkillLevel{
+				Level:            l.Level,
+				LevelDescription: string(l.LevelDescription),
+			})
+		}
+		hardSkills[i].SkillLevels = skillLevels
+		hardSkills[i].RubricVersion = rubric.Version
+	}
+	return hardSkills
+}
+
+// pinCatalogRubrics pins the hard skills of a new cycle to the rubric
+// versions of the catalog in effect at the given time.
+func pinCatalogRubrics(ctx context.Context, catalog HardSkillCatalog, hardSkills []HardSkill, at time.Time) ([]HardSkill, error) {
+	ids := []primitive.ObjectID{}
+	for _, hs := range hardSkills {
+		ids = append(ids, hs.ID)
+	}
+
+	versions, err := catalog.GetRubricVersions(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get rubric versions: %w", err)
+	}
+	return PinRubrics(hardSkills, versions, at), nil
+}
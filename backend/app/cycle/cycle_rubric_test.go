@@ -0,0 +1,62 @@
+package cycle
+
+import (
+	"testing"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app/skill"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPinRubrics(t *testing.T) {
+	versions := []skill.RubricVersion{
+		{
+			SkillID:    mockObjectId(1).objectId,
+			Version:    1,
+			SkillLevel: []skill.SkillLevel{{Level: 1, LevelDescription: "writes a query"}},
+		},
+		{
+			SkillID:       mockObjectId(1).objectId,
+			Version:       2,
+			EffectiveFrom: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+			SkillLevel:    []skill.SkillLevel{{Level: 1, LevelDescription: "writes a join"}, {Level: 2, LevelDescription: "tunes an index"}},
+		},
+	}
+	hardSkills := func() []HardSkill {
+		return []HardSkill{
+			{ID: mockObjectId(1).objectId, Name: "SQL", SkillLevels: []SkillLevel{{Level: 1, LevelDescription: "legacy"}}},
+			{ID: mockObjectId(2).objectId, Name: "Go", SkillLevels: []SkillLevel{{Level: 1, LevelDescription: "writes a func"}}},
+		}
+	}
+
+	testCases := []struct {
+		name            string
+		at              time.Time
+		expectedVersion int
+		expectedLevels  []SkillLevel
+	}{
+		{
+			name:            "should pin the version in effect before a newer one starts",
+			at:              time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+			expectedVersion: 1,
+			expectedLevels:  []SkillLevel{{Level: 1, LevelDescription: "writes a query"}},
+		},
+		{
+			name:            "should pin the newer version once it is in effect",
+			at:              time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+			expectedVersion: 2,
+			expectedLevels:  []SkillLevel{{Level: 1, LevelDescription: "writes a join"}, {Level: 2, LevelDescription: "tunes an index"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := PinRubrics(hardSkills(), versions, tc.at)
+
+			assert.Equal(t, tc.expectedVersion, got[0].RubricVersion)
+			assert.Equal(t, tc.expectedLevels, got[0].SkillLevels)
+			assert.Equal(t, 0, got[1].RubricVersion)
+			assert.Equal(t, hardSkills()[1].SkillLevels, got[1].SkillLevels)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package cycle
+
+import "fmt"
+
+// CycleScoreError is a score that can't be given to a hard skill of a cycle.
+type CycleScoreError struct {
+	Skill  string
+	Reason string
+}
+
+func (e CycleScoreError) Error() string {
+	return fmt.Sprintf("cannot score %q: %s", e.Skill, e.Reason)
+}
+
+// SkillAgreementError is a score sent out of turn, e.g. an answer to a lead
+// score that was never proposed.
+type SkillAgreementError struct {
+	Skill     string
+	Agreement string
+}
+
+func (e SkillAgreementError) Error() string {
+	if e.Agreement == "" {
+		return fmt.Sprintf("%q has no lead score to answer yet", e.Skill)
+	}
+	return fmt.Sprintf("%q is already %s", e.Skill, e.Agreement)
+}
+
+// ApplyLeadScores proposes a lead score for each given skill. Proposing the
+// score the ariser countered with settles the skill on it.
+//
+//	(none) -> Proposed -> Agreed
+//	            |  ^
+//	            v  |
+//	         Countered
+func ApplyLeadScores(skills []HardSkill, scores []LeadScore) ([]HardSkill, error) {
+	result := make([]HardSkill, len(skills))
+	copy(result, skills)
+
+	for _, score := range scores {
+		hs, err := findSkill(result, score.Name, score.Score)
+		if err != nil {
+			return nil, err
+		}
+		if hs.Agreement == AgreementAgreed {
+			return nil, SkillAgreementError{Skill: hs.Name, Agreement: hs.Agreement}
+		}
+
+		if hs.Agreement == AgreementCountered && hs.CounterScore == score.Score {
+			hs.Agreement = AgreementAgreed
+			hs.MutualScore = score.Score
+		} else {
+			hs.Agreement = AgreementProposed
+			hs.CounterScore = 0
+			hs.MutualScore = 0
+		}
+		hs.LeadScore = score.Score
+	}
+
+	return result, nil
+}
+
+// ApplyAgreement is the ariser's answer to the proposed lead scores. An
+// accepted lead score becomes the mutual score; otherwise the counter score
+// goes back to the lead.
+func ApplyAgreement(skills []HardSkill, answers []SkillAgreement) ([]HardSkill, error) {
+	result := make([]HardSkill, len(skills))
+	copy(result, skills)
+
+	for _, answer := range answers {
+		hs, err := findSkill(result, answer.Name, answer.CounterScore)
+		if err != nil {
+			return nil, err
+		}
+		if hs.Agreement != AgreementProposed {
+			return nil, SkillAgreementError{Skill: hs.Name, Agreement: hs.Agreement}
+		}
+
+		if answer.Accept || answer.CounterScore == hs.LeadScore {
+			hs.Agreement = AgreementAgreed
+			hs.MutualScore = hs.LeadScore
+		} else {
+			if answer.CounterScore < 1 {
+				return nil, CycleScoreError{Skill: hs.Name, Reason: "counter score is required when the lead score is not accepted"}
+			}
+			hs.Agreement = AgreementCountered
+			hs.CounterScore = answer.CounterScore
+		}
+	}
+
+	return result, nil
+}
+
+// findSkill returns the skill with the given name, checking score against its
+// levels on the way.
+func findSkill(skills []HardSkill, name string, score int) (*HardSkill, error) {
+	for i := range skills {
+		if skills[i].Name != name {
+			continue
+		}
+		if levels := len(skills[i].SkillLevels); levels > 0 && score > levels {
+			return nil, CycleScoreError{Skill: name, Reason: fmt.Sprintf("score must be at most %d", levels)}
+		}
+		return &skills[i], nil
+	}
+	return nil, CycleScoreError{Skill: name, Reason: "skill is not in this cycle"}
+}
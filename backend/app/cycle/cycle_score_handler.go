@@ -0,0 +1,152 @@
+package cycle
+
+import (
+	"context"
+	"errors"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+)
+
+type ScoreStorage interface {
+	GetNewByID(id string) (*NewCycle, error)
+	UpdateScoresByID(ctx context.Context, id string, version int, hardSkills []HardSkill, email string) (*NewCycle, error)
+}
+
+type scoreHandler struct {
+	storage ScoreStorage
+}
+
+func NewScoreHandler(st ScoreStorage) *scoreHandler {
+	return &scoreHandler{
+		storage: st,
+	}
+}
+
+var notCycleTeamLeadError = cycleHandlerError{message: "only the team lead of this cycle can do it"}
+var notCycleAriserError = cycleHandlerError{message: "only the ariser of this cycle can do it"}
+var cycleNotRunningError = cycleHandlerError{message: "scores can only be given while the cycle is in progress"}
+
+// LeadScores godoc
+//
+//	@summary		LeadScores
+//	@description	Team lead proposes a score for hard skills of a cycle in progress. Proposing the score the ariser countered with agrees on it.
+//	@tags			cycle
+//	@id				LeadScores
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			id			path		string				true	"Cycle ID"
+//	@param			If-Match	header		string				true	"ETag of the cycle"
+//	@param			reqJson		body		LeadScoresRequest	true	"Lead scores"
+//	@response		200			{object}	cycle.NewCycle		"OK"
+//	@response		400			{object}	app.Response		"Bad Request"
+//	@response		403			{object}	app.Response		"Not the team lead of the cycle"
+//	@response		404			{object}	app.Response		"Cycle not found"
+//	@response		409			{object}	app.Response		"Cycle not in progress or skill already agreed"
+//	@response		412			{object}	app.Response		"Cycle was changed since it was read"
+//	@response		428			{object}	app.Response		"If-Match is missing"
+//	@response		500			{object}	app.Response		"Internal Server Error"
+//	@router			/cycles/{id}/lead-scores [put]
+func (h *scoreHandler) LeadScores(c app.Context) {
+	var req LeadScoresRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.BadRequest(invalidRequestError)
+		return
+	}
+
+	h.score(c, ActorTeamLeader, func(skills []HardSkill) ([]HardSkill, error) {
+		return ApplyLeadScores(skills, req.HardSkills)
+	})
+}
+
+// Agreement godoc
+//
+//	@summary		Agreement
+//	@description	Ariser accepts the proposed lead score of hard skills, which makes it the mutual score, or counters it with another score.
+//	@tags			cycle
+//	@id				Agreement
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			id			path		string				true	"Cycle ID"
+//	@param			If-Match	header		string				true	"ETag of the cycle"
+//	@param			reqJson		body		AgreementRequest	true	"Answers to lead scores"
+//	@response		200			{object}	cycle.NewCycle		"OK"
+//	@response		400			{object}	app.Response		"Bad Request"
+//	@response		403			{object}	app.Response		"Not the ariser of the cycle"
+//	@response		404			{object}	app.Response		"Cycle not found"
+//	@response		409			{object}	app.Response		"Cycle not in progress or no lead score to answer"
+//	@response		412			{object}	app.Response		"Cycle was changed since it was read"
+//	@response		428			{object}	app.Response		"If-Match is missing"
+//	@response		500			{object}	app.Response		"Internal Server Error"
+//	@router			/cycles/{id}/agreement [put]
+func (h *scoreHandler) Agreement(c app.Context) {
+	var req AgreementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.BadRequest(invalidRequestError)
+		return
+	}
+
+	h.score(c, ActorAriser, func(skills []HardSkill) ([]HardSkill, error) {
+		return ApplyAgreement(skills, req.HardSkills)
+	})
+}
+
+func (h *scoreHandler) score(c app.Context, actor Actor, apply func([]HardSkill) ([]HardSkill, error)) {
+	id := c.Param("id")
+	email := c.GetString("email")
+	version, ok := app.IfMatch(c)
+	if !ok {
+		return
+	}
+
+	current, err := h.storage.GetNewByID(id)
+	if err != nil {
+		if err == cycleNotFoundError {
+			c.NotFound(err)
+			return
+		}
+		c.BadRequest(err)
+		return
+	}
+
+	if ActorOf(current, email) != actor {
+		if actor == ActorTeamLeader {
+			c.Forbidden(notCycleTeamLeadError)
+			return
+		}
+		c.Forbidden(notCycleAriserError)
+		return
+	}
+	if current.Status != StatusRunning {
+		c.Conflict(cycleNotRunningError)
+		return
+	}
+	if current.Version != version {
+		c.PreconditionFailed(cycleVersionChangedError)
+		return
+	}
+
+	skills, err := apply(current.HardSkills)
+	if err != nil {
+		if errors.As(err, &SkillAgreementError{}) {
+			c.Conflict(err)
+			return
+		}
+		c.BadRequest(err)
+		return
+	}
+
+	res, err := h.storage.UpdateScoresByID(c.Ctx(), id, version, skills, email)
+	if err != nil {
+		if err == cycleVersionChangedError {
+			c.PreconditionFailed(err)
+			return
+		}
+		c.StoreError(err)
+		return
+	}
+
+	c.Header("ETag", app.ETag(res.Version))
+	c.OK(res)
+}
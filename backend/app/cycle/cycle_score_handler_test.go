@@ -0,0 +1,201 @@
+package cycle
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type mockScoreStorage struct {
+	cycle   *NewCycle
+	err     error
+	updated []HardSkill
+}
+
+func (m *mockScoreStorage) GetNewByID(id string) (*NewCycle, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.cycle, nil
+}
+
+func (m *mockScoreStorage) UpdateScoresByID(ctx context.Context, id string, version int, hardSkills []HardSkill, email string) (*NewCycle, error) {
+	if m.cycle.Version != version {
+		return nil, cycleVersionChangedError
+	}
+	m.updated = hardSkills
+	cy := *m.cycle
+	cy.HardSkills = hardSkills
+	cy.Version++
+	return &cy, nil
+}
+
+func TestScoreHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	running := func() *NewCycle {
+		return &NewCycle{
+			ID:             mockObjectId(1).objectId,
+			TeamLeaderMail: "lead@arise.tech",
+			AriserMail:     "ariser@arise.tech",
+			Status:         StatusRunning,
+			Version:        2,
+			HardSkills: []HardSkill{
+				{Name: "CSS", LeadScore: 3, Agreement: AgreementProposed},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		path           string
+		email          string
+		cycle          *NewCycle
+		err            error
+		ifMatch        string
+		reqBody        string
+		expectedStatus int
+		expectedSkill  *HardSkill
+	}{
+		{
+			name:           "should return 200 when lead proposes a score",
+			path:           "lead-scores",
+			email:          "lead@arise.tech",
+			cycle:          running(),
+			reqBody:        `{"hardSkills":[{"name":"CSS","score":4}]}`,
+			expectedStatus: http.StatusOK,
+			expectedSkill:  &HardSkill{Name: "CSS", LeadScore: 4, Agreement: AgreementProposed},
+		},
+		{
+			name:           "should return 200 when ariser accepts the lead score",
+			path:           "agreement",
+			email:          "ariser@arise.tech",
+			cycle:          running(),
+			reqBody:        `{"hardSkills":[{"name":"CSS","accept":true}]}`,
+			expectedStatus: http.StatusOK,
+			expectedSkill:  &HardSkill{Name: "CSS", LeadScore: 3, Agreement: AgreementAgreed, MutualScore: 3},
+		},
+		{
+			name:           "should return 412 when the cycle was changed since it was read",
+			path:           "lead-scores",
+			email:          "lead@arise.tech",
+			cycle:          running(),
+			ifMatch:        `"1"`,
+			reqBody:        `{"hardSkills":[{"name":"CSS","score":4}]}`,
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "should return 428 when If-Match is missing",
+			path:           "agreement",
+			email:          "ariser@arise.tech",
+			cycle:          running(),
+			ifMatch:        "-",
+			reqBody:        `{"hardSkills":[{"name":"CSS","accept":true}]}`,
+			expectedStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:           "should return 403 when ariser sends lead scores",
+			path:           "lead-scores",
+			email:          "ariser@arise.tech",
+			cycle:          running(),
+			reqBody:        `{"hardSkills":[{"name":"CSS","score":5}]}`,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "should return 403 when lead answers for the ariser",
+			path:           "agreement",
+			email:          "lead@arise.tech",
+			cycle:          running(),
+			reqBody:        `{"hardSkills":[{"name":"CSS","accept":true}]}`,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:  "should return 409 when cycle is not in progress",
+			path:  "lead-scores",
+			email: "lead@arise.tech",
+			cycle: func() *NewCycle {
+				cy := running()
+				cy.Status = StatusApproved
+				return cy
+			}(),
+			reqBody:        `{"hardSkills":[{"name":"CSS","score":4}]}`,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:  "should return 409 when there is no lead score to answer",
+			path:  "agreement",
+			email: "ariser@arise.tech",
+			cycle: func() *NewCycle {
+				cy := running()
+				cy.HardSkills[0].Agreement = ""
+				return cy
+			}(),
+			reqBody:        `{"hardSkills":[{"name":"CSS","accept":true}]}`,
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "should return 400 when skill is not in the cycle",
+			path:           "lead-scores",
+			email:          "lead@arise.tech",
+			cycle:          running(),
+			reqBody:        `{"hardSkills":[{"name":"Go","score":4}]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should return 400 when score is missing",
+			path:           "lead-scores",
+			email:          "lead@arise.tech",
+			cycle:          running(),
+			reqBody:        `{"hardSkills":[{"name":"CSS"}]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "should return 404 when cycle is not found",
+			path:           "agreement",
+			email:          "ariser@arise.tech",
+			err:            cycleNotFoundError,
+			reqBody:        `{"hardSkills":[{"name":"CSS","accept":true}]}`,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockScoreStorage{cycle: tc.cycle, err: tc.err}
+			handler := NewScoreHandler(st)
+
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", tc.email)
+			})
+			engine.PUT("/cycles/:id/lead-scores", app.NewGinHandler(handler.LeadScores, zap.NewNop()))
+			engine.PUT("/cycles/:id/agreement", app.NewGinHandler(handler.Agreement, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/cycles/"+mockObjectId(1).hexId+"/"+tc.path, strings.NewReader(tc.reqBody))
+			switch tc.ifMatch {
+			case "":
+				req.Header.Set("If-Match", `"2"`)
+			case "-":
+			default:
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedSkill != nil {
+				assert.Equal(t, []HardSkill{*tc.expectedSkill}, st.updated)
+				assert.Equal(t, `"3"`, rec.Header().Get("ETag"))
+			} else {
+				assert.Nil(t, st.updated)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package cycle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyLeadScores(t *testing.T) {
+	levels := []SkillLevel{{Level: 1}, {Level: 2}, {Level: 3}, {Level: 4}, {Level: 5}}
+
+	testCases := []struct {
+		name    string
+		skill   HardSkill
+		score   LeadScore
+		want    HardSkill
+		wantErr error
+	}{
+		{
+			name:  "should propose a lead score",
+			skill: HardSkill{Name: "CSS", SkillLevels: levels},
+			score: LeadScore{Name: "CSS", Score: 3},
+			want:  HardSkill{Name: "CSS", SkillLevels: levels, LeadScore: 3, Agreement: AgreementProposed},
+		},
+		{
+			name:  "should agree when lead takes the counter score",
+			skill: HardSkill{Name: "CSS", SkillLevels: levels, LeadScore: 3, CounterScore: 4, Agreement: AgreementCountered},
+			score: LeadScore{Name: "CSS", Score: 4},
+			want:  HardSkill{Name: "CSS", SkillLevels: levels, LeadScore: 4, CounterScore: 4, Agreement: AgreementAgreed, MutualScore: 4},
+		},
+		{
+			name:  "should propose again when lead keeps another score",
+			skill: HardSkill{Name: "CSS", SkillLevels: levels, LeadScore: 3, CounterScore: 5, Agreement: AgreementCountered},
+			score: LeadScore{Name: "CSS", Score: 3},
+			want:  HardSkill{Name: "CSS", SkillLevels: levels, LeadScore: 3, Agreement: AgreementProposed},
+		},
+		{
+			name:    "should not rescore an agreed skill",
+			skill:   HardSkill{Name: "CSS", LeadScore: 3, MutualScore: 3, Agreement: AgreementAgreed},
+			score:   LeadScore{Name: "CSS", Score: 2},
+			wantErr: SkillAgreementError{Skill: "CSS", Agreement: AgreementAgreed},
+		},
+		{
+			name:    "should not score above the highest level",
+			skill:   HardSkill{Name: "CSS", SkillLevels: levels},
+			score:   LeadScore{Name: "CSS", Score: 6},
+			wantErr: CycleScoreError{Skill: "CSS", Reason: "score must be at most 5"},
+		},
+		{
+			name:    "should not score a skill outside the cycle",
+			skill:   HardSkill{Name: "CSS"},
+			score:   LeadScore{Name: "Go", Score: 1},
+			wantErr: CycleScoreError{Skill: "Go", Reason: "skill is not in this cycle"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			skills := []HardSkill{tc.skill}
+			got, err := ApplyLeadScores(skills, []LeadScore{tc.score})
+
+			if tc.wantErr != nil {
+				assert.Equal(t, tc.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []HardSkill{tc.want}, got)
+			assert.Equal(t, tc.skill, skills[0], "input must not be changed")
+		})
+	}
+}
+
+func TestApplyAgreement(t *testing.T) {
+	proposed := HardSkill{Name: "CSS", LeadScore: 3, Agreement: AgreementProposed}
+
+	testCases := []struct {
+		name    string
+		skill   HardSkill
+		answer  SkillAgreement
+		want    HardSkill
+		wantErr error
+	}{
+		{
+			name:   "should make the lead score mutual when accepted",
+			skill:  proposed,
+			answer: SkillAgreement{Name: "CSS", Accept: true},
+			want:   HardSkill{Name: "CSS", LeadScore: 3, Agreement: AgreementAgreed, MutualScore: 3},
+		},
+		{
+			name:   "should counter with another score",
+			skill:  proposed,
+			answer: SkillAgreement{Name: "CSS", CounterScore: 4},
+			want:   HardSkill{Name: "CSS", LeadScore: 3, CounterScore: 4, Agreement: AgreementCountered},
+		},
+		{
+			name:    "should need a counter score when not accepted",
+			skill:   proposed,
+			answer:  SkillAgreement{Name: "CSS"},
+			wantErr: CycleScoreError{Skill: "CSS", Reason: "counter score is required when the lead score is not accepted"},
+		},
+		{
+			name:    "should not answer before the lead scores",
+			skill:   HardSkill{Name: "CSS"},
+			answer:  SkillAgreement{Name: "CSS", Accept: true},
+			wantErr: SkillAgreementError{Skill: "CSS"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ApplyAgreement([]HardSkill{tc.skill}, []SkillAgreement{tc.answer})
+
+			if tc.wantErr != nil {
+				assert.Equal(t, tc.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []HardSkill{tc.want}, got)
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package cycle
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const searchDateFormat = "2006-01-02"
+
+var invalidSearchDateError = cycleHandlerError{message: "from and to must be dates like 2024-01-31 or RFC 3339 times, with from not after to"}
+var invalidSearchStateError = cycleHandlerError{message: "invalid cycle state"}
+
+// CycleSearch filters cycles on any mix of its fields; the zero value matches
+// every cycle.
+type CycleSearch struct {
+	// From and To keep the cycles whose period overlaps them.
+	From           *time.Time
+	To             *time.Time
+	AriserMail     string
+	TeamLeaderMail string
+	Status         string
+	State          string
+	// Skill keeps the cycles with a hard skill of this name.
+	Skill string
+	// GoalNotMet keeps the cycles with a hard skill whose mutual score is
+	// below its goal, only the Skill one when it is set. Mutual scores are
+	// 0 until agreed, so pair it with StatusDone for the goals really missed.
+	GoalNotMet bool
+	// Member keeps the cycles this user is the ariser or team lead of.
+	Member string
+}
+
+// filter is the Mongo filter of the search.
+func (s CycleSearch) filter() bson.M {
+	filter := bson.M{}
+	if s.From != nil {
+		filter["endDate"] = bson.M{"$gte": *s.From}
+	}
+	if s.To != nil {
+		filter["startDate"] = bson.M{"$lte": *s.To}
+	}
+	if s.AriserMail != "" {
+		filter["ariserMail"] = s.AriserMail
+	}
+	if s.TeamLeaderMail != "" {
+		filter["teamLeaderMail"] = s.TeamLeaderMail
+	}
+	if s.Status != "" && s.Status != StatusAll {
+		filter["status"] = s.Status
+	}
+	if s.State != "" {
+		filter["state"] = s.State
+	}
+	if s.Skill != "" {
+		filter["hardSkills.name"] = s.Skill
+	}
+	if s.Member != "" {
+		filter["$or"] = memberFilter(s.Member)
+	}
+	if s.GoalNotMet {
+		var skills any = "$hardSkills"
+		if s.Skill != "" {
+			skills = bson.M{"$filter": bson.M{
+				"input": "$hardSkills",
+				"as":    "hs",
+				"cond":  bson.M{"$eq": bson.A{"$$hs.name", s.Skill}},
+			}}
+		}
+		filter["$expr"] = bson.M{"$anyElementTrue": bson.A{bson.M{"$map": bson.M{
+			"input": bson.M{"$ifNull": bson.A{skills, bson.A{}}},
+			"as":    "hs",
+			"in":    bson.M{"$lt": bson.A{"$$hs.mutualScore", "$$hs.goalScore"}},
+		}}}}
+	}
+	return filter
+}
+
+// parseSearchDate reads a date like 2024-01-31 or an RFC 3339 time. An
+// empty value is no date.
+func parseSearchDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range []string{searchDateFormat, time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, invalidSearchDateError
+}
+
+func validateCycleState(state string) bool {
+	switch state {
+	case StateReview, StateRevise, StateApproved, StateRunning, StateDone:
+		return true
+	}
+	return false
+}
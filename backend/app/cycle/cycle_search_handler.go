@@ -0,0 +1,99 @@
+package cycle
+
+import (
+	"context"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+)
+
+type SearchStorage interface {
+	SearchCycles(ctx context.Context, search CycleSearch, page PageRequest) (*Page[*NewCycle], error)
+}
+
+type searchHandler struct {
+	storage SearchStorage
+	admins  []string
+}
+
+func NewSearchHandler(st SearchStorage, admins []string) *searchHandler {
+	return &searchHandler{
+		storage: st,
+		admins:  admins,
+	}
+}
+
+// Search godoc
+//
+//	@summary		Search
+//	@description	Get a page of the cycles matching every filter given. Admins search every cycle, anyone else only those they are the ariser or team lead of.
+//	@tags			cycle
+//	@id				SearchCycles
+//	@security		BearerAuth
+//	@produce		json
+//	@param			from			query		string						false	"Only cycles still running on or after this date"
+//	@param			to				query		string						false	"Only cycles started on or before this date"
+//	@param			ariserMail		query		string						false	"Only cycles of this ariser"
+//	@param			teamLeaderMail	query		string						false	"Only cycles of this team lead"
+//	@param			status			query		string						false	"Only cycles with this status"
+//	@param			state			query		string						false	"Only cycles in this state"
+//	@param			skill			query		string						false	"Only cycles with this hard skill"
+//	@param			goalNotMet		query		bool						false	"Only cycles with a mutual score below its goal, of the skill when given"
+//	@param			cursor			query		string						false	"nextCursor of the previous page"
+//	@param			limit			query		int							false	"Page size, 10 by default and 100 at most"
+//	@param			sort			query		string						false	"startDate (default), endDate or status"
+//	@param			order			query		string						false	"desc (default) or asc"
+//	@response		200				{object}	cycle.Page[cycle.NewCycle]	"OK"
+//	@response		400				{object}	app.Response				"Bad Request"
+//	@response		450				{object}	app.Response				"Store Error"
+//	@router			/cycles/search [get]
+func (h *searchHandler) Search(c app.Context) {
+	from, err := parseSearchDate(c.Query("from"))
+	if err != nil {
+		c.BadRequest(err)
+		return
+	}
+	to, err := parseSearchDate(c.Query("to"))
+	if err != nil {
+		c.BadRequest(err)
+		return
+	}
+	if from != nil && to != nil && from.After(*to) {
+		c.BadRequest(invalidSearchDateError)
+		return
+	}
+
+	search := CycleSearch{
+		From:           from,
+		To:             to,
+		AriserMail:     c.Query("ariserMail"),
+		TeamLeaderMail: c.Query("teamLeaderMail"),
+		Status:         c.Query("status"),
+		State:          c.Query("state"),
+		Skill:          c.Query("skill"),
+		GoalNotMet:     c.Query("goalNotMet") == "true",
+	}
+	if email := c.GetString("email"); !app.IsAdmin(h.admins, email) {
+		search.Member = email
+	}
+	if search.Status != "" && !validateCycleStatus(search.Status) {
+		c.BadRequest(invalidRequestError)
+		return
+	}
+	if search.State != "" && !validateCycleState(search.State) {
+		c.BadRequest(invalidSearchStateError)
+		return
+	}
+
+	page, ok := pageRequest(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.storage.SearchCycles(c.Ctx(), search, page)
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(res)
+}
@@ -0,0 +1,126 @@
+package cycle
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type mockSearchStorage struct {
+	search *CycleSearch
+	page   PageRequest
+}
+
+func (m *mockSearchStorage) SearchCycles(ctx context.Context, search CycleSearch, page PageRequest) (*Page[*NewCycle], error) {
+	m.search = &search
+	m.page = page
+	return &Page[*NewCycle]{Items: []*NewCycle{}}, nil
+}
+
+func TestSearch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name             string
+		email            string
+		query            string
+		expectedStatus   int
+		expectedSearch   *CycleSearch
+		expectedResponse string
+	}{
+		{
+			name:           "should return 200 and search with every filter",
+			query:          "?from=2024-01-01&to=2024-06-30&ariserMail=a@arise.tech&teamLeaderMail=lead@arise.tech&status=Done&state=Done&skill=CSS&goalNotMet=true&limit=20",
+			expectedStatus: http.StatusOK,
+			expectedSearch: &CycleSearch{
+				From:           &from,
+				To:             &to,
+				AriserMail:     "a@arise.tech",
+				TeamLeaderMail: "lead@arise.tech",
+				Status:         StatusDone,
+				State:          StateDone,
+				Skill:          "CSS",
+				GoalNotMet:     true,
+			},
+			expectedResponse: `{"status":"success","message":"","data":{"items":[],"total":0,"hasMore":false}}`,
+		},
+		{
+			name:           "should return 200 and search everything without filters",
+			expectedStatus: http.StatusOK,
+			expectedSearch: &CycleSearch{},
+		},
+		{
+			name:           "should return 200 and search only the cycles of the user when not an admin",
+			email:          "lead@arise.tech",
+			query:          "?ariserMail=a@arise.tech",
+			expectedStatus: http.StatusOK,
+			expectedSearch: &CycleSearch{AriserMail: "a@arise.tech", Member: "lead@arise.tech"},
+		},
+		{
+			name:             "should return 400 when from is after to",
+			query:            "?from=2024-06-30&to=2024-01-01",
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"from and to must be dates like 2024-01-31 or RFC 3339 times, with from not after to"}`,
+		},
+		{
+			name:             "should return 400 when date is invalid",
+			query:            "?from=yesterday",
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"from and to must be dates like 2024-01-31 or RFC 3339 times, with from not after to"}`,
+		},
+		{
+			name:             "should return 400 when status is invalid",
+			query:            "?status=Lost",
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid cycle request"}`,
+		},
+		{
+			name:             "should return 400 when state is invalid",
+			query:            "?state=Lost",
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid cycle state"}`,
+		},
+		{
+			name:             "should return 400 when sort is invalid",
+			query:            "?sort=ariserMail",
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"sort must be startDate, endDate or status and order asc or desc"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockSearchStorage{}
+			handler := NewSearchHandler(st, []string{"hr@arise.tech"})
+
+			email := "hr@arise.tech"
+			if tc.email != "" {
+				email = tc.email
+			}
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", email)
+			})
+			engine.GET("/cycles/search", app.NewGinHandler(handler.Search, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/cycles/search"+tc.query, nil)
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedSearch, st.search)
+			if tc.expectedResponse != "" {
+				assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+			}
+		})
+	}
+}
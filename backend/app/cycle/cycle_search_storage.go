@@ -0,0 +1,64 @@
+package cycle
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SearchCycles finds a page of the cycles matching the search.
+func (s *storage) SearchCycles(ctx context.Context, search CycleSearch, page PageRequest) (*Page[*NewCycle], error) {
+	return s.findPage(ctx, search.filter(), page)
+}
+
+// cycleIndexes are the indexes behind the cycle lists, the search, the
+// lookups of comments and history by cycle and the goal policy of each job
+// role and level.
+var cycleIndexes = map[string][]mongo.IndexModel{
+	newCycleCollection: {
+		{Keys: bson.D{{Key: "teamLeaderMail", Value: 1}, {Key: "status", Value: 1}, {Key: "startDate", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "ariserMail", Value: 1}, {Key: "startDate", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "periodId", Value: 1}, {Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "startDate", Value: 1}, {Key: "endDate", Value: 1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "state", Value: 1}}},
+		{Keys: bson.D{{Key: "hardSkills.name", Value: 1}}},
+		{Keys: bson.D{{Key: "deletedAt", Value: 1}}},
+		{
+			Keys:    bson.D{{Key: "rolledFrom", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"rolledFrom": bson.M{"$exists": true}}),
+		},
+	},
+	cycleCommentCollection: {
+		{Keys: bson.D{{Key: "cycleId", Value: 1}, {Key: "createdAt", Value: 1}}},
+	},
+	cycleEventCollection: {
+		{Keys: bson.D{{Key: "cycleId", Value: 1}, {Key: "at", Value: 1}}},
+	},
+	reviewPeriodCollection: {
+		{Keys: bson.D{{Key: "openDate", Value: 1}, {Key: "closeDate", Value: 1}}},
+	},
+	peerFeedbackCollection: {
+		{Keys: bson.D{{Key: "cycleId", Value: 1}, {Key: "peerMail", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "peerMail", Value: 1}, {Key: "invitedAt", Value: -1}}},
+	},
+	goalPolicyCollection: {
+		{Keys: bson.D{{Key: "jobRole", Value: 1}, {Key: "level", Value: 1}}, Options: options.Index().SetUnique(true)},
+	},
+}
+
+// EnsureIndexes creates the cycle indexes that don't exist yet. It is safe to
+// run on every start.
+func (s *storage) EnsureIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	for collection, indexes := range cycleIndexes {
+		if _, err := s.db.Collection(collection).Indexes().CreateMany(ctx, indexes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package cycle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCycleSearchFilter(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		search   CycleSearch
+		expected bson.M
+	}{
+		{
+			name:     "should match every cycle when nothing is set",
+			search:   CycleSearch{},
+			expected: bson.M{},
+		},
+		{
+			name: "should keep cycles overlapping the dates",
+			search: CycleSearch{
+				From: &from,
+				To:   &to,
+			},
+			expected: bson.M{
+				"endDate":   bson.M{"$gte": from},
+				"startDate": bson.M{"$lte": to},
+			},
+		},
+		{
+			name: "should match people, status, state and skill",
+			search: CycleSearch{
+				AriserMail:     "ariser@arise.tech",
+				TeamLeaderMail: "lead@arise.tech",
+				Status:         StatusDone,
+				State:          StateDone,
+				Skill:          "CSS",
+			},
+			expected: bson.M{
+				"ariserMail":      "ariser@arise.tech",
+				"teamLeaderMail":  "lead@arise.tech",
+				"status":          StatusDone,
+				"state":           StateDone,
+				"hardSkills.name": "CSS",
+			},
+		},
+		{
+			name:   "should keep the cycles of the member only",
+			search: CycleSearch{Member: "lead@arise.tech"},
+			expected: bson.M{
+				"$or": bson.A{bson.M{"ariserMail": "lead@arise.tech"}, bson.M{"teamLeaderMail": "lead@arise.tech"}},
+			},
+		},
+		{
+			name:     "should ignore status All",
+			search:   CycleSearch{Status: StatusAll},
+			expected: bson.M{},
+		},
+		{
+			name:   "should compare mutual and goal scores of every skill",
+			search: CycleSearch{GoalNotMet: true},
+			expected: bson.M{
+				"$expr": bson.M{"$anyElementTrue": bson.A{bson.M{"$map": bson.M{
+					"input": bson.M{"$ifNull": bson.A{"$hardSkills", bson.A{}}},
+					"as":    "hs",
+					"in":    bson.M{"$lt": bson.A{"$$hs.mutualScore", "$$hs.goalScore"}},
+				}}}},
+			},
+		},
+		{
+			name:   "should compare mutual and goal scores of the given skill only",
+			search: CycleSearch{Skill: "CSS", GoalNotMet: true},
+			expected: bson.M{
+				"hardSkills.name": "CSS",
+				"$expr": bson.M{"$anyElementTrue": bson.A{bson.M{"$map": bson.M{
+					"input": bson.M{"$ifNull": bson.A{
+						bson.M{"$filter": bson.M{
+							"input": "$hardSkills",
+							"as":    "hs",
+							"cond":  bson.M{"$eq": bson.A{"$$hs.name", "CSS"}},
+						}},
+						bson.A{},
+					}},
+					"as": "hs",
+					"in": bson.M{"$lt": bson.A{"$$hs.mutualScore", "$$hs.goalScore"}},
+				}}}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.search.filter())
+		})
+	}
+}
+
+func TestParseSearchDate(t *testing.T) {
+	t.Run("should read a date", func(t *testing.T) {
+		got, err := parseSearchDate("2024-01-31")
+
+		assert.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), *got)
+	})
+
+	t.Run("should read an RFC 3339 time", func(t *testing.T) {
+		got, err := parseSearchDate("2024-01-31T10:00:00+07:00")
+
+		assert.NoError(t, err)
+		assert.True(t, time.Date(2024, 1, 31, 3, 0, 0, 0, time.UTC).Equal(*got))
+	})
+
+	t.Run("should be no date when empty", func(t *testing.T) {
+		got, err := parseSearchDate("")
+
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("should fail on anything else", func(t *testing.T) {
+		_, err := parseSearchDate("31/01/2024")
+
+		assert.Equal(t, invalidSearchDateError, err)
+	})
+}
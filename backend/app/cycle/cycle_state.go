@@ -0,0 +1,79 @@
+package cycle
+
+import "fmt"
+
+type Actor string
+
+const (
+	ActorAriser     Actor = "ariser"
+	ActorTeamLeader Actor = "teamLeader"
+)
+
+// transition is one legal move of a NewCycle status and who may trigger it.
+type transition struct {
+	from   string
+	to     string
+	state  string
+	actors []Actor
+}
+
+// transitions is the whole NewCycle state machine. A move that isn't listed
+// here is illegal, whoever asks for it.
+//
+//	Pending -> Approved -> In Progress -> Done
+//	Pending -> Rejected -> Pending          (ariser resubmits goals)
+//	In Progress -> Pending                  (ariser revises goals)
+//	Done -> In Progress                     (lead reopens the cycle)
+var transitions = []transition{
+	{from: StatusPending, to: StatusApproved, state: StateApproved, actors: []Actor{ActorTeamLeader}},
+	{from: StatusPending, to: StatusRejected, state: StateRevise, actors: []Actor{ActorTeamLeader}},
+	{from: StatusRejected, to: StatusPending, state: StateReview, actors: []Actor{ActorAriser}},
+	{from: StatusApproved, to: StatusRunning, state: StateRunning, actors: []Actor{ActorAriser, ActorTeamLeader}},
+	{from: StatusRunning, to: StatusPending, state: StateReview, actors: []Actor{ActorAriser}},
+	{from: StatusRunning, to: StatusDone, state: StateDone, actors: []Actor{ActorTeamLeader}},
+	{from: StatusDone, to: StatusRunning, state: StateRunning, actors: []Actor{ActorTeamLeader}},
+}
+
+type CycleTransitionError struct {
+	From  string
+	To    string
+	Actor Actor
+}
+
+func (e CycleTransitionError) Error() string {
+	if e.Actor == "" {
+		return fmt.Sprintf("cannot change cycle status from %q to %q: not a member of this cycle", e.From, e.To)
+	}
+	return fmt.Sprintf("cannot change cycle status from %q to %q as %s", e.From, e.To, e.Actor)
+}
+
+// Transition checks whether actor may move a cycle from one status to another
+// and returns the state the cycle should be in afterwards.
+func Transition(from string, to string, actor Actor) (string, error) {
+	for _, t := range transitions {
+		if t.from != from || t.to != to {
+			continue
+		}
+		for _, a := range t.actors {
+			if a == actor {
+				return t.state, nil
+			}
+		}
+		break
+	}
+
+	return "", CycleTransitionError{From: from, To: to, Actor: actor}
+}
+
+// ActorOf tells which side of the cycle the given email is on.
+func ActorOf(cy *NewCycle, email string) Actor {
+	switch email {
+	case "":
+		return ""
+	case cy.TeamLeaderMail:
+		return ActorTeamLeader
+	case cy.AriserMail:
+		return ActorAriser
+	}
+	return ""
+}
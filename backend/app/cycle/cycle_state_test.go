@@ -0,0 +1,41 @@
+package cycle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransition(t *testing.T) {
+	testCases := []struct {
+		name      string
+		from      string
+		to        string
+		actor     Actor
+		wantState string
+		wantErr   bool
+	}{
+		{name: "lead approves pending goals", from: StatusPending, to: StatusApproved, actor: ActorTeamLeader, wantState: StateApproved},
+		{name: "lead rejects pending goals", from: StatusPending, to: StatusRejected, actor: ActorTeamLeader, wantState: StateRevise},
+		{name: "ariser resubmits rejected goals", from: StatusRejected, to: StatusPending, actor: ActorAriser, wantState: StateReview},
+		{name: "ariser starts approved cycle", from: StatusApproved, to: StatusRunning, actor: ActorAriser, wantState: StateRunning},
+		{name: "lead finishes running cycle", from: StatusRunning, to: StatusDone, actor: ActorTeamLeader, wantState: StateDone},
+		{name: "lead reopens finished cycle", from: StatusDone, to: StatusRunning, actor: ActorTeamLeader, wantState: StateRunning},
+		{name: "ariser cannot approve own goals", from: StatusPending, to: StatusApproved, actor: ActorAriser, wantErr: true},
+		{name: "ariser cannot finish cycle", from: StatusRunning, to: StatusDone, actor: ActorAriser, wantErr: true},
+		{name: "pending cannot jump to done", from: StatusPending, to: StatusDone, actor: ActorTeamLeader, wantErr: true},
+		{name: "outsider cannot move cycle", from: StatusPending, to: StatusApproved, actor: "", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state, err := Transition(tc.from, tc.to, tc.actor)
+			if tc.wantErr {
+				assert.ErrorAs(t, err, &CycleTransitionError{})
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantState, state)
+		})
+	}
+}
@@ -2,13 +2,10 @@ package cycle
 
 import (
 	"context"
-	"fmt"
-	"log/slog"
-	"sync"
 	"time"
 
-	"gitdev.devops.krungthai.com/aster/ariskill/app/skill"
 	"gitdev.devops.krungthai.com/aster/ariskill/app/user"
+	"gitdev.devops.krungthai.com/aster/ariskill/database"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -38,6 +35,8 @@ func (e CycleStorageError) Error() string {
 var invalidRequestError = CycleStorageError{message: "invalid cycle request"}
 var cycleNotFoundError = CycleStorageError{message: "cycle not found"}
 var dbConnectNotFound = CycleStorageError{message: "cannot connect to mongodb"}
+var cycleVersionChangedError = CycleStorageError{message: "cycle was changed by someone else, reload it and try again"}
+var cycleNotDoneError = CycleStorageError{message: "scores can only be applied once the cycle is done"}
 
 func convertIdToObjectId(id string) (*primitive.ObjectID, error) {
 	objectId, err := primitive.ObjectIDFromHex(id)
@@ -47,507 +46,152 @@ func convertIdToObjectId(id string) (*primitive.ObjectID, error) {
 	return &objectId, nil
 }
 
-// UpdateCycleByID implements Storage.
-func (s *storage) UpdateByID(id string, updateCycle Cycle) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	objId, _ := primitive.ObjectIDFromHex(id)
-	filter := bson.M{"_id": objId}
-	update := bson.M{"$set": updateCycle}
-	_, err := s.db.Collection(cycleCollection).UpdateOne(ctx, filter, update, options.Update())
-	if err != nil {
-		return err
-	}
-
-	return nil
+// GetFromUserEmail lists the cycles of an ariser one page at a time.
+func (s *storage) GetFromUserEmail(ctx context.Context, email string, page PageRequest) (*Page[*NewCycle], error) {
+	return s.findPage(ctx, bson.M{"ariserMail": email}, page)
 }
 
-func (s *storage) UpdateByIDSave(id string, updateCycle CycleSave) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	objId, _ := primitive.ObjectIDFromHex(id)
-	filter := bson.M{"_id": objId}
-	update := bson.M{"$set": updateCycle}
-	_, err := s.db.Collection(cycleCollection).UpdateOne(ctx, filter, update, options.Update())
-	if err != nil {
-		return err
+// GetAllFromReceiverEmail lists the cycles a team lead reviews one page at a
+// time, only those with the given status unless it is StatusAll.
+func (s *storage) GetAllFromReceiverEmail(ctx context.Context, status string, email string, page PageRequest) (*Page[*NewCycle], error) {
+	filter := bson.M{"teamLeaderMail": email}
+	if status != StatusAll {
+		filter["status"] = status
 	}
-	return nil
+	return s.findPage(ctx, filter, page)
 }
 
-func (s *storage) GetFromUserEmail(email string) ([]*Cycle, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	filter := bson.M{"sender_mail": email}
-	var cycles []*Cycle
-	findOptions := options.Find()
-
-	cursor, err := s.db.Collection(cycleCollection).Find(ctx, filter, findOptions)
-	if err != nil {
-		return nil, err
+// findPage finds the page of cycles matching filter that comes after the
+// cursor of the request, and counts the cycles on all pages. Deleted cycles
+// are left out unless filter is on deletedAt.
+func (s *storage) findPage(ctx context.Context, filter bson.M, page PageRequest) (*Page[*NewCycle], error) {
+	if _, ok := filter["deletedAt"]; !ok {
+		filter["deletedAt"] = nil
 	}
-
-	err = cursor.All(context.Background(), &cycles)
+	after, err := page.after()
 	if err != nil {
 		return nil, err
 	}
-
-	return cycles, nil
-}
-
-// func (s *storage) GetUserAndCycle(email string) ([]*Cycle, error) {
-// 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-// 	defer cancel()
-
-// 	filter := bson.M{"sender_mail": email}
-// 	var cycles []*Cycle
-// 	findOptions := options.Find()
-// 	//res, err := s.db.Collection(cycleCollection).Find(ctx, filter, findOptions)
-// 	cursor, err := s.db.Collection(cycleCollection).Find(ctx, filter, findOptions)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	err = cursor.All(context.Background(), &cycles)
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	return cycles, nil
-// }
-
-func (s *storage) GetByID(id string) (*Cycle, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
-	objId, err := convertIdToObjectId(id)
-	if err != nil {
-		return nil, err
-	}
-	filter := bson.M{"_id": objId}
 
-	var cycle Cycle
-	err = s.db.Collection(cycleCollection).FindOne(ctx, filter).Decode(&cycle)
+	total, err := s.db.Collection(newCycleCollection).CountDocuments(ctx, filter)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return nil, cycleNotFoundError
-		}
-		return nil, err
-	}
-	return &cycle, nil
-}
-
-// Cycle strut
-// ID              primitive.ObjectID `json:"id" bson:"_id"`
-// SenderMail      string             `json:"senderMail" bson:"sender_mail"`
-// ReceiverMail    string             `json:"receiverMail" bson:"receiver_mail"`
-// StartDate       time.Time          `json:"startDate" bson:"start_date"`
-// EndDate         time.Time          `json:"endDate" bson:"end_date"`
-// QuantitiveSkill []QuantitiveSkill  `json:"quantitiveSkill" bson:"quantitive_skill"`
-// IntuitiveSkill  []IntuitiveSkill   `json:"intuitiveSkill" bson:"intuitive_skill"`
-// Status          string             `json:"status" bson:"status"`
-// Comment         string             `json:"comment" bson:"comment"`
-
-// You have got any problem with this function, contract Mik. 19.51
-func (s *storage) InsertOne(cyInput CycleInput, mail string) (*Cycle, error) {
-	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Second)
-	defer cancel()
-
-	// Covert CycleInput to Cycle
-	cycle := Cycle{SenderMail: mail, ReceiverMail: cyInput.ReceiverMail, StartDate: cyInput.StartDate, EndDate: cyInput.EndDate, QuantitativeSkill: cyInput.QuantitativeSkill, IntuitiveSkill: cyInput.IntuitiveSkill, Status: "Pending", Comment: cyInput.Comment}
-
-	for i, val := range cycle.QuantitativeSkill {
-		cycle.QuantitativeSkill[i].LeadGoalScore = val.GoalScore
-		fmt.Println(val.GoalScore, cycle.QuantitativeSkill[i].LeadGoalScore)
-		cycle.QuantitativeSkill[i].FinalScore = val.PersonalScore
-	}
-	// Call db to insert data
-	res, err := s.db.Collection(cycleCollection).InsertOne(ctx, cycle, options.InsertOne())
-	if err != nil {
-		return nil, err
-	}
-
-	// Get autogenerate ID and return nil if nothing error.
-	cycle.ID = res.InsertedID.(primitive.ObjectID)
-	return &cycle, nil
-}
-
-func (s *storage) GetAllFromReceiverEmail(status string, email string, page int) ([]*Cycle, error) {
-	var cycles []*Cycle
-	pageSize := 10
-
-	var filter primitive.M
-	if status != StatusAll {
-		filter = bson.M{"status": status, "receiver_mail": email}
-	} else {
-		filter = bson.M{"receiver_mail": email}
+		return nil, dbConnectNotFound
 	}
 
-	findOptions := options.Find()
-	findOptions.SetSkip(int64((page - 1) * pageSize))
-	findOptions.SetLimit(int64(pageSize))
-
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
-
-	res, err := s.db.Collection(cycleCollection).Find(ctx, filter, findOptions)
+	findOptions := options.Find().SetSort(page.sort()).SetLimit(int64(page.Limit + 1))
+	cursor, err := s.db.Collection(newCycleCollection).Find(ctx, bson.M{"$and": bson.A{filter, after}}, findOptions)
 	if err != nil {
-		if err == mongo.ErrNoDocuments {
-			return nil, cycleNotFoundError
-		}
 		return nil, dbConnectNotFound
 	}
-
-	err = res.All(context.Background(), &cycles)
-	if err != nil {
+	cycles := []*NewCycle{}
+	if err := cursor.All(ctx, &cycles); err != nil {
 		return nil, err
 	}
 
-	// fmt.Println("cycles: ", cycles[0])
-
-	return cycles, nil
-}
-
-func (s *storage) DeleteOne(id string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
-
-	oid, err := primitive.ObjectIDFromHex(id)
-	if err != nil {
-		return invalidRequestError
-	}
-	filter := bson.M{"_id": oid}
-
-	res, err := s.db.Collection(cycleCollection).DeleteOne(ctx, filter)
-	if err != nil {
-		return dbConnectNotFound
-	}
-	if res.DeletedCount == 0 {
-		return cycleNotFoundError
-	} else {
-		return nil
+	res := &Page[*NewCycle]{Items: cycles, Total: total}
+	if len(cycles) > page.Limit {
+		res.Items = cycles[:page.Limit]
+		res.HasMore = true
+		res.NextCursor = page.cursorAt(res.Items[page.Limit-1])
 	}
+	return res, nil
 }
 
 const skillCollection = "skills"
 
-func (s *storage) ToDisplayFormat(cy *Cycle) (CycleDisplay, error) {
-	ids := []string{}
-	for _, val := range cy.QuantitativeSkill {
-		ids = append(ids, val.ID.Hex())
-	}
-
-	skills, err := getSkillFromCycle(s.db, context.Background(), ids)
+// UpdateUserFinalScore applies the mutual scores of a done cycle to the
+// ariser's profile. A cycle is only applied once; calling it again is a no-op.
+func (s *storage) UpdateUserFinalScore(id string) error {
+	objId, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return CycleDisplay{}, err
-	}
-
-	disPlaySkills := []QuantitativeSkillDisplay{}
-	for i, val := range skills {
-		displaySkill := QuantitativeSkillDisplay{
-			ID:            cy.QuantitativeSkill[i].ID,
-			Name:          val.Name,
-			Description:   val.Description,
-			Logo:          val.Logo,
-			PersonalScore: cy.QuantitativeSkill[i].PersonalScore,
-			GoalScore:     cy.QuantitativeSkill[i].GoalScore,
-			LeadGoalScore: cy.QuantitativeSkill[i].LeadGoalScore,
-			FinalScore:    cy.QuantitativeSkill[i].FinalScore,
-		}
-		disPlaySkills = append(disPlaySkills, displaySkill)
-	}
-	var cyclesDisplay = CycleDisplay{
-		ID:                cy.ID,
-		SenderMail:        cy.SenderMail,
-		ReceiverMail:      cy.ReceiverMail,
-		StartDate:         cy.StartDate,
-		EndDate:           cy.EndDate,
-		QuantitativeSkill: disPlaySkills,
-		IntuitiveSkill:    cy.IntuitiveSkill,
-		Status:            cy.Status,
-		Comment:           cy.Comment,
-	}
-	return cyclesDisplay, nil
-}
-
-func (s *storage) ToDisplayFormatAll(cycles []*Cycle) []*CycleDisplay {
-	allId := [][]string{}
-
-	// skills := [][]skill.Skill{}
-	for i := 0; i < len(cycles); i++ {
-		ids := []string{}
-		for _, val := range cycles[i].QuantitativeSkill {
-			ids = append(ids, val.ID.Hex())
-		}
-		allId = append(allId, ids)
-		// skill, _ := sk.GetSkillFromCycle(context.Background(), ids)
-		// skills = append(skills, skill)
+		return invalidRequestError
 	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-	skills, err := getAllSkillFromCycle(s.db, context.Background(), allId)
-	if err != nil {
-		return []*CycleDisplay{}
-	}
-	cyclesAll := []*CycleDisplay{}
-
-	for i := 0; i < len(skills); i++ {
-		toDisplaySkills := []QuantitativeSkillDisplay{}
-
-		for j := 0; j < len(skills[i]); j++ {
-			displaySkill := QuantitativeSkillDisplay{
-				ID:            cycles[i].QuantitativeSkill[j].ID,
-				Name:          skills[i][j].Name,
-				Description:   skills[i][j].Description,
-				Logo:          skills[i][j].Logo,
-				PersonalScore: cycles[i].QuantitativeSkill[j].PersonalScore,
-				GoalScore:     cycles[i].QuantitativeSkill[j].GoalScore,
-				LeadGoalScore: cycles[i].QuantitativeSkill[j].LeadGoalScore,
-				FinalScore:    cycles[i].QuantitativeSkill[j].FinalScore,
+	return s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		var cycle NewCycle
+		if err := s.db.Collection(newCycleCollection).FindOne(sc, bson.M{"_id": objId}).Decode(&cycle); err != nil {
+			if err == mongo.ErrNoDocuments {
+				return cycleNotFoundError
 			}
-			toDisplaySkills = append(toDisplaySkills, displaySkill)
-		}
-		var cyclesDisplay = CycleDisplay{
-			ID:                cycles[i].ID,
-			SenderMail:        cycles[i].SenderMail,
-			ReceiverMail:      cycles[i].ReceiverMail,
-			StartDate:         cycles[i].StartDate,
-			EndDate:           cycles[i].EndDate,
-			QuantitativeSkill: toDisplaySkills,
-			IntuitiveSkill:    cycles[i].IntuitiveSkill,
-			Status:            cycles[i].Status,
-			State:             cycles[i].State,
-			Comment:           cycles[i].Comment,
-		}
-		cyclesAll = append(cyclesAll, &cyclesDisplay)
-	}
-	return cyclesAll
-}
-
-func getSkillFromCycle(db *mongo.Database, c context.Context, id []string) ([]skill.Skill, error) {
-	mapId := map[primitive.ObjectID]int{}
-	arrId := []primitive.ObjectID{}
-	for _, val := range id {
-		oid, _ := primitive.ObjectIDFromHex(val)
-		if _, found := mapId[oid]; !found {
-			arrId = append(arrId, oid)
+			return err
 		}
-	}
-	skills := []skill.Skill{}
-	filter := bson.M{"_id": bson.M{"$in": arrId}}
-	cursor, err := db.Collection(skillCollection).Find(c, filter)
-
-	if err != nil {
-		return nil, err
-	}
-
-	err = cursor.All(c, &skills)
-	if err != nil {
-		return nil, err
-	}
-
-	allSkill := []skill.Skill{}
-	for j := 0; j < len(id); j++ {
-		oid, _ := primitive.ObjectIDFromHex(id[j])
-		for _, val := range skills {
-			if val.ID == oid {
-				allSkill = append(allSkill, val)
-			}
+		if cycle.Status != StatusDone {
+			return cycleNotDoneError
 		}
-	}
-
-	return allSkill, nil
+		return s.applyScores(sc, &cycle)
+	})
 }
 
-// GetAllSkillFromCycle implements cycle.skillStorageInterface.
-func getAllSkillFromCycle(db *mongo.Database, c context.Context, id [][]string) ([][]skill.Skill, error) {
-	// fmt.Println("AAA@@@@@")
-	mapId := map[primitive.ObjectID]int{}
-	arrId := []primitive.ObjectID{}
-	for i := 0; i < len(id); i++ {
-		for _, val := range id[i] {
-			oid, _ := primitive.ObjectIDFromHex(val)
-			if _, found := mapId[oid]; !found {
-				arrId = append(arrId, oid)
-			}
-		}
-	}
-
-	skills := []skill.Skill{}
-	filter := bson.M{"_id": bson.M{"$in": arrId}}
-	cursor, err := db.Collection(skillCollection).Find(c, filter)
-
-	if err != nil {
-		return nil, err
-	}
-
-	err = cursor.All(c, &skills)
+// withTransaction runs fn in a transaction, retrying it on transient errors.
+func (s *storage) withTransaction(ctx context.Context, fn func(sc mongo.SessionContext) error) error {
+	session, err := s.db.Client().StartSession()
 	if err != nil {
-		return nil, err
-	}
-
-	allSkill := [][]skill.Skill{}
-	for i := 0; i < len(id); i++ {
-		sk := []skill.Skill{}
-		for j := 0; j < len(id[i]); j++ {
-			oid, _ := primitive.ObjectIDFromHex(id[i][j])
-			for _, val := range skills {
-				if val.ID == oid {
-					sk = append(sk, val)
-				}
-			}
-		}
-		allSkill = append(allSkill, sk)
+		return err
 	}
+	defer session.EndSession(ctx)
 
-	return allSkill, nil
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
 }
 
-func (s *storage) GetUserDetailWithEmail(cycles []*CycleDisplay) []*CycleWithUserDetail {
-	arrEmail := []string{}
-	for _, val := range cycles {
-		arrEmail = append(arrEmail, val.SenderMail)
-	}
-	toSearchEmailArr := []string{}
-	toSearchEmail := map[string]int{}
-	for _, val := range arrEmail {
-		if _, found := toSearchEmail[val]; !found {
-			toSearchEmailArr = append(toSearchEmailArr, val)
-			toSearchEmail[val] = 1
-		}
-	}
-
-	users := []user.User{}
-	filter := bson.M{"email": bson.M{"$in": toSearchEmailArr}}
-	cursor, err := s.db.Collection("users").Find(context.Background(), filter)
-
-	if err != nil {
-		panic(err)
+// applyScores writes the mutual scores of cy to the ariser's hard skills and
+// marks cy as applied. It must run inside a transaction.
+func (s *storage) applyScores(sc mongo.SessionContext, cy *NewCycle) error {
+	if cy.ScoresAppliedAt != nil {
+		return nil
 	}
 
-	err = cursor.All(context.Background(), &users)
-	if err != nil {
-		panic(err)
-	}
-	// fmt.Println(toSearchEmailArr, users)
-
-	cyclesAll := []*CycleWithUserDetail{}
-
-	for i := 0; i < len(arrEmail); i++ {
-		for _, val := range users {
-			if val.Email == arrEmail[i] {
-				cycle := &CycleWithUserDetail{
-					ID:                cycles[i].ID,
-					UID:               val.ID,
-					FirstName:         val.FirstName,
-					LastName:          val.LastName,
-					JobRole:           val.JobRole,
-					Level:             val.Level,
-					SenderMail:        cycles[i].SenderMail,
-					ReceiverMail:      cycles[i].ReceiverMail,
-					StartDate:         cycles[i].StartDate,
-					EndDate:           cycles[i].EndDate,
-					QuantitativeSkill: cycles[i].QuantitativeSkill,
-					IntuitiveSkill:    cycles[i].IntuitiveSkill,
-					Status:            cycles[i].Status,
-					State:             cycles[i].State,
-					Comment:           cycles[i].Comment,
-				}
-				cyclesAll = append(cyclesAll, cycle)
-				break
-			}
-		}
+	var ariser user.User
+	if err := s.db.Collection(userCollection).FindOne(sc, bson.M{"email": cy.AriserMail}).Decode(&ariser); err != nil {
+		return err
 	}
 
-	// fmt.Println("cycles all: ", cyclesAll[0])
-	return cyclesAll
-}
-
-func (s *storage) UpdateUserFinalScore(id string) error {
-	cycle, err := s.GetByID(id)
-	if err != nil {
+	hardSkills := ApplyMutualScores(ariser.HardSkills, cy.HardSkills)
+	update := bson.M{"$set": bson.M{"hard_skills": hardSkills}}
+	if _, err := s.db.Collection(userCollection).UpdateOne(sc, bson.M{"email": cy.AriserMail}, update); err != nil {
 		return err
 	}
 
-	response := GetFinalScoreResponse{
-		Email: cycle.SenderMail,
-	}
-	for _, skill := range cycle.QuantitativeSkill {
-		response.QuantiativeSkills = append(response.QuantiativeSkills,
-			QuantiativeSkillToUpdate{
-				ID:         skill.ID,
-				FinalScore: skill.FinalScore,
-			})
+	now := time.Now()
+	update = bson.M{"$set": bson.M{"scoresAppliedAt": now}}
+	if _, err := s.db.Collection(newCycleCollection).UpdateOne(sc, bson.M{"_id": cy.ID}, update); err != nil {
+		return err
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
-
-	filter := bson.M{"email": response.Email}
-	update := bson.M{}
+	cy.ScoresAppliedAt = &now
+	return nil
+}
 
-	var arrayFilters []interface{}
-	for i, skill := range response.QuantiativeSkills {
-		filterIdentifier := fmt.Sprintf("elem%d", i)
-		arrayFilters = append(arrayFilters, bson.M{filterIdentifier + ".skillID": skill.ID})
+// afterStatusChange keeps the profile in line with a status move: reaching
+// Done applies the scores, reopening a done cycle lets them be applied again.
+func (s *storage) afterStatusChange(sc mongo.SessionContext, before *NewCycle, after *NewCycle) error {
+	if after.Status == StatusDone {
+		return s.applyScores(sc, after)
 	}
-	if len(arrayFilters) > 0 {
-		update["$set"] = bson.M{}
-		for i, skill := range response.QuantiativeSkills {
-			filterIdentifier := fmt.Sprintf("elem%d", i)
-			update["$set"].(bson.M)["technical_skills.$["+filterIdentifier+"].score"] = skill.FinalScore
-		}
-		updateOptions := options.Update().SetArrayFilters(options.ArrayFilters{Filters: arrayFilters})
-		_, err = s.db.Collection("users").UpdateOne(ctx, filter, update, updateOptions)
-		if err != nil {
+	if before.Status == StatusDone {
+		update := bson.M{"$unset": bson.M{"scoresAppliedAt": ""}}
+		if _, err := s.db.Collection(newCycleCollection).UpdateOne(sc, bson.M{"_id": after.ID}, update); err != nil {
 			return err
 		}
+		after.ScoresAppliedAt = nil
 	}
-	for _, skill := range response.QuantiativeSkills {
-		addFilter := bson.M{"email": response.Email, "technical_skills.skillID": bson.M{"$ne": skill.ID}}
-		addToSet := bson.M{"$addToSet": bson.M{"technical_skills": bson.M{"skillID": skill.ID, "score": skill.FinalScore}}}
-		_, err = s.db.Collection("users").UpdateOne(ctx, addFilter, addToSet)
-		if err != nil {
-			return err
-		}
-	}
-
 	return nil
 }
 
 func validateCycleStatus(status string) bool {
 	switch status {
-	case StatusAll, StatusPending, StatusApproved, StatusRunning, StatusDone:
+	case StatusAll, StatusPending, StatusApproved, StatusRunning, StatusDone, StatusRejected:
 		return true
 	}
 
 	return false
 }
 
-// To improve
-func (s *storage) ToDisplayFormatAllExpermentConCurrency(cycles []*Cycle) []*CycleDisplay {
-	var wg sync.WaitGroup
-	c := make(chan *CycleDisplay, len(cycles))
-	temp := func(cy *Cycle, s *storage, c chan *CycleDisplay, wg *sync.WaitGroup) {
-		t, err := s.ToDisplayFormat(cy)
-		if err != nil {
-			//TODO: what should we do
-			slog.Error(err.Error())
-		}
-		c <- &t
-		wg.Done()
-	}
-
-	for i := 0; i < len(cycles); i++ {
-		wg.Add(1)
-		go temp(cycles[i], s, c, &wg)
-	}
-	wg.Wait()
-	cyclesAll := []*CycleDisplay{}
-	for v := range c {
-		cyclesAll = append(cyclesAll, v)
-	}
-	return cyclesAll
-}
-
 // New CyCle Storage
 
 const newCycleCollection = "new_cycles"
@@ -560,7 +204,7 @@ func (s *storage) GetNewByID(id string) (*NewCycle, error) {
 	if err != nil {
 		return nil, err
 	}
-	filter := bson.M{"_id": objId}
+	filter := bson.M{"_id": objId, "deletedAt": nil}
 
 	var cycle NewCycle
 	err = s.db.Collection(newCycleCollection).FindOne(ctx, filter).Decode(&cycle)
@@ -583,6 +227,9 @@ func toHardSkillDisplay(hs *[]HardSkill) []HardSkillDisplay {
 			SkillLevels:   val.SkillLevels,
 			PersonalScore: val.PersonalScore,
 			GoalScore:     val.GoalScore,
+			LeadScore:     val.LeadScore,
+			MutualScore:   val.MutualScore,
+			RubricVersion: val.RubricVersion,
 		}
 
 		hsd = append(hsd, *newHsd)
@@ -601,29 +248,68 @@ func (s *storage) ToNewUserDetailFormat(cy *NewCycle) (*NewCycleWithUserDetail,
 		return nil, err
 	}
 
-	cyd := &NewCycleWithUserDetail{
+	return toNewUserDetail(cy, &user), nil
+}
+
+// ToNewUserDetailFormatAll looks up the arisers of all cycles at once.
+// Cycles whose ariser has no profile are left out.
+func (s *storage) ToNewUserDetailFormatAll(cycles []*NewCycle) ([]*NewCycleWithUserDetail, error) {
+	emails := []string{}
+	seen := map[string]bool{}
+	for _, cy := range cycles {
+		if !seen[cy.AriserMail] {
+			emails = append(emails, cy.AriserMail)
+			seen[cy.AriserMail] = true
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	users := []user.User{}
+	cursor, err := s.db.Collection(userCollection).Find(ctx, bson.M{"email": bson.M{"$in": emails}})
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, err
+	}
+
+	byEmail := make(map[string]*user.User)
+	for i := range users {
+		byEmail[users[i].Email] = &users[i]
+	}
+
+	cyclesAll := []*NewCycleWithUserDetail{}
+	for _, cy := range cycles {
+		if u, found := byEmail[cy.AriserMail]; found {
+			cyclesAll = append(cyclesAll, toNewUserDetail(cy, u))
+		}
+	}
+	return cyclesAll, nil
+}
+
+func toNewUserDetail(cy *NewCycle, u *user.User) *NewCycleWithUserDetail {
+	return &NewCycleWithUserDetail{
 		ID:             cy.ID,
-		FirstName:      user.FirstName,
-		LastName:       user.LastName,
-		JobRole:        user.JobRole,
-		Level:          user.Level,
+		FirstName:      u.FirstName,
+		LastName:       u.LastName,
+		JobRole:        u.JobRole,
+		Level:          u.Level,
 		TeamLeaderMail: cy.TeamLeaderMail,
 		AriserMail:     cy.AriserMail,
 		StartDate:      cy.StartDate,
 		EndDate:        cy.EndDate,
 		Status:         cy.Status,
-		Comment:        cy.Comment,
 		HardSkills:     toHardSkillDisplay(&cy.HardSkills),
 		State:          cy.State,
 	}
-
-	return cyd, nil
 }
 
 func (s *storage) GetLatestCycleFromUserEmail(email string) (*NewCycle, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
-	filter := bson.M{"ariserMail": email, "status": "In Progress"}
+	filter := bson.M{"ariserMail": email, "status": "In Progress", "deletedAt": nil}
 	var cycles *NewCycle
 	findOptions := options.FindOne().SetSort(bson.M{"startDate": -1})
 	err := s.db.Collection(newCycleCollection).FindOne(ctx, filter, findOptions).Decode(&cycles)
@@ -650,7 +336,10 @@ func (s *storage) GetUsersHardSkillByEmail(ctx context.Context, email string) (*
 	return &userData, nil
 }
 
-func (s *storage) UpdateHardSkillsByEmail(ctx context.Context, email string, goalSkillRequest UpdateGoalSkillsRequest) (*NewCycle, error) {
+// UpdateHardSkillsByEmail sends the goals of the latest cycle of the ariser
+// for review once they follow the goal policy of the ariser's job role and
+// level. It only writes while the cycle is still at the given version.
+func (s *storage) UpdateHardSkillsByEmail(ctx context.Context, email string, version int, goalSkillRequest UpdateGoalSkillsRequest) (*NewCycle, error) {
 	userDetail, err := s.GetUsersHardSkillByEmail(ctx, email)
 	if err != nil {
 		return nil, err
@@ -659,33 +348,247 @@ func (s *storage) UpdateHardSkillsByEmail(ctx context.Context, email string, goa
 	if err != nil {
 		return nil, err
 	}
+	if cycles.Version != version {
+		return nil, cycleVersionChangedError
+	}
+	hardSkills, err := withGoals(cycles.HardSkills, goalSkillRequest.HardSkills)
+	if err != nil {
+		return nil, err
+	}
+
 	// use mapping to map data from userDetail
 	userMapping := make(map[string]user.MyHardSkill)
 	for _, v := range userDetail.HardSkills {
 		userMapping[v.Name] = v
 	}
 
-	// loop data from mapping to hardskill Request
-	for i, value := range goalSkillRequest.HardSkills {
-		goalSkillRequest.HardSkills[i].PersonalScore = userMapping[value.Name].CurrentLevel
-		if goalSkillRequest.HardSkills[i].PersonalScore+1 < goalSkillRequest.HardSkills[i].GoalScore || goalSkillRequest.HardSkills[i].PersonalScore > goalSkillRequest.HardSkills[i].GoalScore {
-			return nil, fmt.Errorf("miss match goal-score")
+	// loop data from mapping to the hard skills of the cycle
+	for i, value := range hardSkills {
+		if mine, ok := userMapping[value.Name]; ok {
+			hardSkills[i].PersonalScore = mine.CurrentLevel
 		}
 	}
 
-	cycles.HardSkills = goalSkillRequest.HardSkills
-	cycles.Status = StatusPending
-	cycles.State = StateReview
+	policy, err := s.GetGoalPolicy(ctx, userDetail.JobRole, userDetail.Level)
+	if err != nil {
+		return nil, err
+	}
+	if err := policy.checkGoals(hardSkills); err != nil {
+		return nil, err
+	}
 
-	filter := bson.M{"_id": primitive.ObjectID(cycles.ID)}
+	state, err := Transition(cycles.Status, StatusPending, ActorAriser)
+	if err != nil {
+		return nil, err
+	}
 
-	update := bson.M{"$set": cycles}
+	before := *cycles
+	now := time.Now()
+	cycles.HardSkills = hardSkills
+	cycles.Status = StatusPending
+	cycles.State = state
+	cycles.SubmittedAt = &now
+	cycles.Version++
 
-	_, err = s.db.Collection(newCycleCollection).UpdateOne(ctx, filter, update, options.Update())
+	filter := bson.M{"_id": primitive.ObjectID(cycles.ID), "version": database.VersionFilter(version)}
 
+	update := bson.M{
+		"$set": bson.M{
+			"hardSkills":  cycles.HardSkills,
+			"status":      cycles.Status,
+			"state":       cycles.State,
+			"submittedAt": cycles.SubmittedAt,
+		},
+		"$inc": bson.M{"version": 1},
+	}
+
+	err = s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		res, err := s.db.Collection(newCycleCollection).UpdateOne(sc, filter, update, options.Update())
+		if err != nil {
+			return err
+		}
+		if res.MatchedCount == 0 {
+			return cycleVersionChangedError
+		}
+		return s.recordEvent(sc, EventGoalsUpdated, email, &before, cycles)
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	return cycles, nil
 }
+
+// UpdateNewStatusByID moves a new cycle to another status. The update only
+// matches while the cycle is still at the version it was read with, so two
+// concurrent moves can't both win.
+func (s *storage) UpdateNewStatusByID(ctx context.Context, id string, version int, status string, state string, email string) (*NewCycle, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, invalidRequestError
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	set := bson.M{"status": status, "state": state}
+	if status == StatusPending {
+		set["submittedAt"] = now
+	}
+	filter := bson.M{"_id": objId, "version": database.VersionFilter(version)}
+	update := bson.M{"$set": set, "$inc": bson.M{"version": 1}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var cycle NewCycle
+	err = s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		var before NewCycle
+		err := s.db.Collection(newCycleCollection).FindOneAndUpdate(sc, filter, update, opts).Decode(&before)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				return cycleVersionChangedError
+			}
+			return err
+		}
+
+		cycle = before
+		cycle.Status = status
+		cycle.State = state
+		cycle.Version++
+		if status == StatusPending {
+			cycle.SubmittedAt = &now
+		}
+		if err := s.afterStatusChange(sc, &before, &cycle); err != nil {
+			return err
+		}
+		return s.recordEvent(sc, EventStatusChanged, email, &before, &cycle)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &cycle, nil
+}
+
+// UpdateScoresByID writes the scores of the hard skills of a cycle that is
+// still at the given version.
+func (s *storage) UpdateScoresByID(ctx context.Context, id string, version int, hardSkills []HardSkill, email string) (*NewCycle, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, invalidRequestError
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": objId, "version": database.VersionFilter(version)}
+	update := bson.M{"$set": bson.M{"hardSkills": hardSkills}, "$inc": bson.M{"version": 1}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	var cycle NewCycle
+	err = s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		var before NewCycle
+		err := s.db.Collection(newCycleCollection).FindOneAndUpdate(sc, filter, update, opts).Decode(&before)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				return cycleVersionChangedError
+			}
+			return err
+		}
+
+		cycle = before
+		cycle.HardSkills = hardSkills
+		cycle.Version++
+		return s.recordEvent(sc, EventScoresChanged, email, &before, &cycle)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &cycle, nil
+}
+
+// UpdateNewByID replaces the editable fields of a new cycle, moving it from
+// the status it was read with. Like UpdateNewStatusByID it only matches while
+// the cycle is still at the given version.
+func (s *storage) UpdateNewByID(ctx context.Context, id string, from string, version int, cy NewCycle, email string) (*NewCycle, error) {
+	objId, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, invalidRequestError
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	set := bson.M{
+		"startDate":  cy.StartDate,
+		"endDate":    cy.EndDate,
+		"hardSkills": cy.HardSkills,
+		"status":     cy.Status,
+		"state":      cy.State,
+	}
+	if cy.Status == StatusPending && from != StatusPending {
+		now := time.Now()
+		cy.SubmittedAt = &now
+		set["submittedAt"] = now
+	}
+	filter := bson.M{"_id": objId, "status": from, "version": database.VersionFilter(version)}
+	update := bson.M{"$set": set, "$inc": bson.M{"version": 1}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+
+	err = s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		var before NewCycle
+		err := s.db.Collection(newCycleCollection).FindOneAndUpdate(sc, filter, update, opts).Decode(&before)
+		if err != nil {
+			if err == mongo.ErrNoDocuments {
+				return cycleVersionChangedError
+			}
+			return err
+		}
+
+		cy.ID = before.ID
+		cy.TeamLeaderMail = before.TeamLeaderMail
+		cy.AriserMail = before.AriserMail
+		cy.ScoresAppliedAt = before.ScoresAppliedAt
+		cy.Overdue = before.Overdue
+		cy.Version = before.Version + 1
+		if cy.SubmittedAt == nil {
+			cy.SubmittedAt = before.SubmittedAt
+		}
+		eventType := EventUpdated
+		if before.Status != cy.Status {
+			eventType = EventStatusChanged
+			if err := s.afterStatusChange(sc, &before, &cy); err != nil {
+				return err
+			}
+		}
+		return s.recordEvent(sc, eventType, email, &before, &cy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &cy, nil
+}
+
+func (s *storage) InsertNew(ctx context.Context, cy NewCycle, email string) (*NewCycle, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// Only the ariser submits goals for review; a cycle a team lead opens
+	// for them waits on the ariser, not on the review.
+	if cy.Status == StatusPending && cy.SubmittedAt == nil && email == cy.AriserMail {
+		now := time.Now()
+		cy.SubmittedAt = &now
+	}
+	err := s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		res, err := s.db.Collection(newCycleCollection).InsertOne(sc, cy, options.InsertOne())
+		if err != nil {
+			return err
+		}
+		cy.ID = res.InsertedID.(primitive.ObjectID)
+		return s.recordEvent(sc, EventCreated, email, &NewCycle{}, &cy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &cy, nil
+}
@@ -10,14 +10,15 @@ import (
 )
 
 type mockCycleStorage struct {
-	cycles       []*Cycle
-	cycle        *Cycle
-	cyclesReturn []*Cycle
-	// newCycle     *NewCycle
-	newCycles []*NewCycle
+	cyclesReturn []*NewCycle
+	newCycles    []*NewCycle
+	events       []CycleEvent
+	page         PageRequest
+	policy       *GoalPolicy
 
 	methodsToCall map[string]bool
 	err           error
+	finalScoreErr error
 }
 
 type mockingObjectId struct {
@@ -32,55 +33,22 @@ func mockObjectId(id int) mockingObjectId {
 }
 
 // GetAllFromEmail implements Storage.
-func (ms *mockCycleStorage) GetAllFromReceiverEmail(status string, email string, page int) ([]*Cycle, error) {
+func (ms *mockCycleStorage) GetAllFromReceiverEmail(ctx context.Context, status string, email string, page PageRequest) (*Page[*NewCycle], error) {
 	ms.methodsToCall["GetAllFromEmail"] = true
+	ms.page = page
 	if ms.err != nil {
 		return nil, ms.err
 	}
 
-	return ms.cyclesReturn, nil
+	return &Page[*NewCycle]{Items: ms.cyclesReturn, Total: int64(len(ms.cyclesReturn))}, nil
 }
 
-// GetAll implements Storage.
-func (m *mockCycleStorage) GetAll() ([]*Cycle, error) {
+func (m *mockCycleStorage) UpdateNewByID(ctx context.Context, id string, from string, version int, cy NewCycle, email string) (*NewCycle, error) {
+	m.methodsToCall["UpdateNewByID"] = true
 	if m.err != nil {
 		return nil, m.err
 	}
-
-	return m.cycles, nil
-}
-
-func (m *mockCycleStorage) DeleteOne(id string) error {
-	m.methodsToCall["DeleteByID"] = true
-	return m.err
-}
-
-// InsertOne implements Storage.
-func (m *mockCycleStorage) InsertOne(cy CycleInput, mail string) (*Cycle, error) {
-	if m.err != nil {
-		return nil, m.err
-	}
-	m.cycle.IntuitiveSkill = []IntuitiveSkill{}
-	return m.cycle, nil
-}
-
-// UpdateCycleByID implements Storage.
-func (m *mockCycleStorage) UpdateByID(id string, updateCycle Cycle) error {
-	m.methodsToCall["UpdateByID"] = true
-	return m.err
-}
-
-func (m *mockCycleStorage) UpdateByIDSave(id string, updateCycle CycleSave) error {
-	m.methodsToCall["UpdateByIDSave"] = true
-	return m.err
-}
-
-func (ms *mockCycleStorage) GetByID(id string) (*Cycle, error) {
-	ms.methodsToCall["GetByID"] = true
-	if ms.err != nil {
-		return nil, ms.err
-	}
-	return ms.cycles[0], nil
+	return &cy, nil
 }
 
 func (ms *mockCycleStorage) ExpectToCall(methodName string) {
@@ -98,84 +66,28 @@ func (ms *mockCycleStorage) Verify(t *testing.T) {
 	}
 }
 
-func (ms *mockCycleStorage) ToDisplayFormat(cy *Cycle) (CycleDisplay, error) {
-	cycleDisplayMock := CycleDisplay{
-		ID:                cy.ID,
-		SenderMail:        cy.SenderMail,
-		ReceiverMail:      cy.ReceiverMail,
-		StartDate:         cy.StartDate,
-		EndDate:           cy.EndDate,
-		QuantitativeSkill: []QuantitativeSkillDisplay{},
-		IntuitiveSkill:    cy.IntuitiveSkill,
-		Status:            cy.Status,
-		Comment:           cy.Comment,
-	}
-	return cycleDisplayMock, nil
-}
-
-func (ms *mockCycleStorage) ToDisplayFormatAll(cycles []*Cycle) []*CycleDisplay {
-	cyclesDisplayMock := []*CycleDisplay{}
-	for _, val := range cycles {
-		cycleDisplayMock := CycleDisplay{
-			ID:                val.ID,
-			SenderMail:        val.SenderMail,
-			ReceiverMail:      val.ReceiverMail,
-			StartDate:         val.StartDate,
-			EndDate:           val.EndDate,
-			QuantitativeSkill: []QuantitativeSkillDisplay{},
-			IntuitiveSkill:    val.IntuitiveSkill,
-			Status:            val.Status,
-			Comment:           val.Comment,
-		}
-		cyclesDisplayMock = append(cyclesDisplayMock, &cycleDisplayMock)
-	}
-	return cyclesDisplayMock
-}
-
-func (ms *mockCycleStorage) ToDisplayFormatAllExpermentConCurrency(cycles []*Cycle) []*CycleDisplay {
-	return nil
-}
-
-func (ms *mockCycleStorage) GetFromUserEmail(email string) ([]*Cycle, error) {
+func (ms *mockCycleStorage) GetFromUserEmail(ctx context.Context, email string, page PageRequest) (*Page[*NewCycle], error) {
 	ms.methodsToCall["GetFromUserEmail"] = true
+	ms.page = page
 	if ms.err != nil {
 		return nil, ms.err
 	}
 
-	return ms.cycles, nil
-}
-
-func (ms *mockCycleStorage) GetUserDetailWithEmail(cycles []*CycleDisplay) []*CycleWithUserDetail {
-	if ms.cyclesReturn != nil {
-		cyclesAll := []*CycleWithUserDetail{}
-		for i := 0; i < len(cycles); i++ {
-			cycle := &CycleWithUserDetail{
-				ID:                cycles[i].ID,
-				UID:               primitive.NewObjectID().Hex(),
-				FirstName:         "Ariser",
-				LastName:          "by krungthai",
-				JobRole:           "full-stack",
-				Level:             "junior",
-				SenderMail:        cycles[i].SenderMail,
-				ReceiverMail:      cycles[i].ReceiverMail,
-				StartDate:         cycles[i].StartDate,
-				EndDate:           cycles[i].EndDate,
-				QuantitativeSkill: cycles[i].QuantitativeSkill,
-				IntuitiveSkill:    cycles[i].IntuitiveSkill,
-				Status:            cycles[i].Status,
-				Comment:           cycles[i].Comment,
-			}
-			cyclesAll = append(cyclesAll, cycle)
-		}
+	return &Page[*NewCycle]{Items: ms.newCycles, Total: int64(len(ms.newCycles))}, nil
+}
 
-		return cyclesAll
+func (ms *mockCycleStorage) ToNewUserDetailFormatAll(cycles []*NewCycle) ([]*NewCycleWithUserDetail, error) {
+	cyclesAll := []*NewCycleWithUserDetail{}
+	for _, cy := range cycles {
+		detail, _ := ms.ToNewUserDetailFormat(cy)
+		cyclesAll = append(cyclesAll, detail)
 	}
-	return nil
+	return cyclesAll, nil
 }
 
 func (ms *mockCycleStorage) UpdateUserFinalScore(id string) error {
 	ms.methodsToCall["UpdateUserFinalScore"] = true
-	return ms.err
+	return ms.finalScoreErr
 }
 
 func (ms *mockCycleStorage) GetNewByID(id string) (*NewCycle, error) {
@@ -199,12 +111,30 @@ func (ms *mockCycleStorage) ToNewUserDetailFormat(cy *NewCycle) (*NewCycleWithUs
 		EndDate:        cy.EndDate,
 		HardSkills:     []HardSkillDisplay{},
 		Status:         cy.Status,
-		Comment:        cy.Comment,
 	}
 	return cycle, nil
 }
 
-func (ms *mockCycleStorage) UpdateHardSkillsByEmail(ctx context.Context, email string, goalSkillRequest UpdateGoalSkillsRequest) (*NewCycle, error) {
+func (ms *mockCycleStorage) GetEventsByCycleID(ctx context.Context, id string) ([]CycleEvent, error) {
+	ms.methodsToCall["GetEventsByCycleID"] = true
+	if ms.err != nil {
+		return nil, ms.err
+	}
+	return ms.events, nil
+}
+
+func (ms *mockCycleStorage) GetUsersHardSkillByEmail(ctx context.Context, email string) (*user.User, error) {
+	return &user.User{Email: email, JobRole: "frontend", Level: "Junior"}, nil
+}
+
+func (ms *mockCycleStorage) GetGoalPolicy(ctx context.Context, jobRole string, level string) (*GoalPolicy, error) {
+	if ms.policy != nil {
+		return ms.policy, nil
+	}
+	return DefaultGoalPolicy(jobRole, level), nil
+}
+
+func (ms *mockCycleStorage) UpdateHardSkillsByEmail(ctx context.Context, email string, version int, goalSkillRequest UpdateGoalSkillsRequest) (*NewCycle, error) {
 	panic("not Implement")
 }
 
@@ -212,6 +142,20 @@ func (ms *mockCycleStorage) GetLatestCycleFromUserEmail(email string) (*NewCycle
 	panic("not Implement")
 }
 
+func (ms *mockCycleStorage) UpdateNewStatusByID(ctx context.Context, id string, version int, status string, state string, email string) (*NewCycle, error) {
+	ms.methodsToCall["UpdateNewStatusByID"] = true
+	if ms.err != nil {
+		return nil, ms.err
+	}
+	if ms.newCycles[0].Version != version {
+		return nil, cycleVersionChangedError
+	}
+	ms.newCycles[0].Status = status
+	ms.newCycles[0].State = state
+	ms.newCycles[0].Version++
+	return ms.newCycles[0], nil
+}
+
 // TODO : Uncomment to Test add hardSkills a Cycle to project
 // new cycle
 type mockNewCycleStorage struct {
@@ -220,44 +164,34 @@ type mockNewCycleStorage struct {
 	err      error
 }
 
-func (ms *mockNewCycleStorage) UpdateByID(id string, updateCycle Cycle) error {
-	panic("not Implement")
-}
-func (ms *mockNewCycleStorage) UpdateByIDSave(id string, updateCycle CycleSave) error {
-	panic("not Implement")
-}
-func (ms *mockNewCycleStorage) InsertOne(cy CycleInput, mail string) (*Cycle, error) {
-	panic("not Implement")
-}
-func (ms *mockNewCycleStorage) GetAllFromReceiverEmail(status string, email string, page int) ([]*Cycle, error) {
+func (ms *mockNewCycleStorage) GetAllFromReceiverEmail(ctx context.Context, status string, email string, page PageRequest) (*Page[*NewCycle], error) {
 	panic("not Implement")
 }
 func (ms *mockNewCycleStorage) UpdateUserFinalScore(id string) error {
 	panic("not Implement")
 }
-func (ms *mockNewCycleStorage) GetFromUserEmail(email string) ([]*Cycle, error) {
+func (ms *mockNewCycleStorage) GetFromUserEmail(ctx context.Context, email string, page PageRequest) (*Page[*NewCycle], error) {
 	panic("not Implement")
 }
-func (ms *mockNewCycleStorage) GetByID(id string) (*Cycle, error) {
+func (ms *mockNewCycleStorage) ToNewUserDetailFormatAll(cycles []*NewCycle) ([]*NewCycleWithUserDetail, error) {
 	panic("not Implement")
 }
-func (ms *mockNewCycleStorage) DeleteOne(id string) error {
+func (ms *mockNewCycleStorage) UpdateNewByID(ctx context.Context, id string, from string, version int, cy NewCycle, email string) (*NewCycle, error) {
 	panic("not Implement")
 }
-func (ms *mockNewCycleStorage) ToDisplayFormat(cy *Cycle) (CycleDisplay, error) {
-	panic("not Implement")
-}
-func (ms *mockNewCycleStorage) ToDisplayFormatAll(cycles []*Cycle) []*CycleDisplay {
-	panic("not Implement")
-}
-func (ms *mockNewCycleStorage) GetUserDetailWithEmail(cycles []*CycleDisplay) []*CycleWithUserDetail {
-	panic("not Implement")
-}
-func (ms *mockNewCycleStorage) UpdateHardSkillsByEmail(ctx context.Context, email string, goalSkillRequest UpdateGoalSkillsRequest) (*NewCycle, error) {
+func (ms *mockNewCycleStorage) UpdateHardSkillsByEmail(ctx context.Context, email string, version int, goalSkillRequest UpdateGoalSkillsRequest) (*NewCycle, error) {
 	if ms.err != nil {
 		return nil, ms.err
 	}
-	ms.newCycle.HardSkills = goalSkillRequest.HardSkills
+	if ms.newCycle.Version != version {
+		return nil, cycleVersionChangedError
+	}
+	hardSkills, err := withGoals(ms.newCycle.HardSkills, goalSkillRequest.HardSkills)
+	if err != nil {
+		return nil, err
+	}
+	ms.newCycle.HardSkills = hardSkills
+	ms.newCycle.Version++
 	return ms.newCycle, nil
 }
 
@@ -275,6 +209,10 @@ func (ms *mockNewCycleStorage) GetUsersHardSkillByEmail(ctx context.Context, ema
 	return &ms.user, nil
 }
 
+func (ms *mockNewCycleStorage) GetGoalPolicy(ctx context.Context, jobRole string, level string) (*GoalPolicy, error) {
+	return DefaultGoalPolicy(jobRole, level), nil
+}
+
 func (ms *mockNewCycleStorage) GetNewByID(id string) (*NewCycle, error) {
 	panic("not Implement")
 }
@@ -282,3 +220,11 @@ func (ms *mockNewCycleStorage) GetNewByID(id string) (*NewCycle, error) {
 func (ms *mockNewCycleStorage) ToNewUserDetailFormat(cy *NewCycle) (*NewCycleWithUserDetail, error) {
 	panic("not Implement")
 }
+
+func (ms *mockNewCycleStorage) UpdateNewStatusByID(ctx context.Context, id string, version int, status string, state string, email string) (*NewCycle, error) {
+	panic("not Implement")
+}
+
+func (ms *mockNewCycleStorage) GetEventsByCycleID(ctx context.Context, id string) ([]CycleEvent, error) {
+	panic("not Implement")
+}
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ETag is the entity tag of a document at the given version.
+func ETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// IfMatch reads the version the client last saw from the If-Match header.
+// When the header is missing or is not an ETag given out by ETag it answers
+// 428 and reports false.
+func IfMatch(c Context) (int, bool) {
+	tag := strings.TrimPrefix(strings.TrimSpace(c.GetHeader("If-Match")), "W/")
+	version, err := strconv.Atoi(strings.Trim(tag, `"`))
+	if err != nil || version < 0 {
+		c.JSON(http.StatusPreconditionRequired, Response{
+			Status:  Fail,
+			Message: "If-Match header with the ETag of the current version is required",
+		})
+		return 0, false
+	}
+	return version, true
+}
@@ -29,12 +29,20 @@ type Context interface {
 	StoreError(err error)
 	InternalServerError(err error)
 	NotFound(err error)
+	Forbidden(err error)
+	Conflict(err error)
+	PreconditionFailed(err error)
+	UnprocessableEntity(v any, err error)
 	JSON(code int, v any)
+	Data(code int, contentType string, data []byte)
 	Ctx() gcontext.Context
 	GetString(key string) string
 	ShouldBindJSON(v any) error
 	Param(key string) string
 	Query(key string) string
+	GetHeader(key string) string
+	Header(key string, value string)
+	GetRawData() ([]byte, error)
 }
 
 func NewContext(c *gin.Context, logger *zap.Logger) Context {
@@ -109,10 +117,47 @@ func (c *context) NotFound(err error) {
 	})
 }
 
+func (c *context) Forbidden(err error) {
+	c.logger.Error(err.Error())
+	c.Context.JSON(http.StatusForbidden, Response{
+		Status:  Fail,
+		Message: err.Error(),
+	})
+}
+
+func (c *context) Conflict(err error) {
+	c.logger.Error(err.Error())
+	c.Context.JSON(http.StatusConflict, Response{
+		Status:  Fail,
+		Message: err.Error(),
+	})
+}
+
+func (c *context) PreconditionFailed(err error) {
+	c.logger.Error(err.Error())
+	c.Context.JSON(http.StatusPreconditionFailed, Response{
+		Status:  Fail,
+		Message: err.Error(),
+	})
+}
+
+func (c *context) UnprocessableEntity(v any, err error) {
+	c.logger.Error(err.Error())
+	c.Context.JSON(http.StatusUnprocessableEntity, Response{
+		Status:  Fail,
+		Message: err.Error(),
+		Data:    v,
+	})
+}
+
 func (c *context) JSON(code int, v any) {
 	c.Context.JSON(code, v)
 }
 
+func (c *context) Data(code int, contentType string, data []byte) {
+	c.Context.Data(code, contentType, data)
+}
+
 func (c *context) ShouldBindJSON(v any) error {
 	return c.Context.ShouldBindJSON(v)
 }
@@ -133,6 +178,18 @@ func (c *context) Query(key string) string {
 	return c.Context.Query(key)
 }
 
+func (c *context) GetHeader(key string) string {
+	return c.Context.GetHeader(key)
+}
+
+func (c *context) Header(key string, value string) {
+	c.Context.Header(key, value)
+}
+
+func (c *context) GetRawData() ([]byte, error) {
+	return c.Context.GetRawData()
+}
+
 func NewGinHandler(handler func(Context), logger *zap.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		handler(NewContext(c, logger.With(zap.String("transaction-id", c.Request.Header.Get("transaction-id")))))
@@ -156,7 +213,8 @@ func NewRouter(logger *zap.Logger) *Router {
 	config := cors.Config{
 		AllowAllOrigins:  true,
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
-		AllowHeaders:     []string{"X-Requested-With", "Authorization", "Origin", "Content-Length", "Content-Type", "TransactionID"},
+		AllowHeaders:     []string{"X-Requested-With", "Authorization", "Origin", "Content-Length", "Content-Type", "TransactionID", "If-Match"},
+		ExposeHeaders:    []string{"ETag", "Content-Disposition"},
 		AllowCredentials: false,
 		MaxAge:           12 * time.Hour,
 	}
@@ -0,0 +1,39 @@
+package notification
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	KindLeadHandover = "lead_handover"
+	KindPeerFeedback = "peer_feedback"
+)
+
+// Notification is a message shown to one user in the app until they read it.
+type Notification struct {
+	ID        primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	Recipient string              `json:"recipient" bson:"recipient"`
+	Kind      string              `json:"kind" bson:"kind"`
+	Message   string              `json:"message" bson:"message"`
+	CycleID   *primitive.ObjectID `json:"cycleId,omitempty" bson:"cycleId,omitempty"`
+	CreatedAt time.Time           `json:"createdAt" bson:"createdAt"`
+	ReadAt    *time.Time          `json:"readAt,omitempty" bson:"readAt,omitempty"`
+}
+
+type NotificationHandlerError struct {
+	message string
+}
+
+func (e NotificationHandlerError) Error() string {
+	return e.message
+}
+
+type NotificationStorageError struct {
+	message string
+}
+
+func (e NotificationStorageError) Error() string {
+	return e.message
+}
@@ -0,0 +1,81 @@
+package notification
+
+import (
+	"context"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Storage interface {
+	GetByRecipient(ctx context.Context, email string, unreadOnly bool) ([]Notification, error)
+	MarkRead(ctx context.Context, id primitive.ObjectID, email string) (*Notification, error)
+}
+
+type notificationHandler struct {
+	storage Storage
+}
+
+func NewNotificationHandler(st Storage) *notificationHandler {
+	return &notificationHandler{
+		storage: st,
+	}
+}
+
+var invalidNotificationIdError = NotificationHandlerError{message: "invalid notification id"}
+
+// GetMine godoc
+//
+//	@summary		GetMine
+//	@description	List the notifications of the user, newest first
+//	@tags			notification
+//	@id				GetMyNotifications
+//	@security		BearerAuth
+//	@produce		json
+//	@param			unread	query		bool						false	"Only unread notifications"
+//	@response		200		{array}		notification.Notification	"OK"
+//	@response		450		{object}	app.Response				"Store Error"
+//	@router			/notifications [get]
+func (h *notificationHandler) GetMine(c app.Context) {
+	notifications, err := h.storage.GetByRecipient(c.Ctx(), c.GetString("email"), c.Query("unread") == "true")
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(notifications)
+}
+
+// MarkRead godoc
+//
+//	@summary		MarkRead
+//	@description	Mark a notification of the user as read
+//	@tags			notification
+//	@id				MarkNotificationRead
+//	@security		BearerAuth
+//	@produce		json
+//	@param			id	path		string						true	"Notification ID"
+//	@response		200	{object}	notification.Notification	"OK"
+//	@response		400	{object}	app.Response				"Bad Request"
+//	@response		404	{object}	app.Response				"Notification not found"
+//	@response		450	{object}	app.Response				"Store Error"
+//	@router			/notifications/{id}/read [put]
+func (h *notificationHandler) MarkRead(c app.Context) {
+	id, err := primitive.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		c.BadRequest(invalidNotificationIdError)
+		return
+	}
+
+	n, err := h.storage.MarkRead(c.Ctx(), id, c.GetString("email"))
+	if err != nil {
+		if err == notificationNotFoundError {
+			c.NotFound(err)
+			return
+		}
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(n)
+}
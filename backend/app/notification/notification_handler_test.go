@@ -0,0 +1,176 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+type mockStorage struct {
+	notifications []Notification
+	unreadOnly    bool
+	err           error
+}
+
+func (m *mockStorage) GetByRecipient(ctx context.Context, email string, unreadOnly bool) ([]Notification, error) {
+	m.unreadOnly = unreadOnly
+	if m.err != nil {
+		return nil, m.err
+	}
+	mine := []Notification{}
+	for _, n := range m.notifications {
+		if n.Recipient == email {
+			mine = append(mine, n)
+		}
+	}
+	return mine, nil
+}
+
+func (m *mockStorage) MarkRead(ctx context.Context, id primitive.ObjectID, email string) (*Notification, error) {
+	for i := range m.notifications {
+		n := &m.notifications[i]
+		if n.ID == id && n.Recipient == email {
+			readAt := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+			n.ReadAt = &readAt
+			return n, nil
+		}
+	}
+	return nil, notificationNotFoundError
+}
+
+func notificationFixtures() []Notification {
+	id, _ := primitive.ObjectIDFromHex("000000000000000000000001")
+	other, _ := primitive.ObjectIDFromHex("000000000000000000000002")
+	return []Notification{
+		{
+			ID:        id,
+			Recipient: "ariser@arise.tech",
+			Kind:      KindLeadHandover,
+			Message:   "Your cycle is now reviewed by new@arise.tech instead of old@arise.tech",
+			CreatedAt: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:        other,
+			Recipient: "someone@arise.tech",
+			Kind:      KindLeadHandover,
+			CreatedAt: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func TestGetMine(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name               string
+		query              string
+		err                error
+		expectedStatus     int
+		expectedUnreadOnly bool
+		expectedResponse   string
+	}{
+		{
+			name:           "should return 200 and notifications of the user",
+			expectedStatus: http.StatusOK,
+			expectedResponse: `{
+				"status": "success",
+				"message": "",
+				"data": [{
+					"id": "000000000000000000000001",
+					"recipient": "ariser@arise.tech",
+					"kind": "lead_handover",
+					"message": "Your cycle is now reviewed by new@arise.tech instead of old@arise.tech",
+					"createdAt": "2024-01-31T00:00:00Z"
+				}]
+			}`,
+		},
+		{
+			name:               "should return 200 and only unread notifications",
+			query:              "?unread=true",
+			expectedStatus:     http.StatusOK,
+			expectedUnreadOnly: true,
+		},
+		{
+			name:           "should return 450 when storage is failed",
+			err:            errors.New("find error"),
+			expectedStatus: 450,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockStorage{notifications: notificationFixtures(), err: tc.err}
+			handler := NewNotificationHandler(st)
+
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", "ariser@arise.tech")
+			})
+			engine.GET("/notifications", app.NewGinHandler(handler.GetMine, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/notifications"+tc.query, nil)
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.Equal(t, tc.expectedUnreadOnly, st.unreadOnly)
+			if tc.expectedResponse != "" {
+				assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestMarkRead(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		id             string
+		expectedStatus int
+	}{
+		{
+			name:           "should return 200 when notification is the user's",
+			id:             "000000000000000000000001",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "should return 404 when notification is someone else's",
+			id:             "000000000000000000000002",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "should return 400 when id is invalid",
+			id:             "not-valid",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockStorage{notifications: notificationFixtures()}
+			handler := NewNotificationHandler(st)
+
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Set("email", "ariser@arise.tech")
+			})
+			engine.PUT("/notifications/:id/read", app.NewGinHandler(handler.MarkRead, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/notifications/"+tc.id+"/read", nil)
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+		})
+	}
+}
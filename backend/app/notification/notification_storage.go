@@ -0,0 +1,87 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const notificationCollection = "notifications"
+
+var notificationNotFoundError = NotificationStorageError{message: "notification not found"}
+
+type storage struct {
+	db *mongo.Database
+}
+
+func NewStorage(db *mongo.Database) *storage {
+	return &storage{
+		db: db,
+	}
+}
+
+// Notify stores the notifications, stamping those without a time with now.
+func (s *storage) Notify(ctx context.Context, notifications []Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	docs := make([]any, len(notifications))
+	for i, n := range notifications {
+		if n.CreatedAt.IsZero() {
+			n.CreatedAt = now
+		}
+		docs[i] = n
+	}
+	_, err := s.db.Collection(notificationCollection).InsertMany(ctx, docs)
+	return err
+}
+
+// GetByRecipient lists the notifications of a user, newest first.
+func (s *storage) GetByRecipient(ctx context.Context, email string, unreadOnly bool) ([]Notification, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"recipient": email}
+	if unreadOnly {
+		filter["readAt"] = bson.M{"$exists": false}
+	}
+	findOptions := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}})
+	cursor, err := s.db.Collection(notificationCollection).Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := []Notification{}
+	if err := cursor.All(ctx, &notifications); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// MarkRead marks a notification of the user as read. Reading it again keeps
+// the first time.
+func (s *storage) MarkRead(ctx context.Context, id primitive.ObjectID, email string) (*Notification, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": id, "recipient": email}
+	update := bson.A{bson.M{"$set": bson.M{"readAt": bson.M{"$ifNull": bson.A{"$readAt", time.Now()}}}}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var n Notification
+	if err := s.db.Collection(notificationCollection).FindOneAndUpdate(ctx, filter, update, opts).Decode(&n); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, notificationNotFoundError
+		}
+		return nil, err
+	}
+	return &n, nil
+}
@@ -12,7 +12,7 @@ import (
 
 type SquadStorage interface {
 	GetOneByID(ctx context.Context, id string) (*squad.Squad, error)
-	UpdateByID(ctx context.Context, id string, squad *squad.Squad) (*squad.Squad, error)
+	UpdateByID(ctx context.Context, id string, version int, squad *squad.Squad) (*squad.Squad, error)
 }
 
 type squadHandler struct {
@@ -52,6 +52,7 @@ func (h squadHandler) GetUserSkillRatingBySquadID(c app.Context) {
 		return
 	}
 
+	c.Header("ETag", app.ETag(squadInfo.Version))
 	data := mySkillRateInSquadResponse{}
 	data.ID = squadInfo.Id
 	data.Name = squadInfo.Name
@@ -77,17 +78,20 @@ func (h squadHandler) GetUserSkillRatingBySquadID(c app.Context) {
 // RateSkills godoc
 //
 //	@summary		RateSkills
-//	@description	Rate every skills
+//	@description	Rate every skills. If-Match must hold the ETag of the squad as last read, so ratings another member made in the meantime are not lost.
 //	@tags			profile
 //	@id				RateSkills
 //	@security		BearerAuth
 //	@accept			json
 //	@produce		json
 //	@param			squadID		path		string			true	"Squad ID"
+//	@param			If-Match	header		string			true	"ETag of the squad"
 //	@param			rateSkill	body		RateSkill		true	"Rate Skill"
 //	@response		200			{object}	squad.Squad		"OK"
 //	@response		400			{object}	app.Response	"Bad Request"
 //	@response		401			{object}	app.Response	"Unauthorized"
+//	@response		412			{object}	app.Response	"Squad was changed since it was read"
+//	@response		428			{object}	app.Response	"If-Match is missing"
 //	@response		500			{object}	app.Response	"Internal Server Error"
 //	@router			/profile/squad/{squadID}/skill-ratings [post]
 func (h *squadHandler) RateSkills(c app.Context) {
@@ -111,14 +115,28 @@ func (h *squadHandler) RateSkills(c app.Context) {
 		return
 	}
 
+	version, ok := app.IfMatch(c)
+	if !ok {
+		return
+	}
+	if curSq.Version != version {
+		c.PreconditionFailed(squadVersionChangedError)
+		return
+	}
+
 	*curSq = updateSquadSkillsRatings(uId, rateSkill, *curSq)
 
-	result, err := h.storage.UpdateByID(c.Ctx(), squadId, curSq)
+	result, err := h.storage.UpdateByID(c.Ctx(), squadId, version, curSq)
 	if err != nil {
+		if err == squadVersionChangedError {
+			c.PreconditionFailed(err)
+			return
+		}
 		c.InternalServerError(err)
 		return
 	}
 
+	c.Header("ETag", app.ETag(result.Version))
 	c.OK(result)
 }
 
@@ -43,7 +43,7 @@ func (ms *mockSquadStorage) GetOneByID(ctx context.Context, id string) (*squad.S
 	return nil, mongo.ErrNoDocuments
 }
 
-func (ms *mockSquadStorage) UpdateByID(ctx context.Context, id string, updateSquad *squad.Squad) (*squad.Squad, error) {
+func (ms *mockSquadStorage) UpdateByID(ctx context.Context, id string, version int, updateSquad *squad.Squad) (*squad.Squad, error) {
 	var sq squad.Squad
 	if ms.err != nil {
 		return nil, ms.err
@@ -64,6 +64,7 @@ func (ms *mockSquadStorage) UpdateByID(ctx context.Context, id string, updateSqu
 				},
 			},
 		},
+		Version: version + 1,
 	}
 	sq.Id, _ = primitive.ObjectIDFromHex("64e17f4ae098346113ae4f62")
 	return &sq, nil
@@ -242,6 +243,7 @@ func TestRateSkill(t *testing.T) {
 		engine.POST("/squads/:id/rate", app.NewGinHandler(handler.RateSkills, zap.NewNop()))
 		rec := httptest.NewRecorder()
 		req, _ := http.NewRequest(http.MethodPost, "/squads/:id/rate", bytes.NewBuffer(jsonBody))
+		req.Header.Set("If-Match", `"0"`)
 
 		engine.ServeHTTP(rec, req)
 
@@ -255,6 +257,7 @@ func TestRateSkill(t *testing.T) {
 			   "teamleadMail":"",
 			   "desc":"Aster",
 			   "createdAt":"2023-10-25T12:00:00Z",
+			   "version":1,
 			   "skillsRatings":[
 				  {
 					 "skid":"64e17f4ae098346113ae4f61",
@@ -270,6 +273,36 @@ func TestRateSkill(t *testing.T) {
 		 }`
 		assert.Equal(t, 200, rec.Code)
 		assert.JSONEq(t, want, resp)
+		assert.Equal(t, `"1"`, rec.Header().Get("ETag"))
+	})
+	t.Run("should return 412 when the squad was rated by someone else since it was read", func(t *testing.T) {
+		objId, _ := primitive.ObjectIDFromHex("64e17f4ae098346113ae4f61")
+		jsonBody, _ := json.Marshal(RateSkill{Ratings: []Rating{{SkillId: objId, Score: 2}}})
+		handler := NewSquadHandler(&mockSquadStorage{squad: &squad.Squad{Name: "Aster", Version: 2}})
+
+		engine := gin.New()
+		engine.POST("/squads/:id/rate", app.NewGinHandler(handler.RateSkills, zap.NewNop()))
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/squads/:id/rate", bytes.NewBuffer(jsonBody))
+		req.Header.Set("If-Match", `"1"`)
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, 412, rec.Code)
+	})
+	t.Run("should return 428 when If-Match is missing", func(t *testing.T) {
+		objId, _ := primitive.ObjectIDFromHex("64e17f4ae098346113ae4f61")
+		jsonBody, _ := json.Marshal(RateSkill{Ratings: []Rating{{SkillId: objId, Score: 2}}})
+		handler := NewSquadHandler(&mockSquadStorage{squad: &squad.Squad{Name: "Aster"}})
+
+		engine := gin.New()
+		engine.POST("/squads/:id/rate", app.NewGinHandler(handler.RateSkills, zap.NewNop()))
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/squads/:id/rate", bytes.NewBuffer(jsonBody))
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, 428, rec.Code)
 	})
 	t.Run("should return 400 when bad request", func(t *testing.T) {
 		handler := NewSquadHandler(&mockSquadStorage{})
@@ -2,9 +2,9 @@ package profile
 
 import (
 	"context"
-	"errors"
 
 	"gitdev.devops.krungthai.com/aster/ariskill/app/squad"
+	"gitdev.devops.krungthai.com/aster/ariskill/database"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -12,6 +12,16 @@ import (
 
 const squadCollection = "squads"
 
+type SquadStorageError struct {
+	message string
+}
+
+func (e SquadStorageError) Error() string {
+	return e.message
+}
+
+var squadVersionChangedError = SquadStorageError{message: "squad was changed by someone else, reload it and try again"}
+
 func (s *storage) GetOneByID(ctx context.Context, id string) (*squad.Squad, error) {
 	obId, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
@@ -27,19 +37,23 @@ func (s *storage) GetOneByID(ctx context.Context, id string) (*squad.Squad, erro
 	return squad, nil
 }
 
-func (s *storage) UpdateByID(ctx context.Context, id string, updateSquad *squad.Squad) (*squad.Squad, error) {
+// UpdateByID replaces a squad that is still at the given version, so ratings
+// made by another member since it was read are never overwritten.
+func (s *storage) UpdateByID(ctx context.Context, id string, version int, updateSquad *squad.Squad) (*squad.Squad, error) {
+	updateSquad.Version = version + 1
 	update := bson.M{"$set": updateSquad}
 	objId, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
 		return nil, err
 	}
-	res, errRes := s.db.Collection(squadCollection).UpdateOne(ctx, bson.M{"_id": objId}, update)
+	filter := bson.M{"_id": objId, "version": database.VersionFilter(version)}
+	res, errRes := s.db.Collection(squadCollection).UpdateOne(ctx, filter, update)
 	if errRes != nil {
 		return nil, errRes
 	}
 
 	if res.MatchedCount <= 0 {
-		return nil, errors.New("squad not found")
+		return nil, squadVersionChangedError
 	}
 
 	var result *squad.Squad
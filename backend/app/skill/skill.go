@@ -1,24 +1,77 @@
 package skill
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
 
 type SkillKind string
 
+const (
+	KindSoft      = "soft"
+	KindTechnical = "technical"
+)
+
+// Skill is a soft or technical skill of the catalog, also found in search by
+// its aliases. An archived skill is left out of the catalog but still found
+// by ID.
 type Skill struct {
 	ID          primitive.ObjectID `json:"id" bson:"_id"`
 	Name        string             `json:"name" bson:"name"`
 	Description string             `json:"description" bson:"description"`
 	Logo        string             `json:"logo" bson:"logo"`
 	Kind        string             `json:"kind" bson:"kind"`
+	Aliases     []string           `json:"aliases,omitempty" bson:"aliases,omitempty"`
+	UpdatedBy   string             `json:"updatedBy,omitempty" bson:"updatedBy,omitempty"`
+	UpdatedAt   *time.Time         `json:"updatedAt,omitempty" bson:"updatedAt,omitempty"`
+	ArchivedAt  *time.Time         `json:"archivedAt,omitempty" bson:"archivedAt,omitempty"`
 }
 
+// HardSkill is a skill of the job roles it lists, scored on the levels of
+// its rubric. SkillLevel is the latest version of the rubric, RubricVersion
+// its number. An archived hard skill is not put in new cycles any more.
+// CategoryID places it in the skill tree and Prerequisites are the levels of
+// other hard skills its own levels need. Aliases find it in search.
 type HardSkill struct {
-	ID          primitive.ObjectID `json:"id" bson:"_id"`
-	Name        string             `json:"name" bson:"name"`
-	Description DescriptionEnum    `json:"description" bson:"description"`
-	JobRole     []JobRole          `json:"jobRole" bson:"jobRole"`
-	Sort        int                `json:"sort" bson:"sort"`
-	SkillLevel  []SkillLevel       `json:"skillLevel" bson:"skillLevel"`
+	ID            primitive.ObjectID  `json:"id" bson:"_id"`
+	Name          string              `json:"name" bson:"name"`
+	Description   DescriptionEnum     `json:"description" bson:"description"`
+	JobRole       []JobRole           `json:"jobRole" bson:"jobRole"`
+	Sort          int                 `json:"sort" bson:"sort"`
+	SkillLevel    []SkillLevel        `json:"skillLevel" bson:"skillLevel"`
+	RubricVersion int                 `json:"rubricVersion,omitempty" bson:"rubricVersion,omitempty"`
+	CategoryID    *primitive.ObjectID `json:"categoryId,omitempty" bson:"categoryId,omitempty"`
+	Prerequisites []Prerequisite      `json:"prerequisites,omitempty" bson:"prerequisites,omitempty"`
+	Aliases       []string            `json:"aliases,omitempty" bson:"aliases,omitempty"`
+	UpdatedBy     string              `json:"updatedBy,omitempty" bson:"updatedBy,omitempty"`
+	UpdatedAt     *time.Time          `json:"updatedAt,omitempty" bson:"updatedAt,omitempty"`
+	ArchivedAt    *time.Time          `json:"archivedAt,omitempty" bson:"archivedAt,omitempty"`
+}
+
+type SkillInput struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description" binding:"required"`
+	Logo        string `json:"logo"`
+	Kind        string `json:"kind" binding:"required,oneof=soft technical"`
+}
+
+type HardSkillInput struct {
+	Name        string          `json:"name" binding:"required"`
+	Description DescriptionEnum `json:"description" binding:"required"`
+	JobRole     []JobRole       `json:"jobRole" binding:"required,min=1,dive,required"`
+	Sort        int             `json:"sort" binding:"min=0"`
+	SkillLevel  []SkillLevel    `json:"skillLevel" binding:"required,min=1,dive"`
+	CategoryID  string          `json:"categoryId"`
+	// Prerequisites replace the ones the hard skill had.
+	Prerequisites []Prerequisite `json:"prerequisites" binding:"dive"`
+	// EffectiveFrom is when new skill levels take effect, now by default.
+	EffectiveFrom *time.Time `json:"effectiveFrom"`
+}
+
+// AliasesInput replaces the aliases of a skill, such as "k8s" for Kubernetes.
+type AliasesInput struct {
+	Aliases []string `json:"aliases" binding:"required,dive,required"`
 }
 
 type ID struct {
@@ -26,8 +79,8 @@ type ID struct {
 }
 
 type SkillLevel struct {
-	Level            int              `json:"level"`
-	LevelDescription LevelDescription `json:"levelDescription"`
+	Level            int              `json:"level" binding:"required,min=1"`
+	LevelDescription LevelDescription `json:"levelDescription" binding:"required"`
 }
 
 type DescriptionEnum string
@@ -53,3 +106,14 @@ const (
 	ExampleLevel4 LevelDescription = "example level 4"
 	ExampleLevel5 LevelDescription = "example level 5"
 )
+
+// ValidRubric tells whether the levels of a rubric are numbered from 1 up, in
+// order and without gaps.
+func ValidRubric(levels []SkillLevel) bool {
+	for i, l := range levels {
+		if l.Level != i+1 {
+			return false
+		}
+	}
+	return len(levels) > 0
+}
@@ -0,0 +1,451 @@
+package skill
+
+import (
+	"context"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AdminStorage interface {
+	GetAllSkills(ctx context.Context) ([]Skill, error)
+	GetAllHardSkills(ctx context.Context) ([]HardSkill, error)
+	InsertSkill(ctx context.Context, sk Skill) (*Skill, error)
+	InsertHardSkill(ctx context.Context, hs HardSkill, effectiveFrom time.Time) (*HardSkill, error)
+	UpdateSkill(ctx context.Context, id string, sk Skill) (*Skill, error)
+	UpdateHardSkill(ctx context.Context, id string, hs HardSkill, effectiveFrom time.Time) (*HardSkill, error)
+	ArchiveSkill(ctx context.Context, id string, email string) (*Skill, error)
+	ArchiveHardSkill(ctx context.Context, id string, email string) (*HardSkill, error)
+	SetSkillAliases(ctx context.Context, id string, aliases []string, email string) (*Skill, error)
+	SetHardSkillAliases(ctx context.Context, id string, aliases []string, email string) (*HardSkill, error)
+}
+
+type adminHandler struct {
+	storage AdminStorage
+	admins  []string
+}
+
+func NewSkillAdminHandler(st AdminStorage, admins []string) *adminHandler {
+	return &adminHandler{
+		storage: st,
+		admins:  admins,
+	}
+}
+
+type skillHandlerError struct {
+	message string
+}
+
+func (e skillHandlerError) Error() string {
+	return e.message
+}
+
+var notSkillAdminError = skillHandlerError{message: "only admins can manage the skill catalog"}
+var invalidSkillInputError = skillHandlerError{message: "invalid skill input, name, description and a kind of soft or technical are required"}
+var invalidHardSkillInputError = skillHandlerError{message: "invalid hard skill input, name, description, a job role and skill levels are required"}
+var invalidAliasesError = skillHandlerError{message: "invalid aliases input, aliases must be a list of names"}
+var invalidRubricError = skillHandlerError{message: "skill levels must be numbered from 1 up, in order and without gaps"}
+
+// GetAllSkills godoc
+//
+//	@summary		GetAllSkills
+//	@description	List every soft and technical skill by kind and name, archived ones included.
+//	@tags			skill
+//	@id				GetAllSkills
+//	@security		BearerAuth
+//	@produce		json
+//	@response		200	{array}		skill.Skill		"OK"
+//	@response		403	{object}	app.Response	"Not an admin"
+//	@response		450	{object}	app.Response	"Store Error"
+//	@router			/admin/skills [get]
+func (h *adminHandler) GetAllSkills(c app.Context) {
+	if !h.admin(c) {
+		return
+	}
+
+	sks, err := h.storage.GetAllSkills(c.Ctx())
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(sks)
+}
+
+// InsertSkill godoc
+//
+//	@summary		InsertSkill
+//	@description	Add a soft or technical skill to the catalog.
+//	@tags			skill
+//	@id				InsertSkill
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			reqJson	body		SkillInput		true	"Skill"
+//	@response		200		{object}	skill.Skill		"OK"
+//	@response		400		{object}	app.Response	"Bad Request"
+//	@response		403		{object}	app.Response	"Not an admin"
+//	@response		409		{object}	app.Response	"Name is taken"
+//	@response		450		{object}	app.Response	"Store Error"
+//	@router			/admin/skills [post]
+func (h *adminHandler) InsertSkill(c app.Context) {
+	sk, ok := h.skill(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.storage.InsertSkill(c.Ctx(), sk)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+
+	c.OK(res)
+}
+
+// UpdateSkill godoc
+//
+//	@summary		UpdateSkill
+//	@description	Replace the name, description, logo and kind of a skill.
+//	@tags			skill
+//	@id				UpdateSkill
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			skillID	path		string			true	"Skill ID"
+//	@param			reqJson	body		SkillInput		true	"Skill"
+//	@response		200		{object}	skill.Skill		"OK"
+//	@response		400		{object}	app.Response	"Bad Request"
+//	@response		403		{object}	app.Response	"Not an admin"
+//	@response		404		{object}	app.Response	"Skill not found"
+//	@response		409		{object}	app.Response	"Name is taken"
+//	@response		450		{object}	app.Response	"Store Error"
+//	@router			/admin/skills/{skillID} [put]
+func (h *adminHandler) UpdateSkill(c app.Context) {
+	sk, ok := h.skill(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.storage.UpdateSkill(c.Ctx(), c.Param("skillID"), sk)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+
+	c.OK(res)
+}
+
+// ArchiveSkill godoc
+//
+//	@summary		ArchiveSkill
+//	@description	Take a skill out of the catalog. It is still found by ID for the profiles that have it.
+//	@tags			skill
+//	@id				ArchiveSkill
+//	@security		BearerAuth
+//	@produce		json
+//	@param			skillID	path		string			true	"Skill ID"
+//	@response		200		{object}	skill.Skill		"OK"
+//	@response		400		{object}	app.Response	"Bad Request"
+//	@response		403		{object}	app.Response	"Not an admin"
+//	@response		404		{object}	app.Response	"Skill not found"
+//	@response		450		{object}	app.Response	"Store Error"
+//	@router			/admin/skills/{skillID}/archive [post]
+func (h *adminHandler) ArchiveSkill(c app.Context) {
+	if !h.admin(c) {
+		return
+	}
+
+	res, err := h.storage.ArchiveSkill(c.Ctx(), c.Param("skillID"), c.GetString("email"))
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+
+	c.OK(res)
+}
+
+// GetAllHardSkills godoc
+//
+//	@summary		GetAllHardSkills
+//	@description	List every hard skill in catalog order, archived ones included.
+//	@tags			skill
+//	@id				GetAllHardSkills
+//	@security		BearerAuth
+//	@produce		json
+//	@response		200	{array}		skill.HardSkill	"OK"
+//	@response		403	{object}	app.Response	"Not an admin"
+//	@response		450	{object}	app.Response	"Store Error"
+//	@router			/admin/hard-skills [get]
+func (h *adminHandler) GetAllHardSkills(c app.Context) {
+	if !h.admin(c) {
+		return
+	}
+
+	hss, err := h.storage.GetAllHardSkills(c.Ctx())
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(hss)
+}
+
+// InsertHardSkill godoc
+//
+//	@summary		InsertHardSkill
+//	@description	Add a hard skill to the catalog for the job roles it lists. Its skill levels must be numbered from 1 up and are the first version of its rubric, in effect from effectiveFrom or now. It can be put in a skill category and need levels of other hard skills as prerequisites.
+//	@tags			skill
+//	@id				InsertHardSkill
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			reqJson	body		HardSkillInput	true	"Hard skill"
+//	@response		200		{object}	skill.HardSkill	"OK"
+//	@response		400		{object}	app.Response	"Bad Request"
+//	@response		403		{object}	app.Response	"Not an admin"
+//	@response		404		{object}	app.Response	"Skill category not found"
+//	@response		409		{object}	app.Response	"Name is taken"
+//	@response		450		{object}	app.Response	"Store Error"
+//	@router			/admin/hard-skills [post]
+func (h *adminHandler) InsertHardSkill(c app.Context) {
+	hs, effectiveFrom, ok := h.hardSkill(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.storage.InsertHardSkill(c.Ctx(), hs, effectiveFrom)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+
+	c.OK(res)
+}
+
+// UpdateHardSkill godoc
+//
+//	@summary		UpdateHardSkill
+//	@description	Replace the description, job roles, sort order, skill levels, category and prerequisites of a hard skill. The name must stay the same, as profiles, cycles and goal policies refer to the hard skill by it; add an alias for a new name. New skill levels are a new version of its rubric, in effect from effectiveFrom or now, which must be after the current version. Cycles keep the version in effect when they started.
+//	@tags			skill
+//	@id				UpdateHardSkill
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			skillID	path		string			true	"Hard skill ID"
+//	@param			reqJson	body		HardSkillInput	true	"Hard skill"
+//	@response		200		{object}	skill.HardSkill	"OK"
+//	@response		400		{object}	app.Response	"Bad Request"
+//	@response		403		{object}	app.Response	"Not an admin"
+//	@response		404		{object}	app.Response	"Skill or skill category not found"
+//	@response		409		{object}	app.Response	"Name was changed or skill levels were changed by someone else"
+//	@response		450		{object}	app.Response	"Store Error"
+//	@router			/admin/hard-skills/{skillID} [put]
+func (h *adminHandler) UpdateHardSkill(c app.Context) {
+	hs, effectiveFrom, ok := h.hardSkill(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.storage.UpdateHardSkill(c.Ctx(), c.Param("skillID"), hs, effectiveFrom)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+
+	c.OK(res)
+}
+
+// ArchiveHardSkill godoc
+//
+//	@summary		ArchiveHardSkill
+//	@description	Take a hard skill out of the catalog so new cycles leave it out. Cycles that have it keep it.
+//	@tags			skill
+//	@id				ArchiveHardSkill
+//	@security		BearerAuth
+//	@produce		json
+//	@param			skillID	path		string			true	"Hard skill ID"
+//	@response		200		{object}	skill.HardSkill	"OK"
+//	@response		400		{object}	app.Response	"Bad Request"
+//	@response		403		{object}	app.Response	"Not an admin"
+//	@response		404		{object}	app.Response	"Skill not found"
+//	@response		450		{object}	app.Response	"Store Error"
+//	@router			/admin/hard-skills/{skillID}/archive [post]
+func (h *adminHandler) ArchiveHardSkill(c app.Context) {
+	if !h.admin(c) {
+		return
+	}
+
+	res, err := h.storage.ArchiveHardSkill(c.Ctx(), c.Param("skillID"), c.GetString("email"))
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+
+	c.OK(res)
+}
+
+// UpdateSkillAliases godoc
+//
+//	@summary		UpdateSkillAliases
+//	@description	Replace the aliases a soft or technical skill is also found by in search, such as "JS" for JavaScript. An alias can't be the name or an alias of another skill.
+//	@tags			skill
+//	@id				UpdateSkillAliases
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			skillID	path		string			true	"Skill ID"
+//	@param			reqJson	body		AliasesInput	true	"Aliases"
+//	@response		200		{object}	skill.Skill		"OK"
+//	@response		400		{object}	app.Response	"Bad Request"
+//	@response		403		{object}	app.Response	"Not an admin"
+//	@response		404		{object}	app.Response	"Skill not found"
+//	@response		409		{object}	app.Response	"Alias is taken"
+//	@response		450		{object}	app.Response	"Store Error"
+//	@router			/admin/skills/{skillID}/aliases [put]
+func (h *adminHandler) UpdateSkillAliases(c app.Context) {
+	aliases, ok := h.aliases(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.storage.SetSkillAliases(c.Ctx(), c.Param("skillID"), aliases, c.GetString("email"))
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+
+	c.OK(res)
+}
+
+// UpdateHardSkillAliases godoc
+//
+//	@summary		UpdateHardSkillAliases
+//	@description	Replace the aliases a hard skill is also found by in search, such as "k8s" for Kubernetes. An alias can't be the name or an alias of another hard skill.
+//	@tags			skill
+//	@id				UpdateHardSkillAliases
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			skillID	path		string			true	"Hard skill ID"
+//	@param			reqJson	body		AliasesInput	true	"Aliases"
+//	@response		200		{object}	skill.HardSkill	"OK"
+//	@response		400		{object}	app.Response	"Bad Request"
+//	@response		403		{object}	app.Response	"Not an admin"
+//	@response		404		{object}	app.Response	"Skill not found"
+//	@response		409		{object}	app.Response	"Alias is taken"
+//	@response		450		{object}	app.Response	"Store Error"
+//	@router			/admin/hard-skills/{skillID}/aliases [put]
+func (h *adminHandler) UpdateHardSkillAliases(c app.Context) {
+	aliases, ok := h.aliases(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.storage.SetHardSkillAliases(c.Ctx(), c.Param("skillID"), aliases, c.GetString("email"))
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+
+	c.OK(res)
+}
+
+func (h *adminHandler) admin(c app.Context) bool {
+	return app.RequireAdmin(c, h.admins, notSkillAdminError)
+}
+
+// skill binds the skill of the request, checking the caller is an admin.
+func (h *adminHandler) skill(c app.Context) (Skill, bool) {
+	if !h.admin(c) {
+		return Skill{}, false
+	}
+
+	var input SkillInput
+	if err := c.Bind(&input); err != nil {
+		c.BadRequest(invalidSkillInputError)
+		return Skill{}, false
+	}
+
+	now := time.Now()
+	return Skill{
+		Name:        input.Name,
+		Description: input.Description,
+		Logo:        input.Logo,
+		Kind:        input.Kind,
+		UpdatedBy:   c.GetString("email"),
+		UpdatedAt:   &now,
+	}, true
+}
+
+// aliases binds the aliases of the request, checking the caller is an admin.
+func (h *adminHandler) aliases(c app.Context) ([]string, bool) {
+	if !h.admin(c) {
+		return nil, false
+	}
+
+	var input AliasesInput
+	if err := c.Bind(&input); err != nil {
+		c.BadRequest(invalidAliasesError)
+		return nil, false
+	}
+	return input.Aliases, true
+}
+
+// hardSkill binds the hard skill of the request and when its skill levels
+// take effect, checking the caller is an admin and its rubric.
+func (h *adminHandler) hardSkill(c app.Context) (HardSkill, time.Time, bool) {
+	if !h.admin(c) {
+		return HardSkill{}, time.Time{}, false
+	}
+
+	var input HardSkillInput
+	if err := c.Bind(&input); err != nil {
+		c.BadRequest(invalidHardSkillInputError)
+		return HardSkill{}, time.Time{}, false
+	}
+	if !ValidRubric(input.SkillLevel) {
+		c.BadRequest(invalidRubricError)
+		return HardSkill{}, time.Time{}, false
+	}
+	var categoryID *primitive.ObjectID
+	if input.CategoryID != "" {
+		oid, err := primitive.ObjectIDFromHex(input.CategoryID)
+		if err != nil {
+			c.BadRequest(invalidCategoryIdError)
+			return HardSkill{}, time.Time{}, false
+		}
+		categoryID = &oid
+	}
+
+	now := time.Now()
+	effectiveFrom := now
+	if input.EffectiveFrom != nil {
+		effectiveFrom = *input.EffectiveFrom
+	}
+	return HardSkill{
+		Name:          input.Name,
+		Description:   input.Description,
+		JobRole:       input.JobRole,
+		Sort:          input.Sort,
+		SkillLevel:    input.SkillLevel,
+		CategoryID:    categoryID,
+		Prerequisites: input.Prerequisites,
+		UpdatedBy:     c.GetString("email"),
+		UpdatedAt:     &now,
+	}, effectiveFrom, true
+}
+
+func storeError(c app.Context, err error) {
+	switch err {
+	case invalidSkillIdError, rubricNotLaterError, invalidCategoryIdError, categoryTooDeepError, invalidPrerequisiteError, prerequisiteLoopError:
+		c.BadRequest(err)
+	case skillNotFoundError, rubricVersionNotFoundError, categoryNotFoundError:
+		c.NotFound(err)
+	case skillNameTakenError, hardSkillRenameError, rubricChangedError, categoryNotEmptyError, aliasTakenError:
+		c.Conflict(err)
+	default:
+		c.StoreError(err)
+	}
+}
@@ -0,0 +1,547 @@
+package skill
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+type mockAdminStorage struct {
+	skill     *Skill
+	hardSkill *HardSkill
+	id        string
+	archiver  string
+	err       error
+
+	effectiveFrom time.Time
+}
+
+func (m *mockAdminStorage) GetAllSkills(ctx context.Context) ([]Skill, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []Skill{*m.skill}, nil
+}
+
+func (m *mockAdminStorage) GetAllHardSkills(ctx context.Context) ([]HardSkill, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []HardSkill{*m.hardSkill}, nil
+}
+
+func (m *mockAdminStorage) InsertSkill(ctx context.Context, sk Skill) (*Skill, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	sk.ID = mockSkillID
+	m.skill = &sk
+	return &sk, nil
+}
+
+func (m *mockAdminStorage) InsertHardSkill(ctx context.Context, hs HardSkill, effectiveFrom time.Time) (*HardSkill, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	hs.ID = mockSkillID
+	m.hardSkill = &hs
+	return &hs, nil
+}
+
+func (m *mockAdminStorage) UpdateSkill(ctx context.Context, id string, sk Skill) (*Skill, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.id = id
+	sk.ID = mockSkillID
+	m.skill = &sk
+	return &sk, nil
+}
+
+func (m *mockAdminStorage) UpdateHardSkill(ctx context.Context, id string, hs HardSkill, effectiveFrom time.Time) (*HardSkill, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.id = id
+	m.effectiveFrom = effectiveFrom
+	hs.ID = mockSkillID
+	m.hardSkill = &hs
+	return &hs, nil
+}
+
+func (m *mockAdminStorage) ArchiveSkill(ctx context.Context, id string, email string) (*Skill, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.id, m.archiver = id, email
+	return m.skill, nil
+}
+
+func (m *mockAdminStorage) ArchiveHardSkill(ctx context.Context, id string, email string) (*HardSkill, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.id, m.archiver = id, email
+	return m.hardSkill, nil
+}
+
+func (m *mockAdminStorage) SetSkillAliases(ctx context.Context, id string, aliases []string, email string) (*Skill, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.id, m.archiver = id, email
+	m.skill.Aliases = aliases
+	return m.skill, nil
+}
+
+func (m *mockAdminStorage) SetHardSkillAliases(ctx context.Context, id string, aliases []string, email string) (*HardSkill, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.id, m.archiver = id, email
+	m.hardSkill.Aliases = aliases
+	return m.hardSkill, nil
+}
+
+var mockSkillID, _ = primitive.ObjectIDFromHex("5e201c51e09c2c084c88a790")
+
+func adminEngine(h *adminHandler, email string) *gin.Engine {
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Set("email", email)
+	})
+	engine.GET("/admin/skills", app.NewGinHandler(h.GetAllSkills, zap.NewNop()))
+	engine.POST("/admin/skills", app.NewGinHandler(h.InsertSkill, zap.NewNop()))
+	engine.PUT("/admin/skills/:skillID", app.NewGinHandler(h.UpdateSkill, zap.NewNop()))
+	engine.POST("/admin/skills/:skillID/archive", app.NewGinHandler(h.ArchiveSkill, zap.NewNop()))
+	engine.GET("/admin/hard-skills", app.NewGinHandler(h.GetAllHardSkills, zap.NewNop()))
+	engine.POST("/admin/hard-skills", app.NewGinHandler(h.InsertHardSkill, zap.NewNop()))
+	engine.PUT("/admin/hard-skills/:skillID", app.NewGinHandler(h.UpdateHardSkill, zap.NewNop()))
+	engine.POST("/admin/hard-skills/:skillID/archive", app.NewGinHandler(h.ArchiveHardSkill, zap.NewNop()))
+	engine.PUT("/admin/skills/:skillID/aliases", app.NewGinHandler(h.UpdateSkillAliases, zap.NewNop()))
+	engine.PUT("/admin/hard-skills/:skillID/aliases", app.NewGinHandler(h.UpdateHardSkillAliases, zap.NewNop()))
+	return engine
+}
+
+func TestInsertSkill(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reqBody := `{"name":"Kotlin","description":"A modern JVM language","logo":"kotlin.svg","kind":"technical"}`
+
+	testCases := []struct {
+		name             string
+		email            string
+		reqBody          string
+		storageErr       error
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:           "should return 200 and the new skill",
+			email:          "admin@arise.tech",
+			reqBody:        reqBody,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:             "should return 403 when user is not an admin",
+			email:            "lead@arise.tech",
+			reqBody:          reqBody,
+			expectedStatus:   http.StatusForbidden,
+			expectedResponse: `{"status":"error","message":"only admins can manage the skill catalog"}`,
+		},
+		{
+			name:             "should return 400 when kind is unknown",
+			email:            "admin@arise.tech",
+			reqBody:          `{"name":"Kotlin","description":"A modern JVM language","kind":"hard"}`,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid skill input, name, description and a kind of soft or technical are required"}`,
+		},
+		{
+			name:             "should return 409 when the name is taken",
+			email:            "admin@arise.tech",
+			reqBody:          reqBody,
+			storageErr:       skillNameTakenError,
+			expectedStatus:   http.StatusConflict,
+			expectedResponse: `{"status":"error","message":"a skill with this name already exists"}`,
+		},
+		{
+			name:             "should return 450 when insert is failed",
+			email:            "admin@arise.tech",
+			reqBody:          reqBody,
+			storageErr:       errors.New("insert error"),
+			expectedStatus:   450,
+			expectedResponse: `{"status":"error","message":"insert error"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockAdminStorage{err: tc.storageErr}
+			engine := adminEngine(NewSkillAdminHandler(st, []string{"admin@arise.tech"}), tc.email)
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/admin/skills", strings.NewReader(tc.reqBody))
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedResponse != "" {
+				assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+			}
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, "Kotlin", st.skill.Name)
+				assert.Equal(t, KindTechnical, st.skill.Kind)
+				assert.Equal(t, "admin@arise.tech", st.skill.UpdatedBy)
+				assert.NotNil(t, st.skill.UpdatedAt)
+				assert.Nil(t, st.skill.ArchivedAt)
+			}
+		})
+	}
+}
+
+func TestUpdateHardSkill(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	reqBody := `{
+		"name": "Kubernetes",
+		"description": "description",
+		"jobRole": ["backend", "devops"],
+		"sort": 12,
+		"skillLevel": [
+			{"level": 1, "levelDescription": "runs a pod"},
+			{"level": 2, "levelDescription": "writes a deployment"},
+			{"level": 3, "levelDescription": "runs a cluster"}
+		]
+	}`
+
+	testCases := []struct {
+		name             string
+		email            string
+		id               string
+		reqBody          string
+		storageErr       error
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:           "should return 200 and the updated hard skill",
+			email:          "admin@arise.tech",
+			id:             mockSkillID.Hex(),
+			reqBody:        reqBody,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:             "should return 403 when user is not an admin",
+			email:            "lead@arise.tech",
+			id:               mockSkillID.Hex(),
+			reqBody:          reqBody,
+			expectedStatus:   http.StatusForbidden,
+			expectedResponse: `{"status":"error","message":"only admins can manage the skill catalog"}`,
+		},
+		{
+			name:             "should return 400 when job roles are missing",
+			email:            "admin@arise.tech",
+			id:               mockSkillID.Hex(),
+			reqBody:          `{"name":"Kubernetes","description":"description","jobRole":[],"skillLevel":[{"level":1,"levelDescription":"runs a pod"}]}`,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid hard skill input, name, description, a job role and skill levels are required"}`,
+		},
+		{
+			name:             "should return 400 when skill levels have a gap",
+			email:            "admin@arise.tech",
+			id:               mockSkillID.Hex(),
+			reqBody:          `{"name":"Kubernetes","description":"description","jobRole":["backend"],"skillLevel":[{"level":1,"levelDescription":"runs a pod"},{"level":3,"levelDescription":"runs a cluster"}]}`,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"skill levels must be numbered from 1 up, in order and without gaps"}`,
+		},
+		{
+			name:             "should return 400 when id is invalid",
+			email:            "admin@arise.tech",
+			id:               "dkls",
+			reqBody:          reqBody,
+			storageErr:       invalidSkillIdError,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid skill id"}`,
+		},
+		{
+			name:             "should return 404 when the hard skill is not found",
+			email:            "admin@arise.tech",
+			id:               mockSkillID.Hex(),
+			reqBody:          reqBody,
+			storageErr:       skillNotFoundError,
+			expectedStatus:   http.StatusNotFound,
+			expectedResponse: `{"status":"error","message":"skill not found"}`,
+		},
+		{
+			name:             "should return 400 when the new skill levels take effect before the current ones",
+			email:            "admin@arise.tech",
+			id:               mockSkillID.Hex(),
+			reqBody:          reqBody,
+			storageErr:       rubricNotLaterError,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"new skill levels must take effect after the current ones"}`,
+		},
+		{
+			name:             "should return 409 when the hard skill is renamed",
+			email:            "admin@arise.tech",
+			id:               mockSkillID.Hex(),
+			reqBody:          reqBody,
+			storageErr:       hardSkillRenameError,
+			expectedStatus:   http.StatusConflict,
+			expectedResponse: `{"status":"error","message":"a hard skill can't be renamed, add the new name as an alias instead"}`,
+		},
+		{
+			name:             "should return 409 when the skill levels were changed at the same time",
+			email:            "admin@arise.tech",
+			id:               mockSkillID.Hex(),
+			reqBody:          reqBody,
+			storageErr:       rubricChangedError,
+			expectedStatus:   http.StatusConflict,
+			expectedResponse: `{"status":"error","message":"skill levels were changed by someone else, reload them and try again"}`,
+		},
+		{
+			name:             "should return 400 when category id is invalid",
+			email:            "admin@arise.tech",
+			id:               mockSkillID.Hex(),
+			reqBody:          strings.Replace(reqBody, `"sort": 12,`, `"sort": 12, "categoryId": "dkls",`, 1),
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid skill category id"}`,
+		},
+		{
+			name:             "should return 400 when prerequisites lead back to the hard skill",
+			email:            "admin@arise.tech",
+			id:               mockSkillID.Hex(),
+			reqBody:          reqBody,
+			storageErr:       prerequisiteLoopError,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"prerequisites cannot lead back to the hard skill itself"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockAdminStorage{err: tc.storageErr}
+			engine := adminEngine(NewSkillAdminHandler(st, []string{"admin@arise.tech"}), tc.email)
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/admin/hard-skills/"+tc.id, strings.NewReader(tc.reqBody))
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedResponse != "" {
+				assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+			}
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, mockSkillID.Hex(), st.id)
+				assert.Equal(t, []JobRole{Backend, "devops"}, st.hardSkill.JobRole)
+				assert.Equal(t, 12, st.hardSkill.Sort)
+				assert.Len(t, st.hardSkill.SkillLevel, 3)
+				assert.Equal(t, LevelDescription("runs a cluster"), st.hardSkill.SkillLevel[2].LevelDescription)
+				assert.WithinDuration(t, time.Now(), st.effectiveFrom, time.Minute)
+			}
+		})
+	}
+
+	t.Run("should pass on the date the new skill levels take effect", func(t *testing.T) {
+		st := &mockAdminStorage{}
+		engine := adminEngine(NewSkillAdminHandler(st, []string{"admin@arise.tech"}), "admin@arise.tech")
+		rec := httptest.NewRecorder()
+		body := strings.Replace(reqBody, `"sort": 12,`, `"sort": 12, "effectiveFrom": "2025-01-01T00:00:00Z",`, 1)
+		req, _ := http.NewRequest(http.MethodPut, "/admin/hard-skills/"+mockSkillID.Hex(), strings.NewReader(body))
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), st.effectiveFrom)
+	})
+
+	t.Run("should pass on the category and prerequisites", func(t *testing.T) {
+		st := &mockAdminStorage{}
+		engine := adminEngine(NewSkillAdminHandler(st, []string{"admin@arise.tech"}), "admin@arise.tech")
+		rec := httptest.NewRecorder()
+		body := strings.Replace(reqBody, `"sort": 12,`, `"sort": 12, "categoryId": "000000000000000000000001", "prerequisites": [{"level": 3, "skillId": "000000000000000000000002", "requiredLevel": 2}],`, 1)
+		req, _ := http.NewRequest(http.MethodPut, "/admin/hard-skills/"+mockSkillID.Hex(), strings.NewReader(body))
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, mockOID(1), *st.hardSkill.CategoryID)
+		assert.Equal(t, []Prerequisite{{Level: 3, SkillID: mockOID(2), RequiredLevel: 2}}, st.hardSkill.Prerequisites)
+	})
+}
+
+func TestArchiveHardSkill(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	archivedAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should return 200 and the archived hard skill", func(t *testing.T) {
+		st := &mockAdminStorage{hardSkill: &HardSkill{
+			ID:         mockSkillID,
+			Name:       "jQuery",
+			JobRole:    []JobRole{Frontend},
+			SkillLevel: []SkillLevel{},
+			ArchivedAt: &archivedAt,
+		}}
+		engine := adminEngine(NewSkillAdminHandler(st, []string{"admin@arise.tech"}), "admin@arise.tech")
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/hard-skills/"+mockSkillID.Hex()+"/archive", nil)
+
+		engine.ServeHTTP(rec, req)
+
+		want := `{
+			"status": "success",
+			"message": "",
+			"data": {
+				"id": "5e201c51e09c2c084c88a790",
+				"name": "jQuery",
+				"description": "",
+				"jobRole": ["frontend"],
+				"sort": 0,
+				"skillLevel": [],
+				"archivedAt": "2024-07-01T00:00:00Z"
+			}
+		}`
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, want, rec.Body.String())
+		assert.Equal(t, "admin@arise.tech", st.archiver)
+	})
+
+	t.Run("should return 403 when user is not an admin", func(t *testing.T) {
+		st := &mockAdminStorage{}
+		engine := adminEngine(NewSkillAdminHandler(st, []string{"admin@arise.tech"}), "lead@arise.tech")
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/hard-skills/"+mockSkillID.Hex()+"/archive", nil)
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Empty(t, st.id)
+	})
+
+	t.Run("should return 404 when the skill is not found", func(t *testing.T) {
+		st := &mockAdminStorage{err: skillNotFoundError}
+		engine := adminEngine(NewSkillAdminHandler(st, []string{"admin@arise.tech"}), "admin@arise.tech")
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/skills/"+mockSkillID.Hex()+"/archive", nil)
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestUpdateHardSkillAliases(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name             string
+		email            string
+		reqBody          string
+		storageErr       error
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:           "should return 200 and the hard skill with its aliases",
+			email:          "admin@arise.tech",
+			reqBody:        `{"aliases":["k8s","kube"]}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "should return 200 when aliases are cleared",
+			email:          "admin@arise.tech",
+			reqBody:        `{"aliases":[]}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:             "should return 403 when user is not an admin",
+			email:            "lead@arise.tech",
+			reqBody:          `{"aliases":["k8s"]}`,
+			expectedStatus:   http.StatusForbidden,
+			expectedResponse: `{"status":"error","message":"only admins can manage the skill catalog"}`,
+		},
+		{
+			name:             "should return 400 when aliases are missing",
+			email:            "admin@arise.tech",
+			reqBody:          `{}`,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid aliases input, aliases must be a list of names"}`,
+		},
+		{
+			name:             "should return 400 when an alias is empty",
+			email:            "admin@arise.tech",
+			reqBody:          `{"aliases":["k8s",""]}`,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid aliases input, aliases must be a list of names"}`,
+		},
+		{
+			name:             "should return 409 when an alias is taken",
+			email:            "admin@arise.tech",
+			reqBody:          `{"aliases":["Docker"]}`,
+			storageErr:       aliasTakenError,
+			expectedStatus:   http.StatusConflict,
+			expectedResponse: `{"status":"error","message":"an alias is already the name or an alias of another skill"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockAdminStorage{hardSkill: &HardSkill{ID: mockSkillID, Name: "Kubernetes"}, err: tc.storageErr}
+			engine := adminEngine(NewSkillAdminHandler(st, []string{"admin@arise.tech"}), tc.email)
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/admin/hard-skills/"+mockSkillID.Hex()+"/aliases", strings.NewReader(tc.reqBody))
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedResponse != "" {
+				assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+			}
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, mockSkillID.Hex(), st.id)
+				assert.Equal(t, "admin@arise.tech", st.archiver)
+			}
+		})
+	}
+}
+
+func TestGetAllSkills(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should return 200 and every skill for an admin", func(t *testing.T) {
+		st := &mockAdminStorage{skill: &Skill{ID: mockSkillID, Name: "Go", Kind: KindTechnical}}
+		engine := adminEngine(NewSkillAdminHandler(st, []string{"admin@arise.tech"}), "admin@arise.tech")
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/skills", nil)
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"name":"Go"`)
+	})
+
+	t.Run("should return 403 when user is not an admin", func(t *testing.T) {
+		st := &mockAdminStorage{}
+		engine := adminEngine(NewSkillAdminHandler(st, []string{"admin@arise.tech"}), "lead@arise.tech")
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/hard-skills", nil)
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
+
+func TestValidRubric(t *testing.T) {
+	assert.True(t, ValidRubric([]SkillLevel{{Level: 1}, {Level: 2}, {Level: 3}}))
+	assert.False(t, ValidRubric([]SkillLevel{{Level: 2}, {Level: 1}}))
+	assert.False(t, ValidRubric([]SkillLevel{{Level: 1}, {Level: 3}}))
+	assert.False(t, ValidRubric([]SkillLevel{}))
+}
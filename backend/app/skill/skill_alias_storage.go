@@ -0,0 +1,60 @@
+package skill
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var aliasTakenError = SkillStorageError{message: "an alias is already the name or an alias of another skill"}
+
+func (s *storage) SetSkillAliases(ctx context.Context, id string, aliases []string, email string) (*Skill, error) {
+	return setAliases[Skill](ctx, s.db.Collection(skillCollection), id, aliases, email)
+}
+
+func (s *storage) SetHardSkillAliases(ctx context.Context, id string, aliases []string, email string) (*HardSkill, error) {
+	return setAliases[HardSkill](ctx, s.db.Collection(hardSkillCollection), id, aliases, email)
+}
+
+// setAliases replaces the aliases of a skill. None of them may be the name or
+// an alias of another skill, so searching for it can't find the wrong one.
+func setAliases[T any](ctx context.Context, coll *mongo.Collection, id string, aliases []string, email string) (*T, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, invalidSkillIdError
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	kept := cleanAliases(aliases)
+	for _, alias := range kept {
+		if err := nameTaken(ctx, coll, alias, oid); err != nil {
+			if err == skillNameTakenError {
+				return nil, aliasTakenError
+			}
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	return updateOne[T](ctx, coll, bson.M{"_id": oid}, bson.M{"aliases": kept, "updatedBy": email, "updatedAt": now})
+}
+
+// cleanAliases trims the aliases and keeps each one once, whatever its case.
+func cleanAliases(aliases []string) []string {
+	kept := []string{}
+	seen := make(map[string]bool)
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		if alias == "" || seen[strings.ToLower(alias)] {
+			continue
+		}
+		seen[strings.ToLower(alias)] = true
+		kept = append(kept, alias)
+	}
+	return kept
+}
@@ -0,0 +1,469 @@
+package skill
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	CatalogFormatCSV  = "csv"
+	CatalogFormatYAML = "yaml"
+)
+
+// CatalogImportActor is who the skills imported from the command line were
+// updated by.
+const CatalogImportActor = "catalog-import"
+
+// What an import does to a skill of the catalog.
+const (
+	CatalogAdded     = "added"
+	CatalogUpdated   = "updated"
+	CatalogUnchanged = "unchanged"
+)
+
+// Catalog is the skill catalog as exported to and imported from CSV or YAML,
+// where skills are told apart by name. Levels are the descriptions of the
+// levels of a hard skill's rubric, from level 1 up.
+type Catalog struct {
+	Skills     []CatalogSkill     `json:"skills" yaml:"skills"`
+	HardSkills []CatalogHardSkill `json:"hardSkills" yaml:"hardSkills"`
+}
+
+type CatalogSkill struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+	Logo        string `json:"logo,omitempty" yaml:"logo,omitempty"`
+	Kind        string `json:"kind" yaml:"kind"`
+
+	at string
+}
+
+type CatalogHardSkill struct {
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description" yaml:"description"`
+	JobRole     []string `json:"jobRole" yaml:"jobRole"`
+	Sort        int      `json:"sort" yaml:"sort"`
+	Levels      []string `json:"levels" yaml:"levels"`
+
+	at string
+}
+
+// CatalogProblem is why an imported skill can't be taken. At is where it is
+// in the file: a CSV line or a YAML list entry.
+type CatalogProblem struct {
+	At      string `json:"at"`
+	Message string `json:"message"`
+}
+
+// CatalogError lists every problem of an imported catalog. Nothing is
+// imported until there are none.
+type CatalogError struct {
+	Problems []CatalogProblem
+}
+
+func (e CatalogError) Error() string {
+	return fmt.Sprintf("the catalog has %d problem(s), nothing was imported", len(e.Problems))
+}
+
+// CatalogChange is what importing a skill does to the catalog. Fields are the
+// ones an update changes.
+type CatalogChange struct {
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	Change string   `json:"change"`
+	Fields []string `json:"fields,omitempty"`
+
+	skill     *Skill
+	hardSkill *HardSkill
+}
+
+// CatalogDiff is what an import did or, on a dry run, would do, skills first
+// and then hard skills, in the order of the file. Skills of the catalog left
+// out of the file are kept as they are.
+type CatalogDiff struct {
+	DryRun    bool            `json:"dryRun"`
+	Added     int             `json:"added"`
+	Updated   int             `json:"updated"`
+	Unchanged int             `json:"unchanged"`
+	Changes   []CatalogChange `json:"changes"`
+}
+
+var catalogCSVHeader = []string{"kind", "name", "description", "logo", "jobRole", "sort"}
+
+// NewCatalog exports the skills and hard skills that aren't archived.
+func NewCatalog(skills []Skill, hardSkills []HardSkill) Catalog {
+	c := Catalog{Skills: []CatalogSkill{}, HardSkills: []CatalogHardSkill{}}
+	for _, sk := range skills {
+		if sk.ArchivedAt != nil {
+			continue
+		}
+		c.Skills = append(c.Skills, CatalogSkill{Name: sk.Name, Description: sk.Description, Logo: sk.Logo, Kind: sk.Kind})
+	}
+	for _, hs := range hardSkills {
+		if hs.ArchivedAt != nil {
+			continue
+		}
+		ch := CatalogHardSkill{Name: hs.Name, Description: string(hs.Description), Sort: hs.Sort, JobRole: []string{}, Levels: []string{}}
+		for _, role := range hs.JobRole {
+			ch.JobRole = append(ch.JobRole, string(role))
+		}
+		for _, l := range hs.SkillLevel {
+			ch.Levels = append(ch.Levels, string(l.LevelDescription))
+		}
+		c.HardSkills = append(c.HardSkills, ch)
+	}
+	return c
+}
+
+// WriteCatalog writes the catalog as CSV or YAML.
+func WriteCatalog(w io.Writer, c Catalog, format string) error {
+	if format == CatalogFormatYAML {
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(c); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+	return c.writeCSV(w)
+}
+
+// writeCSV writes one row per skill, the soft and technical ones first, with
+// a level column per level of the longest rubric.
+func (c Catalog) writeCSV(w io.Writer) error {
+	levels := 0
+	for _, hs := range c.HardSkills {
+		levels = max(levels, len(hs.Levels))
+	}
+	header := append([]string{}, catalogCSVHeader...)
+	for i := 1; i <= levels; i++ {
+		header = append(header, fmt.Sprintf("level%d", i))
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, sk := range c.Skills {
+		row := []string{sk.Kind, sk.Name, sk.Description, sk.Logo, "", ""}
+		row = append(row, make([]string, levels)...)
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	for _, hs := range c.HardSkills {
+		row := []string{KindHard, hs.Name, hs.Description, "", strings.Join(hs.JobRole, ";"), strconv.Itoa(hs.Sort)}
+		row = append(row, hs.Levels...)
+		row = append(row, make([]string, levels-len(hs.Levels))...)
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadCatalog reads a catalog from CSV or YAML and checks it, returning a
+// CatalogError with every problem found.
+func ReadCatalog(r io.Reader, format string) (Catalog, error) {
+	var c Catalog
+	var problems []CatalogProblem
+	if format == CatalogFormatYAML {
+		c, problems = readYAML(r)
+	} else {
+		c, problems = readCSV(r)
+	}
+	problems = append(problems, c.Check()...)
+	if len(problems) > 0 {
+		return Catalog{}, CatalogError{Problems: problems}
+	}
+	return c, nil
+}
+
+func readYAML(r io.Reader) (Catalog, []CatalogProblem) {
+	var c Catalog
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&c); err != nil && !errors.Is(err, io.EOF) {
+		return Catalog{}, []CatalogProblem{{At: "file", Message: err.Error()}}
+	}
+	for i := range c.Skills {
+		c.Skills[i].at = fmt.Sprintf("skills[%d]", i)
+	}
+	for i := range c.HardSkills {
+		c.HardSkills[i].at = fmt.Sprintf("hardSkills[%d]", i)
+	}
+	return c, nil
+}
+
+// readCSV reads the rows of a CSV catalog, its columns found by the names in
+// the header. Job roles are separated by semicolons and levels end at the
+// first empty level column.
+func readCSV(r io.Reader) (Catalog, []CatalogProblem) {
+	c := Catalog{Skills: []CatalogSkill{}, HardSkills: []CatalogHardSkill{}}
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return c, []CatalogProblem{{At: "line 1", Message: "cannot read the header: " + err.Error()}}
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), " ", ""))] = i
+	}
+	for _, name := range []string{"kind", "name"} {
+		if _, ok := columns[name]; !ok {
+			return c, []CatalogProblem{{At: "line 1", Message: fmt.Sprintf("the header has no %s column", name)}}
+		}
+	}
+
+	problems := []CatalogProblem{}
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := cr.FieldPos(0)
+		at := fmt.Sprintf("line %d", line)
+		if err != nil {
+			problems = append(problems, CatalogProblem{At: at, Message: err.Error()})
+			continue
+		}
+		cell := func(name string) string {
+			if i, ok := columns[strings.ToLower(name)]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		if strings.TrimSpace(strings.Join(row, "")) == "" {
+			continue
+		}
+
+		switch kind := strings.ToLower(cell("kind")); kind {
+		case KindSoft, KindTechnical:
+			c.Skills = append(c.Skills, CatalogSkill{Name: cell("name"), Description: cell("description"), Logo: cell("logo"), Kind: kind, at: at})
+		case KindHard:
+			hs := CatalogHardSkill{Name: cell("name"), Description: cell("description"), JobRole: []string{}, Levels: []string{}, at: at}
+			for _, role := range strings.Split(cell("jobRole"), ";") {
+				if role = strings.TrimSpace(role); role != "" {
+					hs.JobRole = append(hs.JobRole, role)
+				}
+			}
+			if s := cell("sort"); s != "" {
+				if hs.Sort, err = strconv.Atoi(s); err != nil {
+					problems = append(problems, CatalogProblem{At: at, Message: fmt.Sprintf("sort %q is not a number", s)})
+				}
+			}
+			for i := 1; ; i++ {
+				level := cell(fmt.Sprintf("level%d", i))
+				if level == "" {
+					break
+				}
+				hs.Levels = append(hs.Levels, level)
+			}
+			c.HardSkills = append(c.HardSkills, hs)
+		default:
+			problems = append(problems, CatalogProblem{At: at, Message: fmt.Sprintf("kind %q must be soft, technical or hard", kind)})
+		}
+	}
+	return c, problems
+}
+
+// Check lists what is missing or wrong in the skills of a catalog, and names
+// used twice.
+func (c Catalog) Check() []CatalogProblem {
+	problems := []CatalogProblem{}
+	add := func(at string, message string) {
+		problems = append(problems, CatalogProblem{At: at, Message: message})
+	}
+
+	names := make(map[string]bool)
+	for _, sk := range c.Skills {
+		switch {
+		case sk.Name == "":
+			add(sk.at, "name is required")
+		case names[strings.ToLower(sk.Name)]:
+			add(sk.at, fmt.Sprintf("%q is in the file twice", sk.Name))
+		}
+		names[strings.ToLower(sk.Name)] = true
+		if sk.Description == "" {
+			add(sk.at, "description is required")
+		}
+		if sk.Kind != KindSoft && sk.Kind != KindTechnical {
+			add(sk.at, fmt.Sprintf("kind %q must be soft or technical", sk.Kind))
+		}
+	}
+
+	hardNames := make(map[string]bool)
+	for _, hs := range c.HardSkills {
+		switch {
+		case hs.Name == "":
+			add(hs.at, "name is required")
+		case hardNames[strings.ToLower(hs.Name)]:
+			add(hs.at, fmt.Sprintf("%q is in the file twice", hs.Name))
+		}
+		hardNames[strings.ToLower(hs.Name)] = true
+		if hs.Description == "" {
+			add(hs.at, "description is required")
+		}
+		if len(hs.JobRole) == 0 || slices.Contains(hs.JobRole, "") {
+			add(hs.at, "at least one job role is required")
+		}
+		if hs.Sort < 0 {
+			add(hs.at, "sort can't be negative")
+		}
+		if len(hs.Levels) == 0 || slices.Contains(hs.Levels, "") {
+			add(hs.at, "at least one level is required and levels can't be empty")
+		}
+	}
+	return problems
+}
+
+// DiffCatalog matches the imported skills with the skills and hard skills of
+// the catalog by name, and lists what importing them does. An imported name
+// that is another skill's alias, or its name in another case, is a problem.
+func DiffCatalog(imported Catalog, skills []Skill, hardSkills []HardSkill) (CatalogDiff, []CatalogProblem) {
+	diff := CatalogDiff{Changes: []CatalogChange{}}
+	problems := []CatalogProblem{}
+	count := func(change CatalogChange) {
+		switch change.Change {
+		case CatalogAdded:
+			diff.Added++
+		case CatalogUpdated:
+			diff.Updated++
+		default:
+			diff.Unchanged++
+		}
+		diff.Changes = append(diff.Changes, change)
+	}
+
+	for _, cs := range imported.Skills {
+		change := CatalogChange{Kind: cs.Kind, Name: cs.Name, Change: CatalogAdded}
+		i := slices.IndexFunc(skills, func(sk Skill) bool { return sk.Name == cs.Name })
+		if i >= 0 {
+			change.skill = &skills[i]
+			change.Fields = skillFields(cs, skills[i])
+		} else if clash := clashOf(cs.Name, skills, func(sk Skill) (string, []string) { return sk.Name, sk.Aliases }); clash != "" {
+			problems = append(problems, CatalogProblem{At: cs.at, Message: fmt.Sprintf("%q is already the name or an alias of %q", cs.Name, clash)})
+		}
+		count(withChange(change))
+	}
+
+	for _, ch := range imported.HardSkills {
+		change := CatalogChange{Kind: KindHard, Name: ch.Name, Change: CatalogAdded}
+		i := slices.IndexFunc(hardSkills, func(hs HardSkill) bool { return hs.Name == ch.Name })
+		if i >= 0 {
+			change.hardSkill = &hardSkills[i]
+			change.Fields = hardSkillFields(ch, hardSkills[i])
+		} else if clash := clashOf(ch.Name, hardSkills, func(hs HardSkill) (string, []string) { return hs.Name, hs.Aliases }); clash != "" {
+			problems = append(problems, CatalogProblem{At: ch.at, Message: fmt.Sprintf("%q is already the name or an alias of %q", ch.Name, clash)})
+		}
+		count(withChange(change))
+	}
+	return diff, problems
+}
+
+// withChange tells an update from an unchanged skill by the fields it
+// changes.
+func withChange(change CatalogChange) CatalogChange {
+	if change.skill == nil && change.hardSkill == nil {
+		return change
+	}
+	change.Change = CatalogUpdated
+	if len(change.Fields) == 0 {
+		change.Change = CatalogUnchanged
+	}
+	return change
+}
+
+// clashOf is the name of the skill that already has the given name in
+// another case or as an alias.
+func clashOf[T any](name string, all []T, namesOf func(T) (string, []string)) string {
+	for _, item := range all {
+		own, aliases := namesOf(item)
+		if strings.EqualFold(own, name) || slices.ContainsFunc(aliases, func(alias string) bool { return strings.EqualFold(alias, name) }) {
+			return own
+		}
+	}
+	return ""
+}
+
+// skillFields lists the fields an imported skill changes. An empty logo keeps
+// the one the skill has.
+func skillFields(cs CatalogSkill, sk Skill) []string {
+	fields := []string{}
+	if cs.Description != sk.Description {
+		fields = append(fields, "description")
+	}
+	if cs.Logo != "" && cs.Logo != sk.Logo {
+		fields = append(fields, "logo")
+	}
+	if cs.Kind != sk.Kind {
+		fields = append(fields, "kind")
+	}
+	return fields
+}
+
+func hardSkillFields(ch CatalogHardSkill, hs HardSkill) []string {
+	imported := ch.hardSkill()
+	fields := []string{}
+	if imported.Description != hs.Description {
+		fields = append(fields, "description")
+	}
+	if !slices.Equal(imported.JobRole, hs.JobRole) {
+		fields = append(fields, "jobRole")
+	}
+	if imported.Sort != hs.Sort {
+		fields = append(fields, "sort")
+	}
+	if !slices.Equal(imported.SkillLevel, hs.SkillLevel) {
+		fields = append(fields, "levels")
+	}
+	return fields
+}
+
+// skill is the imported skill as it goes into the catalog, over the one it
+// updates if any.
+func (cs CatalogSkill) skill(current *Skill) Skill {
+	sk := Skill{Name: cs.Name, Description: cs.Description, Logo: cs.Logo, Kind: cs.Kind}
+	if current != nil {
+		sk.ID = current.ID
+		if sk.Logo == "" {
+			sk.Logo = current.Logo
+		}
+	}
+	return sk
+}
+
+func (ch CatalogHardSkill) hardSkill() HardSkill {
+	hs := HardSkill{Name: ch.Name, Description: DescriptionEnum(ch.Description), Sort: ch.Sort, JobRole: []JobRole{}, SkillLevel: []SkillLevel{}}
+	for _, role := range ch.JobRole {
+		hs.JobRole = append(hs.JobRole, JobRole(role))
+	}
+	for i, desc := range ch.Levels {
+		hs.SkillLevel = append(hs.SkillLevel, SkillLevel{Level: i + 1, LevelDescription: LevelDescription(desc)})
+	}
+	return hs
+}
+
+// skillID is the ID of the skill a change updates, nil for a new one.
+func (change CatalogChange) skillID() primitive.ObjectID {
+	switch {
+	case change.skill != nil:
+		return change.skill.ID
+	case change.hardSkill != nil:
+		return change.hardSkill.ID
+	}
+	return primitive.NilObjectID
+}
@@ -0,0 +1,150 @@
+package skill
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+)
+
+type CatalogStorage interface {
+	ExportCatalog(ctx context.Context) (Catalog, error)
+	ImportCatalog(ctx context.Context, imported Catalog, email string, dryRun bool) (*CatalogDiff, error)
+}
+
+type catalogHandler struct {
+	storage CatalogStorage
+	admins  []string
+}
+
+func NewCatalogHandler(st CatalogStorage, admins []string) *catalogHandler {
+	return &catalogHandler{
+		storage: st,
+		admins:  admins,
+	}
+}
+
+var invalidCatalogFormatError = skillHandlerError{message: "format must be csv or yaml"}
+var emptyCatalogError = skillHandlerError{message: "the request body must be the catalog file"}
+
+var catalogContentTypes = map[string]string{
+	CatalogFormatCSV:  "text/csv; charset=utf-8",
+	CatalogFormatYAML: "application/yaml; charset=utf-8",
+}
+
+// ExportCatalog godoc
+//
+//	@summary		ExportCatalog
+//	@description	Download the skills and hard skills of the catalog, with the levels of their rubrics and their job roles, as a CSV or YAML file that can be edited and imported back. Archived skills are left out.
+//	@tags			skill
+//	@id				ExportCatalog
+//	@security		BearerAuth
+//	@produce		text/csv
+//	@produce		application/yaml
+//	@param			format	query		string			false	"csv (default) or yaml"
+//	@response		200		{file}		file			"OK"
+//	@response		400		{object}	app.Response	"Bad Request"
+//	@response		403		{object}	app.Response	"Not an admin"
+//	@response		450		{object}	app.Response	"Store Error"
+//	@router			/admin/catalog/export [get]
+func (h *catalogHandler) ExportCatalog(c app.Context) {
+	if !app.RequireAdmin(c, h.admins, notSkillAdminError) {
+		return
+	}
+	format, contentType, ok := catalogFormat(c)
+	if !ok {
+		return
+	}
+
+	catalog, err := h.storage.ExportCatalog(c.Ctx())
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+	var buf bytes.Buffer
+	if err := WriteCatalog(&buf, catalog, format); err != nil {
+		c.InternalServerError(err)
+		return
+	}
+
+	filename := fmt.Sprintf("skill-catalog-%s.%s", time.Now().Format(time.DateOnly), format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// ImportCatalog godoc
+//
+//	@summary		ImportCatalog
+//	@description	Add or update skills and hard skills by name from a CSV or YAML file laid out as the export, sent as the request body. Skills left out of the file are kept as they are. Nothing is imported while the file has problems, which are listed line by line. With dryRun, only list what the import would change.
+//	@tags			skill
+//	@id				ImportCatalog
+//	@security		BearerAuth
+//	@accept			text/csv
+//	@accept			application/yaml
+//	@produce		json
+//	@param			format	query		string					false	"csv (default) or yaml"
+//	@param			dryRun	query		bool					false	"Only list the changes"
+//	@param			file	body		string					true	"Catalog file"
+//	@response		200		{object}	skill.CatalogDiff		"OK"
+//	@response		400		{object}	app.Response			"Bad Request"
+//	@response		403		{object}	app.Response			"Not an admin"
+//	@response		422		{array}		skill.CatalogProblem	"Problems of the file"
+//	@response		450		{object}	app.Response			"Store Error"
+//	@router			/admin/catalog/import [post]
+func (h *catalogHandler) ImportCatalog(c app.Context) {
+	if !app.RequireAdmin(c, h.admins, notSkillAdminError) {
+		return
+	}
+	format, _, ok := catalogFormat(c)
+	if !ok {
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil || len(bytes.TrimSpace(body)) == 0 {
+		c.BadRequest(emptyCatalogError)
+		return
+	}
+	imported, err := ReadCatalog(bytes.NewReader(body), format)
+	if err != nil {
+		catalogError(c, err)
+		return
+	}
+
+	diff, err := h.storage.ImportCatalog(c.Ctx(), imported, c.GetString("email"), c.Query("dryRun") == "true")
+	if err != nil {
+		catalogError(c, err)
+		return
+	}
+
+	c.OK(diff)
+}
+
+// catalogFormat is the format asked for, csv by default.
+func catalogFormat(c app.Context) (string, string, bool) {
+	format := c.Query("format")
+	if format == "" {
+		format = CatalogFormatCSV
+	}
+	contentType, ok := catalogContentTypes[format]
+	if !ok {
+		c.BadRequest(invalidCatalogFormatError)
+		return "", "", false
+	}
+	return format, contentType, true
+}
+
+// catalogError lists the problems of an imported catalog. Names clashing
+// with the catalog are among them, so anything else failed in the store.
+func catalogError(c app.Context, err error) {
+	var problems CatalogError
+	if errors.As(err, &problems) {
+		c.UnprocessableEntity(problems.Problems, err)
+		return
+	}
+	c.StoreError(err)
+}
@@ -0,0 +1,206 @@
+package skill
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type mockCatalogStorage struct {
+	catalog  Catalog
+	imported *Catalog
+	email    string
+	dryRun   bool
+	err      error
+}
+
+func (m *mockCatalogStorage) ExportCatalog(ctx context.Context) (Catalog, error) {
+	return m.catalog, m.err
+}
+
+func (m *mockCatalogStorage) ImportCatalog(ctx context.Context, imported Catalog, email string, dryRun bool) (*CatalogDiff, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.imported = &imported
+	m.email = email
+	m.dryRun = dryRun
+	return &CatalogDiff{DryRun: dryRun, Added: len(imported.Skills) + len(imported.HardSkills), Changes: []CatalogChange{}}, nil
+}
+
+func catalogEngine(h *catalogHandler, email string) *gin.Engine {
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Set("email", email)
+	})
+	engine.GET("/admin/catalog/export", app.NewGinHandler(h.ExportCatalog, zap.NewNop()))
+	engine.POST("/admin/catalog/import", app.NewGinHandler(h.ImportCatalog, zap.NewNop()))
+	return engine
+}
+
+func TestExportCatalog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	catalog := Catalog{Skills: []CatalogSkill{{Name: "Teamwork", Description: "Working together", Kind: KindSoft}}, HardSkills: []CatalogHardSkill{}}
+
+	testCases := []struct {
+		name                string
+		email               string
+		query               string
+		storageErr          error
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "should return 200 and the catalog as csv by default",
+			email:               "admin@arise.tech",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody:        "kind,name,description,logo,jobRole,sort\nsoft,Teamwork,Working together,,,\n",
+		},
+		{
+			name:                "should return 200 and the catalog as yaml",
+			email:               "admin@arise.tech",
+			query:               "?format=yaml",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/yaml; charset=utf-8",
+			expectedBody:        "skills:\n  - name: Teamwork\n    description: Working together\n    kind: soft\nhardSkills: []\n",
+		},
+		{
+			name:           "should return 403 when user is not an admin",
+			email:          "lead@arise.tech",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"status":"error","message":"only admins can manage the skill catalog"}`,
+		},
+		{
+			name:           "should return 400 when format is unknown",
+			email:          "admin@arise.tech",
+			query:          "?format=xlsx",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"status":"error","message":"format must be csv or yaml"}`,
+		},
+		{
+			name:           "should return 450 when storage fails",
+			email:          "admin@arise.tech",
+			storageErr:     errors.New("connection lost"),
+			expectedStatus: 450,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockCatalogStorage{catalog: catalog, err: tc.storageErr}
+			engine := catalogEngine(NewCatalogHandler(st, []string{"admin@arise.tech"}), tc.email)
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/admin/catalog/export"+tc.query, nil)
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedContentType != "" {
+				assert.Equal(t, tc.expectedContentType, rec.Header().Get("Content-Type"))
+				assert.Contains(t, rec.Header().Get("Content-Disposition"), "attachment; filename=\"skill-catalog-")
+			}
+			if tc.expectedBody != "" {
+				assert.Equal(t, tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestImportCatalog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	file := "kind,name,description\nsoft,Teamwork,Working together\n"
+
+	testCases := []struct {
+		name             string
+		email            string
+		query            string
+		reqBody          string
+		storageErr       error
+		expectedStatus   int
+		expectedDryRun   bool
+		expectedResponse string
+	}{
+		{
+			name:             "should return 200 and what was imported",
+			email:            "admin@arise.tech",
+			reqBody:          file,
+			expectedStatus:   http.StatusOK,
+			expectedResponse: `{"status":"success","message":"","data":{"dryRun":false,"added":1,"updated":0,"unchanged":0,"changes":[]}}`,
+		},
+		{
+			name:           "should return 200 and pass on a dry run",
+			email:          "admin@arise.tech",
+			query:          "?dryRun=true",
+			reqBody:        file,
+			expectedStatus: http.StatusOK,
+			expectedDryRun: true,
+		},
+		{
+			name:             "should return 403 when user is not an admin",
+			email:            "lead@arise.tech",
+			reqBody:          file,
+			expectedStatus:   http.StatusForbidden,
+			expectedResponse: `{"status":"error","message":"only admins can manage the skill catalog"}`,
+		},
+		{
+			name:             "should return 400 when the body is empty",
+			email:            "admin@arise.tech",
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"the request body must be the catalog file"}`,
+		},
+		{
+			name:             "should return 422 and the problems of the file",
+			email:            "admin@arise.tech",
+			query:            "?format=yaml",
+			reqBody:          "skills:\n  - name: Teamwork\n    kind: soft\n",
+			expectedStatus:   http.StatusUnprocessableEntity,
+			expectedResponse: `{"status":"error","message":"the catalog has 1 problem(s), nothing was imported","data":[{"at":"skills[0]","message":"description is required"}]}`,
+		},
+		{
+			name:             "should return 422 when a name clashes with the catalog",
+			email:            "admin@arise.tech",
+			reqBody:          file,
+			storageErr:       CatalogError{Problems: []CatalogProblem{{At: "line 2", Message: `"Teamwork" is already the name or an alias of "Team work"`}}},
+			expectedStatus:   http.StatusUnprocessableEntity,
+			expectedResponse: `{"status":"error","message":"the catalog has 1 problem(s), nothing was imported","data":[{"at":"line 2","message":"\"Teamwork\" is already the name or an alias of \"Team work\""}]}`,
+		},
+		{
+			name:           "should return 450 when storage fails",
+			email:          "admin@arise.tech",
+			reqBody:        file,
+			storageErr:     errors.New("connection lost"),
+			expectedStatus: 450,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockCatalogStorage{err: tc.storageErr}
+			engine := catalogEngine(NewCatalogHandler(st, []string{"admin@arise.tech"}), tc.email)
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/admin/catalog/import"+tc.query, strings.NewReader(tc.reqBody))
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedResponse != "" {
+				assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+			}
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, "admin@arise.tech", st.email)
+				assert.Equal(t, tc.expectedDryRun, st.dryRun)
+				assert.Equal(t, []CatalogSkill{{Name: "Teamwork", Description: "Working together", Kind: KindSoft, at: "line 2"}}, st.imported.Skills)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package skill
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ExportCatalog exports the skills and hard skills that aren't archived.
+func (s *storage) ExportCatalog(ctx context.Context) (Catalog, error) {
+	skills, err := s.GetAllSkills(ctx)
+	if err != nil {
+		return Catalog{}, err
+	}
+	hardSkills, err := s.GetAllHardSkills(ctx)
+	if err != nil {
+		return Catalog{}, err
+	}
+	return NewCatalog(skills, hardSkills), nil
+}
+
+// ImportCatalog adds the imported skills the catalog doesn't have and updates
+// the ones it has by name, unless it is a dry run. Hard skills keep their
+// category, prerequisites and aliases, and new levels are a new version of
+// their rubric in effect from now. It isn't a transaction, but importing the
+// same file again picks up where a failed import stopped.
+func (s *storage) ImportCatalog(ctx context.Context, imported Catalog, email string, dryRun bool) (*CatalogDiff, error) {
+	skills, err := s.GetAllSkills(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hardSkills, err := s.GetAllHardSkills(ctx)
+	if err != nil {
+		return nil, err
+	}
+	diff, problems := DiffCatalog(imported, skills, hardSkills)
+	if len(problems) > 0 {
+		return nil, CatalogError{Problems: problems}
+	}
+	diff.DryRun = dryRun
+	if dryRun {
+		return &diff, nil
+	}
+
+	now := time.Now()
+	for i, cs := range imported.Skills {
+		change := diff.Changes[i]
+		sk := cs.skill(change.skill)
+		sk.UpdatedBy = email
+		sk.UpdatedAt = &now
+		switch change.Change {
+		case CatalogAdded:
+			_, err = s.InsertSkill(ctx, sk)
+		case CatalogUpdated:
+			_, err = s.UpdateSkill(ctx, change.skillID().Hex(), sk)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot import skill %q: %w", cs.Name, err)
+		}
+	}
+
+	for i, ch := range imported.HardSkills {
+		change := diff.Changes[len(imported.Skills)+i]
+		hs := ch.hardSkill()
+		hs.UpdatedBy = email
+		hs.UpdatedAt = &now
+		switch change.Change {
+		case CatalogAdded:
+			_, err = s.InsertHardSkill(ctx, hs, now)
+		case CatalogUpdated:
+			hs.CategoryID = change.hardSkill.CategoryID
+			hs.Prerequisites = change.hardSkill.Prerequisites
+			_, err = s.UpdateHardSkill(ctx, change.skillID().Hex(), hs, now)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot import hard skill %q: %w", ch.Name, err)
+		}
+	}
+	return &diff, nil
+}
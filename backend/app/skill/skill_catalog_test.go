@@ -0,0 +1,246 @@
+package skill
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func catalogFixtures() ([]Skill, []HardSkill) {
+	archivedAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	skills := []Skill{
+		{ID: mockOID(1), Name: "JavaScript", Description: "Scripting for the web", Logo: "js.svg", Kind: KindTechnical, Aliases: []string{"JS"}},
+		{ID: mockOID(2), Name: "Communication", Description: "Getting ideas across", Kind: KindSoft},
+		{ID: mockOID(3), Name: "Perl", Description: "Old scripts", Kind: KindTechnical, ArchivedAt: &archivedAt},
+	}
+	hardSkills := []HardSkill{
+		{
+			ID:          mockOID(4),
+			Name:        "Kubernetes",
+			Description: "Running containers, at scale",
+			JobRole:     []JobRole{"devops", Backend},
+			Sort:        1,
+			SkillLevel: []SkillLevel{
+				{Level: 1, LevelDescription: "Runs a pod"},
+				{Level: 2, LevelDescription: "Writes a \"deployment\""},
+			},
+			Aliases: []string{"k8s"},
+		},
+		{
+			ID:          mockOID(5),
+			Name:        "Docker",
+			Description: "Building images",
+			JobRole:     []JobRole{"devops"},
+			Sort:        2,
+			SkillLevel:  []SkillLevel{{Level: 1, LevelDescription: "Runs a container"}},
+		},
+	}
+	return skills, hardSkills
+}
+
+func TestCatalogRoundTrip(t *testing.T) {
+	skills, hardSkills := catalogFixtures()
+	catalog := NewCatalog(skills, hardSkills)
+
+	for _, format := range []string{CatalogFormatCSV, CatalogFormatYAML} {
+		t.Run("should read back what was written as "+format, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WriteCatalog(&buf, catalog, format))
+
+			read, err := ReadCatalog(&buf, format)
+
+			require.NoError(t, err)
+			diff, problems := DiffCatalog(read, skills, hardSkills)
+			assert.Empty(t, problems)
+			assert.Equal(t, 0, diff.Added)
+			assert.Equal(t, 0, diff.Updated)
+			assert.Equal(t, 4, diff.Unchanged)
+		})
+	}
+}
+
+func TestWriteCatalogCSV(t *testing.T) {
+	skills, hardSkills := catalogFixtures()
+	var buf bytes.Buffer
+
+	require.NoError(t, WriteCatalog(&buf, NewCatalog(skills, hardSkills), CatalogFormatCSV))
+
+	assert.Equal(t, `kind,name,description,logo,jobRole,sort,level1,level2
+technical,JavaScript,Scripting for the web,js.svg,,,,
+soft,Communication,Getting ideas across,,,,,
+hard,Kubernetes,"Running containers, at scale",,devops;backend,1,Runs a pod,"Writes a ""deployment"""
+hard,Docker,Building images,,devops,2,Runs a container,
+`, buf.String())
+}
+
+func TestReadCatalog(t *testing.T) {
+	testCases := []struct {
+		name             string
+		format           string
+		file             string
+		expected         Catalog
+		expectedProblems []CatalogProblem
+	}{
+		{
+			name:   "should read a spreadsheet with its columns in any order",
+			format: CatalogFormatCSV,
+			file: "Name,Kind,Level 1,Level 2,Job Role,Description\n" +
+				"Go,hard,Writes a CLI,Writes a service, backend ; devops ,A compiled language\n" +
+				",,,,,\n" +
+				"Teamwork,Soft,,,,Working together\n",
+			expected: Catalog{
+				Skills: []CatalogSkill{{Name: "Teamwork", Description: "Working together", Kind: KindSoft}},
+				HardSkills: []CatalogHardSkill{{
+					Name:        "Go",
+					Description: "A compiled language",
+					JobRole:     []string{"backend", "devops"},
+					Levels:      []string{"Writes a CLI", "Writes a service"},
+				}},
+			},
+		},
+		{
+			name:   "should list the problems of every line",
+			format: CatalogFormatCSV,
+			file: "kind,name,description,jobRole,sort,level1\n" +
+				"hard,Go,,backend,first,Writes a CLI\n" +
+				"hard,Rust,Memory safe,,,\n" +
+				"people,Teamwork,Working together,,,\n" +
+				"soft,Teamwork,Working together,,,\n" +
+				"soft,teamwork,Together again,,,\n",
+			expectedProblems: []CatalogProblem{
+				{At: "line 2", Message: `sort "first" is not a number`},
+				{At: "line 4", Message: `kind "people" must be soft, technical or hard`},
+				{At: "line 6", Message: `"teamwork" is in the file twice`},
+				{At: "line 2", Message: "description is required"},
+				{At: "line 3", Message: "at least one job role is required"},
+				{At: "line 3", Message: "at least one level is required and levels can't be empty"},
+			},
+		},
+		{
+			name:             "should need a name column",
+			format:           CatalogFormatCSV,
+			file:             "kind,title\nsoft,Teamwork\n",
+			expectedProblems: []CatalogProblem{{At: "line 1", Message: "the header has no name column"}},
+		},
+		{
+			name:   "should read YAML",
+			format: CatalogFormatYAML,
+			file: `hardSkills:
+  - name: Go
+    description: A compiled language
+    jobRole: [backend]
+    sort: 3
+    levels:
+      - Writes a CLI
+`,
+			expected: Catalog{
+				HardSkills: []CatalogHardSkill{{
+					Name:        "Go",
+					Description: "A compiled language",
+					JobRole:     []string{"backend"},
+					Sort:        3,
+					Levels:      []string{"Writes a CLI"},
+				}},
+			},
+		},
+		{
+			name:   "should tell which YAML entry has a problem",
+			format: CatalogFormatYAML,
+			file: `skills:
+  - name: Teamwork
+    description: Working together
+    kind: soft
+  - name: Listening
+    kind: soft
+`,
+			expectedProblems: []CatalogProblem{{At: "skills[1]", Message: "description is required"}},
+		},
+		{
+			name:   "should refuse unknown YAML fields",
+			format: CatalogFormatYAML,
+			file: `skills:
+  - name: Teamwork
+    descripton: Working together
+`,
+			expectedProblems: []CatalogProblem{{At: "file", Message: "yaml: unmarshal errors:\n  line 3: field descripton not found in type skill.CatalogSkill"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			catalog, err := ReadCatalog(strings.NewReader(tc.file), tc.format)
+
+			if tc.expectedProblems != nil {
+				require.Error(t, err)
+				assert.Equal(t, tc.expectedProblems, err.(CatalogError).Problems)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, catalogNames(tc.expected), catalogNames(catalog))
+			for i, hs := range tc.expected.HardSkills {
+				assert.Equal(t, hs.Description, catalog.HardSkills[i].Description)
+				assert.Equal(t, hs.JobRole, catalog.HardSkills[i].JobRole)
+				assert.Equal(t, hs.Sort, catalog.HardSkills[i].Sort)
+				assert.Equal(t, hs.Levels, catalog.HardSkills[i].Levels)
+			}
+		})
+	}
+}
+
+func catalogNames(c Catalog) []string {
+	names := []string{}
+	for _, sk := range c.Skills {
+		names = append(names, sk.Kind+":"+sk.Name)
+	}
+	for _, hs := range c.HardSkills {
+		names = append(names, KindHard+":"+hs.Name)
+	}
+	return names
+}
+
+func TestDiffCatalog(t *testing.T) {
+	skills, hardSkills := catalogFixtures()
+
+	t.Run("should add new skills and update changed ones by name", func(t *testing.T) {
+		imported := Catalog{
+			Skills: []CatalogSkill{
+				{Name: "JavaScript", Description: "Scripting for the web", Kind: KindTechnical},
+				{Name: "Communication", Description: "Getting ideas across clearly", Kind: KindSoft},
+				{Name: "Teamwork", Description: "Working together", Kind: KindSoft},
+			},
+			HardSkills: []CatalogHardSkill{
+				{Name: "Kubernetes", Description: "Running containers, at scale", JobRole: []string{"devops"}, Sort: 1, Levels: []string{"Runs a pod", "Writes a \"deployment\"", "Writes an operator"}},
+			},
+		}
+
+		diff, problems := DiffCatalog(imported, skills, hardSkills)
+
+		assert.Empty(t, problems)
+		assert.Equal(t, 1, diff.Added)
+		assert.Equal(t, 2, diff.Updated)
+		assert.Equal(t, 1, diff.Unchanged)
+		assert.Equal(t, CatalogUnchanged, diff.Changes[0].Change, "an empty logo keeps the logo")
+		assert.Equal(t, []string{"description"}, diff.Changes[1].Fields)
+		assert.Equal(t, CatalogAdded, diff.Changes[2].Change)
+		assert.Equal(t, []string{"jobRole", "levels"}, diff.Changes[3].Fields)
+		assert.Equal(t, mockOID(4), diff.Changes[3].skillID())
+	})
+
+	t.Run("should refuse a name that is an alias or another case of a skill", func(t *testing.T) {
+		imported := Catalog{
+			Skills:     []CatalogSkill{{Name: "JS", Description: "Scripting", Kind: KindTechnical, at: "line 2"}},
+			HardSkills: []CatalogHardSkill{{Name: "docker", Description: "Images", JobRole: []string{"devops"}, Levels: []string{"Runs"}, at: "line 3"}},
+		}
+
+		_, problems := DiffCatalog(imported, skills, hardSkills)
+
+		assert.Equal(t, []CatalogProblem{
+			{At: "line 2", Message: `"JS" is already the name or an alias of "JavaScript"`},
+			{At: "line 3", Message: `"docker" is already the name or an alias of "Docker"`},
+		}, problems)
+	})
+}
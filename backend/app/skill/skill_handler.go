@@ -3,6 +3,9 @@ package skill
 import (
 	"context"
 	"errors"
+	"slices"
+	"strconv"
+	"strings"
 
 	"gitdev.devops.krungthai.com/aster/ariskill/app"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -12,6 +15,8 @@ type Storage interface {
 	GetByKind(ctx context.Context, kind string) ([]Skill, error)
 	GetByID(ctx context.Context, oid string) (Skill, error)
 	GetByRole(ctx context.Context, role string) ([]HardSkill, error)
+	GetAllSkills(ctx context.Context) ([]Skill, error)
+	GetAllHardSkills(ctx context.Context) ([]HardSkill, error)
 }
 type skillHandler struct {
 	storage Storage
@@ -95,3 +100,51 @@ func (h *skillHandler) SkillByJobRole(c app.Context) {
 
 	c.OK(sk)
 }
+
+var invalidSearchError = skillHandlerError{message: "invalid search, q is required and limit must be a number"}
+
+// SearchSkills godoc
+//
+//	@summary		SearchSkills
+//	@description	Search soft, technical and hard skills by name or alias, such as "k8s" for Kubernetes, best match first. Case, spaces and punctuation are ignored and a typo or two is forgiven in longer searches. Archived skills are left out.
+//	@tags			skill
+//	@id				SearchSkills
+//	@security		BearerAuth
+//	@produce		json
+//	@param			q		query		string				true	"Search"
+//	@param			limit	query		int					false	"Most results to return, 20 by default and at most 50"
+//	@response		200		{array}		skill.SearchResult	"OK"
+//	@response		400		{object}	app.Response		"Bad Request"
+//	@response		450		{object}	app.Response		"Store Error"
+//	@router			/skills/search [get]
+func (h *skillHandler) SearchSkills(c app.Context) {
+	q := c.Query("q")
+	if strings.TrimSpace(q) == "" {
+		c.BadRequest(invalidSearchError)
+		return
+	}
+	limit := defaultSearchLimit
+	if c.Query("limit") != "" {
+		n, err := strconv.Atoi(c.Query("limit"))
+		if err != nil {
+			c.BadRequest(invalidSearchError)
+			return
+		}
+		limit = n
+	}
+
+	skills, err := h.storage.GetAllSkills(c.Ctx())
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+	hardSkills, err := h.storage.GetAllHardSkills(c.Ctx())
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+	skills = slices.DeleteFunc(skills, func(sk Skill) bool { return sk.ArchivedAt != nil })
+	hardSkills = slices.DeleteFunc(hardSkills, func(hs HardSkill) bool { return hs.ArchivedAt != nil })
+
+	c.OK(Search(q, skills, hardSkills, limit))
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"gitdev.devops.krungthai.com/aster/ariskill/app"
 	"github.com/gin-gonic/gin"
@@ -17,10 +18,11 @@ import (
 
 type mockStorage struct {
 	Storage
-	skills []Skill
-	skill  Skill
-	kind   string
-	err    error
+	skills     []Skill
+	hardSkills []HardSkill
+	skill      Skill
+	kind       string
+	err        error
 }
 
 func (m *mockStorage) GetByKind(ctx context.Context, kind string) ([]Skill, error) {
@@ -38,6 +40,20 @@ func (m *mockStorage) GetByID(ctx context.Context, id string) (Skill, error) {
 	return m.skill, nil
 }
 
+func (m *mockStorage) GetAllSkills(ctx context.Context) ([]Skill, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.skills, nil
+}
+
+func (m *mockStorage) GetAllHardSkills(ctx context.Context) ([]HardSkill, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.hardSkills, nil
+}
+
 // TODO: eliminate all the gin.Context and use app.Context instead
 
 func TestGetSkillsByKind(t *testing.T) {
@@ -192,3 +208,71 @@ func TestGetSkillByID(t *testing.T) {
 		assert.JSONEq(t, want, resp)
 	})
 }
+
+func TestSearchSkills(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	archivedAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name             string
+		query            string
+		storageErr       error
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "should return 200 and the matching skills best first",
+			query:            "?q=js",
+			expectedStatus:   http.StatusOK,
+			expectedResponse: `{"status":"success","message":"","data":[{"id":"000000000000000000000001","kind":"technical","name":"JavaScript","alias":"JS","score":95},{"id":"000000000000000000000004","kind":"technical","name":"Node.js","score":60}]}`,
+		},
+		{
+			name:             "should return 200 and leave out archived skills",
+			query:            "?q=perl",
+			expectedStatus:   http.StatusOK,
+			expectedResponse: `{"status":"success","message":"","data":[]}`,
+		},
+		{
+			name:             "should return 200 and keep results up to the limit",
+			query:            "?q=ja&limit=1",
+			expectedStatus:   http.StatusOK,
+			expectedResponse: `{"status":"success","message":"","data":[{"id":"000000000000000000000002","kind":"technical","name":"Java","score":80}]}`,
+		},
+		{
+			name:             "should return 400 when q is missing",
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid search, q is required and limit must be a number"}`,
+		},
+		{
+			name:             "should return 400 when limit is not a number",
+			query:            "?q=js&limit=all",
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid search, q is required and limit must be a number"}`,
+		},
+		{
+			name:             "should return 450 when storage fails",
+			query:            "?q=js",
+			storageErr:       errors.New("connection lost"),
+			expectedStatus:   450,
+			expectedResponse: `{"status":"error","message":"connection lost"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			skills, hardSkills := searchFixtures()
+			hardSkills = append(hardSkills, HardSkill{ID: mockOID(8), Name: "Perl", ArchivedAt: &archivedAt})
+			handler := NewSkillHandler(&mockStorage{skills: skills, hardSkills: hardSkills, err: tc.storageErr})
+
+			engine := gin.New()
+			engine.GET("/skills/search", app.NewGinHandler(handler.SearchSkills, zap.NewNop()))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/skills/search"+tc.query, nil)
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
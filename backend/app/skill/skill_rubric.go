@@ -0,0 +1,92 @@
+package skill
+
+import (
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Kinds of change a level goes through from one rubric version to another.
+const (
+	LevelAdded   = "added"
+	LevelRemoved = "removed"
+	LevelChanged = "changed"
+)
+
+// RubricVersion is the skill levels of a hard skill from the time they take
+// effect until the next version does. Cycles are measured against the version
+// in effect when they start.
+type RubricVersion struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	SkillID       primitive.ObjectID `json:"skillId" bson:"skillId"`
+	Version       int                `json:"version" bson:"version"`
+	EffectiveFrom time.Time          `json:"effectiveFrom" bson:"effectiveFrom"`
+	SkillLevel    []SkillLevel       `json:"skillLevel" bson:"skillLevel"`
+	CreatedBy     string             `json:"createdBy,omitempty" bson:"createdBy,omitempty"`
+	CreatedAt     time.Time          `json:"createdAt" bson:"createdAt"`
+}
+
+// LevelChange is how one level of a rubric differs between two versions.
+type LevelChange struct {
+	Level  int              `json:"level"`
+	Change string           `json:"change"`
+	Before LevelDescription `json:"before,omitempty"`
+	After  LevelDescription `json:"after,omitempty"`
+}
+
+type RubricDiff struct {
+	SkillID primitive.ObjectID `json:"skillId"`
+	From    int                `json:"from"`
+	To      int                `json:"to"`
+	Changes []LevelChange      `json:"changes"`
+}
+
+// RubricAt finds the version in effect at the given time among the versions
+// of one hard skill, nil when none is in effect yet.
+func RubricAt(versions []RubricVersion, at time.Time) *RubricVersion {
+	var found *RubricVersion
+	for i := range versions {
+		if versions[i].EffectiveFrom.After(at) {
+			continue
+		}
+		if found == nil || versions[i].Version > found.Version {
+			found = &versions[i]
+		}
+	}
+	return found
+}
+
+// DiffRubrics lists the levels added, removed or described differently from
+// one rubric version to another, by level.
+func DiffRubrics(from RubricVersion, to RubricVersion) RubricDiff {
+	before := make(map[int]LevelDescription)
+	for _, l := range from.SkillLevel {
+		before[l.Level] = l.LevelDescription
+	}
+	after := make(map[int]LevelDescription)
+	for _, l := range to.SkillLevel {
+		after[l.Level] = l.LevelDescription
+	}
+
+	changes := []LevelChange{}
+	for _, l := range to.SkillLevel {
+		desc, ok := before[l.Level]
+		switch {
+		case !ok:
+			changes = append(changes, LevelChange{Level: l.Level, Change: LevelAdded, After: l.LevelDescription})
+		case desc != l.LevelDescription:
+			changes = append(changes, LevelChange{Level: l.Level, Change: LevelChanged, Before: desc, After: l.LevelDescription})
+		}
+	}
+	for _, l := range from.SkillLevel {
+		if _, ok := after[l.Level]; !ok {
+			changes = append(changes, LevelChange{Level: l.Level, Change: LevelRemoved, Before: l.LevelDescription})
+		}
+	}
+	slices.SortStableFunc(changes, func(a, b LevelChange) int {
+		return a.Level - b.Level
+	})
+
+	return RubricDiff{SkillID: to.SkillID, From: from.Version, To: to.Version, Changes: changes}
+}
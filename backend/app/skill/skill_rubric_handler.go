@@ -0,0 +1,128 @@
+package skill
+
+import (
+	"context"
+	"strconv"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type RubricStorage interface {
+	GetRubricVersions(ctx context.Context, skillIDs []primitive.ObjectID) ([]RubricVersion, error)
+}
+
+type rubricHandler struct {
+	storage RubricStorage
+}
+
+func NewRubricHandler(st RubricStorage) *rubricHandler {
+	return &rubricHandler{
+		storage: st,
+	}
+}
+
+var invalidRubricVersionError = skillHandlerError{message: "invalid rubric version, from and to must be version numbers"}
+
+// RubricVersions godoc
+//
+//	@summary		RubricVersions
+//	@description	List the versions of the skill levels of a hard skill, oldest first, each with the time it took effect
+//	@tags			skill
+//	@id				RubricVersions
+//	@security		BearerAuth
+//	@produce		json
+//	@param			skillID	path		string				true	"Hard skill ID"
+//	@response		200		{array}		skill.RubricVersion	"OK"
+//	@response		400		{object}	app.Response		"Bad Request"
+//	@response		450		{object}	app.Response		"Store Error"
+//	@router			/hard-skills/{skillID}/rubrics [get]
+func (h *rubricHandler) RubricVersions(c app.Context) {
+	versions, ok := h.versions(c)
+	if !ok {
+		return
+	}
+
+	c.OK(versions)
+}
+
+// RubricDiff godoc
+//
+//	@summary		RubricDiff
+//	@description	Compare two versions of the skill levels of a hard skill, level by level. to defaults to the latest version and from to the one before it.
+//	@tags			skill
+//	@id				RubricDiff
+//	@security		BearerAuth
+//	@produce		json
+//	@param			skillID	path		string				true	"Hard skill ID"
+//	@param			from	query		int					false	"Version to compare from"
+//	@param			to		query		int					false	"Version to compare to"
+//	@response		200		{object}	skill.RubricDiff	"OK"
+//	@response		400		{object}	app.Response		"Bad Request"
+//	@response		404		{object}	app.Response		"Rubric version not found"
+//	@response		450		{object}	app.Response		"Store Error"
+//	@router			/hard-skills/{skillID}/rubrics/diff [get]
+func (h *rubricHandler) RubricDiff(c app.Context) {
+	versions, ok := h.versions(c)
+	if !ok {
+		return
+	}
+
+	latest := 0
+	if len(versions) > 0 {
+		latest = versions[len(versions)-1].Version
+	}
+	to, err := versionParam(c.Query("to"), latest)
+	if err != nil {
+		c.BadRequest(err)
+		return
+	}
+	from, err := versionParam(c.Query("from"), to-1)
+	if err != nil {
+		c.BadRequest(err)
+		return
+	}
+
+	fromVersion, toVersion := findVersion(versions, from), findVersion(versions, to)
+	if fromVersion == nil || toVersion == nil {
+		c.NotFound(rubricVersionNotFoundError)
+		return
+	}
+
+	c.OK(DiffRubrics(*fromVersion, *toVersion))
+}
+
+func (h *rubricHandler) versions(c app.Context) ([]RubricVersion, bool) {
+	oid, err := primitive.ObjectIDFromHex(c.Param("skillID"))
+	if err != nil {
+		c.BadRequest(invalidSkillIdError)
+		return nil, false
+	}
+
+	versions, err := h.storage.GetRubricVersions(c.Ctx(), []primitive.ObjectID{oid})
+	if err != nil {
+		c.StoreError(err)
+		return nil, false
+	}
+	return versions, true
+}
+
+func versionParam(value string, fallback int) (int, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, invalidRubricVersionError
+	}
+	return version, nil
+}
+
+func findVersion(versions []RubricVersion, version int) *RubricVersion {
+	for i := range versions {
+		if versions[i].Version == version {
+			return &versions[i]
+		}
+	}
+	return nil
+}
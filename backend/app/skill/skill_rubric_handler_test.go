@@ -0,0 +1,155 @@
+package skill
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+type mockRubricStorage struct {
+	versions []RubricVersion
+	skillIDs []primitive.ObjectID
+	err      error
+}
+
+func (m *mockRubricStorage) GetRubricVersions(ctx context.Context, skillIDs []primitive.ObjectID) ([]RubricVersion, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.skillIDs = skillIDs
+	return m.versions, nil
+}
+
+func rubricEngine(h *rubricHandler) *gin.Engine {
+	engine := gin.New()
+	engine.GET("/hard-skills/:skillID/rubrics", app.NewGinHandler(h.RubricVersions, zap.NewNop()))
+	engine.GET("/hard-skills/:skillID/rubrics/diff", app.NewGinHandler(h.RubricDiff, zap.NewNop()))
+	return engine
+}
+
+func TestRubricVersions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name             string
+		id               string
+		storageErr       error
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:           "should return 200 and the rubric versions",
+			id:             mockSkillID.Hex(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:             "should return 400 when id is invalid",
+			id:               "dkls",
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid skill id"}`,
+		},
+		{
+			name:             "should return 450 when storage fails",
+			id:               mockSkillID.Hex(),
+			storageErr:       errors.New("connection lost"),
+			expectedStatus:   450,
+			expectedResponse: `{"status":"error","message":"connection lost"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockRubricStorage{versions: mockRubricVersions(), err: tc.storageErr}
+			engine := rubricEngine(NewRubricHandler(st))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/hard-skills/"+tc.id+"/rubrics", nil)
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedResponse != "" {
+				assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+			}
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, []primitive.ObjectID{mockSkillID}, st.skillIDs)
+				assert.Contains(t, rec.Body.String(), `"version":3`)
+			}
+		})
+	}
+}
+
+func TestRubricDiff(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name             string
+		id               string
+		query            string
+		storageErr       error
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:             "should return 200 and the diff from the version before the latest",
+			id:               mockSkillID.Hex(),
+			expectedStatus:   http.StatusOK,
+			expectedResponse: `{"status":"success","message":"","data":{"skillId":"` + mockSkillID.Hex() + `","from":2,"to":3,"changes":[{"level":1,"change":"removed","before":"runs a pod"}]}}`,
+		},
+		{
+			name:             "should return 200 and the diff between the given versions",
+			id:               mockSkillID.Hex(),
+			query:            "?from=1&to=2",
+			expectedStatus:   http.StatusOK,
+			expectedResponse: `{"status":"success","message":"","data":{"skillId":"` + mockSkillID.Hex() + `","from":1,"to":2,"changes":[{"level":2,"change":"changed","before":"writes a deployment","after":"writes a helm chart"},{"level":3,"change":"added","after":"runs a cluster"}]}}`,
+		},
+		{
+			name:             "should return 400 when a version is not a number",
+			id:               mockSkillID.Hex(),
+			query:            "?from=first",
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid rubric version, from and to must be version numbers"}`,
+		},
+		{
+			name:             "should return 404 when a version is not found",
+			id:               mockSkillID.Hex(),
+			query:            "?from=1&to=4",
+			expectedStatus:   http.StatusNotFound,
+			expectedResponse: `{"status":"error","message":"rubric version not found"}`,
+		},
+		{
+			name:             "should return 400 when id is invalid",
+			id:               "dkls",
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid skill id"}`,
+		},
+		{
+			name:             "should return 450 when storage fails",
+			id:               mockSkillID.Hex(),
+			storageErr:       errors.New("connection lost"),
+			expectedStatus:   450,
+			expectedResponse: `{"status":"error","message":"connection lost"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockRubricStorage{versions: mockRubricVersions(), err: tc.storageErr}
+			engine := rubricEngine(NewRubricHandler(st))
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/hard-skills/"+tc.id+"/rubrics/diff"+tc.query, nil)
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+		})
+	}
+}
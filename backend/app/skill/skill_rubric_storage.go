@@ -0,0 +1,93 @@
+package skill
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const rubricCollection = "hard_skill_rubrics"
+
+var rubricVersionNotFoundError = SkillStorageError{message: "rubric version not found"}
+var rubricNotLaterError = SkillStorageError{message: "new skill levels must take effect after the current ones"}
+var rubricChangedError = SkillStorageError{message: "skill levels were changed by someone else, reload them and try again"}
+
+// EnsureIndexes creates the indexes the skill catalog relies on. It is safe
+// to run on every start.
+func (s *storage) EnsureIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err := s.db.Collection(rubricCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "skillId", Value: 1}, {Key: "version", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	return err
+}
+
+// GetRubricVersions lists the rubric versions of the given hard skills, by
+// skill and then oldest first.
+func (s *storage) GetRubricVersions(ctx context.Context, skillIDs []primitive.ObjectID) ([]RubricVersion, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"skillId": bson.M{"$in": skillIDs}}
+	findOptions := options.Find().SetSort(bson.D{{Key: "skillId", Value: 1}, {Key: "version", Value: 1}})
+	cursor, err := s.db.Collection(rubricCollection).Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []RubricVersion{}
+	if err := cursor.All(ctx, &versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// addRubricVersion records new skill levels of a hard skill, in effect from
+// the given time, and returns their version. The levels a hard skill had
+// before its versions were kept become its first version, in effect since
+// ever.
+func (s *storage) addRubricVersion(ctx context.Context, current HardSkill, levels []SkillLevel, effectiveFrom time.Time, by string) (int, error) {
+	coll := s.db.Collection(rubricCollection)
+
+	var latest RubricVersion
+	err := coll.FindOne(ctx, bson.M{"skillId": current.ID}, options.FindOne().SetSort(bson.M{"version": -1})).Decode(&latest)
+	switch {
+	case err == mongo.ErrNoDocuments:
+		latest = RubricVersion{SkillID: current.ID, Version: 1, SkillLevel: current.SkillLevel, CreatedBy: by, CreatedAt: time.Now()}
+		if _, err := coll.InsertOne(ctx, latest); err != nil {
+			return 0, rubricInsertError(err)
+		}
+	case err != nil:
+		return 0, err
+	}
+	if !effectiveFrom.After(latest.EffectiveFrom) {
+		return 0, rubricNotLaterError
+	}
+
+	next := RubricVersion{
+		SkillID:       current.ID,
+		Version:       latest.Version + 1,
+		EffectiveFrom: effectiveFrom,
+		SkillLevel:    levels,
+		CreatedBy:     by,
+		CreatedAt:     time.Now(),
+	}
+	if _, err := coll.InsertOne(ctx, next); err != nil {
+		return 0, rubricInsertError(err)
+	}
+	return next.Version, nil
+}
+
+func rubricInsertError(err error) error {
+	if mongo.IsDuplicateKeyError(err) {
+		return rubricChangedError
+	}
+	return err
+}
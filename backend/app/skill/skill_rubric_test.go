@@ -0,0 +1,145 @@
+package skill
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mockRubricVersions() []RubricVersion {
+	return []RubricVersion{
+		{
+			SkillID: mockSkillID,
+			Version: 1,
+			SkillLevel: []SkillLevel{
+				{Level: 1, LevelDescription: "runs a pod"},
+				{Level: 2, LevelDescription: "writes a deployment"},
+			},
+		},
+		{
+			SkillID:       mockSkillID,
+			Version:       2,
+			EffectiveFrom: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+			SkillLevel: []SkillLevel{
+				{Level: 1, LevelDescription: "runs a pod"},
+				{Level: 2, LevelDescription: "writes a helm chart"},
+				{Level: 3, LevelDescription: "runs a cluster"},
+			},
+		},
+		{
+			SkillID:       mockSkillID,
+			Version:       3,
+			EffectiveFrom: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			SkillLevel: []SkillLevel{
+				{Level: 2, LevelDescription: "writes a helm chart"},
+				{Level: 3, LevelDescription: "runs a cluster"},
+			},
+		},
+	}
+}
+
+func TestRubricAt(t *testing.T) {
+	testCases := []struct {
+		name            string
+		versions        []RubricVersion
+		at              time.Time
+		expectedVersion int
+	}{
+		{
+			name:            "should return the legacy version before any other took effect",
+			versions:        mockRubricVersions(),
+			at:              time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedVersion: 1,
+		},
+		{
+			name:            "should return the version taking effect on that very day",
+			versions:        mockRubricVersions(),
+			at:              time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+			expectedVersion: 2,
+		},
+		{
+			name:            "should return the latest version in effect",
+			versions:        mockRubricVersions(),
+			at:              time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			expectedVersion: 3,
+		},
+		{
+			name:            "should return the latest version in effect whatever the order",
+			versions:        []RubricVersion{mockRubricVersions()[2], mockRubricVersions()[0], mockRubricVersions()[1]},
+			at:              time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+			expectedVersion: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rubric := RubricAt(tc.versions, tc.at)
+
+			assert.NotNil(t, rubric)
+			assert.Equal(t, tc.expectedVersion, rubric.Version)
+		})
+	}
+
+	t.Run("should return nil when no version is in effect yet", func(t *testing.T) {
+		rubric := RubricAt(mockRubricVersions()[1:], time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		assert.Nil(t, rubric)
+	})
+}
+
+func TestDiffRubrics(t *testing.T) {
+	versions := mockRubricVersions()
+
+	testCases := []struct {
+		name     string
+		from     RubricVersion
+		to       RubricVersion
+		expected []LevelChange
+	}{
+		{
+			name: "should list changed and added levels",
+			from: versions[0],
+			to:   versions[1],
+			expected: []LevelChange{
+				{Level: 2, Change: LevelChanged, Before: "writes a deployment", After: "writes a helm chart"},
+				{Level: 3, Change: LevelAdded, After: "runs a cluster"},
+			},
+		},
+		{
+			name: "should list removed levels",
+			from: versions[1],
+			to:   versions[2],
+			expected: []LevelChange{
+				{Level: 1, Change: LevelRemoved, Before: "runs a pod"},
+			},
+		},
+		{
+			name: "should compare an older version to a newer one backwards",
+			from: versions[2],
+			to:   versions[0],
+			expected: []LevelChange{
+				{Level: 1, Change: LevelAdded, After: "runs a pod"},
+				{Level: 2, Change: LevelChanged, Before: "writes a helm chart", After: "writes a deployment"},
+				{Level: 3, Change: LevelRemoved, Before: "runs a cluster"},
+			},
+		},
+		{
+			name:     "should return no changes between the same version",
+			from:     versions[1],
+			to:       versions[1],
+			expected: []LevelChange{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			diff := DiffRubrics(tc.from, tc.to)
+
+			assert.Equal(t, mockSkillID, diff.SkillID)
+			assert.Equal(t, tc.from.Version, diff.From)
+			assert.Equal(t, tc.to.Version, diff.To)
+			assert.Equal(t, tc.expected, diff.Changes)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package skill
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// KindHard is the kind of the hard skills in search results.
+const KindHard = "hard"
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+)
+
+// How well a name or alias matches a search, best first. A match on an alias
+// ranks just below the same match on a name.
+const (
+	scoreExact     = 100
+	scorePrefix    = 80
+	scoreContains  = 60
+	scoreTypo      = 40
+	scoreTypoStart = 30
+	scoreAlias     = 5
+)
+
+// SearchResult is a soft, technical or hard skill matching a search. Alias is
+// the alias it was found by, if not by its name.
+type SearchResult struct {
+	ID    primitive.ObjectID `json:"id"`
+	Kind  string             `json:"kind"`
+	Name  string             `json:"name"`
+	Alias string             `json:"alias,omitempty"`
+	Score int                `json:"score"`
+}
+
+// Search ranks the skills and hard skills whose name or an alias matches the
+// query exactly, by prefix, by part, or within a typo or two, and keeps the
+// best ones. Case, spaces and punctuation are ignored, so "node js" finds
+// Node.js.
+func Search(query string, skills []Skill, hardSkills []HardSkill, limit int) []SearchResult {
+	q := normalize(query)
+	if q == "" {
+		return []SearchResult{}
+	}
+
+	results := []SearchResult{}
+	add := func(id primitive.ObjectID, kind string, name string, aliases []string) {
+		best := SearchResult{ID: id, Kind: kind, Name: name, Score: match(q, normalize(name))}
+		for _, alias := range aliases {
+			if score := match(q, normalize(alias)) - scoreAlias; score > best.Score {
+				best.Score = score
+				best.Alias = alias
+			}
+		}
+		if best.Score > 0 {
+			results = append(results, best)
+		}
+	}
+	for _, sk := range skills {
+		add(sk.ID, sk.Kind, sk.Name, sk.Aliases)
+	}
+	for _, hs := range hardSkills {
+		add(hs.ID, KindHard, hs.Name, hs.Aliases)
+	}
+
+	slices.SortStableFunc(results, func(a, b SearchResult) int {
+		if a.Score != b.Score {
+			return b.Score - a.Score
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	if limit <= 0 || limit > maxSearchLimit {
+		limit = defaultSearchLimit
+	}
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// match scores how well a normalized query matches a normalized name, 0 when
+// it doesn't. Typos are forgiven against the whole name or its start.
+func match(q string, term string) int {
+	if term == "" {
+		return 0
+	}
+	switch {
+	case term == q:
+		return scoreExact
+	case strings.HasPrefix(term, q):
+		return scorePrefix
+	case len(q) > 1 && strings.Contains(term, q):
+		return scoreContains
+	}
+
+	allowed := typos(q)
+	if allowed == 0 {
+		return 0
+	}
+	if d := distance(q, term); d <= allowed {
+		return scoreTypo - 10*(d-1)
+	}
+	if d := startDistance(q, term, allowed); d <= allowed {
+		return scoreTypoStart - 10*(d-1)
+	}
+	return 0
+}
+
+// startDistance is the least distance between the query and a start of the
+// name, as long as a missing or extra letter could make up the difference.
+func startDistance(q string, term string, allowed int) int {
+	rq, rt := []rune(q), []rune(term)
+	best := allowed + 1
+	for n := len(rq) - allowed; n <= len(rq)+allowed; n++ {
+		if n > 0 && n < len(rt) {
+			best = min(best, distance(q, string(rt[:n])))
+		}
+	}
+	return best
+}
+
+// typos is how many typos a query of this length may have: none for short
+// ones, which would match nearly anything.
+func typos(q string) int {
+	switch n := len([]rune(q)); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// distance is the Levenshtein distance between two strings.
+func distance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// normalize lowercases a name and drops everything but letters and digits.
+func normalize(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,111 @@
+package skill
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func searchFixtures() ([]Skill, []HardSkill) {
+	skills := []Skill{
+		{ID: mockOID(1), Name: "JavaScript", Kind: KindTechnical, Aliases: []string{"JS", "ECMAScript"}},
+		{ID: mockOID(2), Name: "Java", Kind: KindTechnical},
+		{ID: mockOID(3), Name: "Communication", Kind: KindSoft},
+		{ID: mockOID(4), Name: "Node.js", Kind: KindTechnical},
+	}
+	hardSkills := []HardSkill{
+		{ID: mockOID(5), Name: "Kubernetes", Aliases: []string{"k8s"}},
+		{ID: mockOID(6), Name: "Docker"},
+		{ID: mockOID(7), Name: "Go", Aliases: []string{"Golang"}},
+	}
+	return skills, hardSkills
+}
+
+func TestSearch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		limit    int
+		expected []SearchResult
+	}{
+		{
+			name:  "should find a hard skill by alias",
+			query: "k8s",
+			expected: []SearchResult{
+				{ID: mockOID(5), Kind: KindHard, Name: "Kubernetes", Alias: "k8s", Score: scoreExact - scoreAlias},
+			},
+		},
+		{
+			name:  "should rank an alias above a name it only starts",
+			query: "js",
+			expected: []SearchResult{
+				{ID: mockOID(1), Kind: KindTechnical, Name: "JavaScript", Alias: "JS", Score: scoreExact - scoreAlias},
+				{ID: mockOID(4), Kind: KindTechnical, Name: "Node.js", Score: scoreContains},
+			},
+		},
+		{
+			name:  "should rank an exact name above names it starts",
+			query: "JAVA",
+			expected: []SearchResult{
+				{ID: mockOID(2), Kind: KindTechnical, Name: "Java", Score: scoreExact},
+				{ID: mockOID(1), Kind: KindTechnical, Name: "JavaScript", Score: scorePrefix},
+			},
+		},
+		{
+			name:  "should ignore spaces and punctuation",
+			query: "node js",
+			expected: []SearchResult{
+				{ID: mockOID(4), Kind: KindTechnical, Name: "Node.js", Score: scoreExact},
+			},
+		},
+		{
+			name:  "should forgive typos",
+			query: "kubernets",
+			expected: []SearchResult{
+				{ID: mockOID(5), Kind: KindHard, Name: "Kubernetes", Score: scoreTypo},
+			},
+		},
+		{
+			name:  "should forgive typos at the start of a name",
+			query: "comunic",
+			expected: []SearchResult{
+				{ID: mockOID(3), Kind: KindSoft, Name: "Communication", Score: scoreTypoStart},
+			},
+		},
+		{
+			name:     "should not forgive typos in short searches",
+			query:    "dok",
+			expected: []SearchResult{},
+		},
+		{
+			name:     "should return nothing for an empty search",
+			query:    " - ",
+			expected: []SearchResult{},
+		},
+		{
+			name:  "should keep the best results up to the limit",
+			query: "ja",
+			limit: 1,
+			expected: []SearchResult{
+				{ID: mockOID(2), Kind: KindTechnical, Name: "Java", Score: scorePrefix},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			skills, hardSkills := searchFixtures()
+
+			got := Search(tc.query, skills, hardSkills, tc.limit)
+
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	assert.Equal(t, 0, distance("docker", "docker"))
+	assert.Equal(t, 1, distance("docker", "doker"))
+	assert.Equal(t, 2, distance("kubernetes", "kuberentes"))
+	assert.Equal(t, 3, distance("", "abc"))
+}
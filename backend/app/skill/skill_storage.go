@@ -2,10 +2,13 @@ package skill
 
 import (
 	"context"
+	"slices"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type storage struct {
@@ -18,13 +21,40 @@ func NewStorage(db *mongo.Database) *storage {
 	}
 }
 
+// withTransaction runs fn in a transaction, retrying it on transient errors.
+func (s *storage) withTransaction(ctx context.Context, fn func(sc mongo.SessionContext) error) error {
+	session, err := s.db.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
+
 const skillCollection = "skills"
 const hardSkillCollection = "hard_skills"
 
+type SkillStorageError struct {
+	message string
+}
+
+func (e SkillStorageError) Error() string {
+	return e.message
+}
+
+var invalidSkillIdError = SkillStorageError{message: "invalid skill id"}
+var skillNotFoundError = SkillStorageError{message: "skill not found"}
+var skillNameTakenError = SkillStorageError{message: "a skill with this name already exists"}
+var hardSkillRenameError = SkillStorageError{message: "a hard skill can't be renamed, add the new name as an alias instead"}
+
 func (s *storage) GetByKind(ctx context.Context, kind string) ([]Skill, error) {
-	query := bson.M{}
+	query := bson.M{"archivedAt": nil}
 	if kind != "" {
-		query = bson.M{"kind": kind}
+		query["kind"] = kind
 	}
 	var sks []Skill
 	cursor, err := s.db.Collection(skillCollection).Find(ctx, query)
@@ -54,6 +84,7 @@ func (s *storage) GetByRole(ctx context.Context, role string) ([]HardSkill, erro
 		"jobRole": bson.M{
 			"$in": []string{role},
 		},
+		"archivedAt": nil,
 	}
 
 	var result = []HardSkill{}
@@ -65,3 +96,229 @@ func (s *storage) GetByRole(ctx context.Context, role string) ([]HardSkill, erro
 
 	return result, err
 }
+
+// GetAllSkills lists every soft and technical skill, archived ones included.
+func (s *storage) GetAllSkills(ctx context.Context) ([]Skill, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "kind", Value: 1}, {Key: "name", Value: 1}})
+	return findAll[Skill](ctx, s.db.Collection(skillCollection), findOptions)
+}
+
+// GetAllHardSkills lists every hard skill in catalog order, archived ones
+// included.
+func (s *storage) GetAllHardSkills(ctx context.Context) ([]HardSkill, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "sort", Value: 1}, {Key: "name", Value: 1}})
+	return findAll[HardSkill](ctx, s.db.Collection(hardSkillCollection), findOptions)
+}
+
+func (s *storage) InsertSkill(ctx context.Context, sk Skill) (*Skill, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := nameTaken(ctx, s.db.Collection(skillCollection), sk.Name, primitive.NilObjectID); err != nil {
+		return nil, err
+	}
+	sk.ID = primitive.NewObjectID()
+	if _, err := s.db.Collection(skillCollection).InsertOne(ctx, sk); err != nil {
+		return nil, err
+	}
+	return &sk, nil
+}
+
+// InsertHardSkill adds a hard skill with its skill levels as the first
+// version of its rubric, in effect from the given time, in one transaction.
+func (s *storage) InsertHardSkill(ctx context.Context, hs HardSkill, effectiveFrom time.Time) (*HardSkill, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := nameTaken(ctx, s.db.Collection(hardSkillCollection), hs.Name, primitive.NilObjectID); err != nil {
+		return nil, err
+	}
+	hs.ID = primitive.NewObjectID()
+	hs.RubricVersion = 1
+	if err := s.checkTaxonomy(ctx, hs); err != nil {
+		return nil, err
+	}
+	first := RubricVersion{
+		SkillID:       hs.ID,
+		Version:       hs.RubricVersion,
+		EffectiveFrom: effectiveFrom,
+		SkillLevel:    hs.SkillLevel,
+		CreatedBy:     hs.UpdatedBy,
+		CreatedAt:     time.Now(),
+	}
+	err := s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		if _, err := s.db.Collection(hardSkillCollection).InsertOne(sc, hs); err != nil {
+			return err
+		}
+		_, err := s.db.Collection(rubricCollection).InsertOne(sc, first)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &hs, nil
+}
+
+// UpdateSkill replaces what an admin can edit of a skill, keeping whether it
+// is archived.
+func (s *storage) UpdateSkill(ctx context.Context, id string, sk Skill) (*Skill, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, invalidSkillIdError
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	coll := s.db.Collection(skillCollection)
+	if err := nameTaken(ctx, coll, sk.Name, oid); err != nil {
+		return nil, err
+	}
+	return updateOne[Skill](ctx, coll, bson.M{"_id": oid}, bson.M{
+		"name":        sk.Name,
+		"description": sk.Description,
+		"logo":        sk.Logo,
+		"kind":        sk.Kind,
+		"updatedBy":   sk.UpdatedBy,
+		"updatedAt":   sk.UpdatedAt,
+	})
+}
+
+// UpdateHardSkill replaces what an admin can edit of a hard skill, keeping
+// whether it is archived. New skill levels are a new version of its rubric,
+// in effect from the given time, written in the same transaction as the
+// skill; cycles keep the version they started with.
+// The name can't change, as profiles, cycles and goal policies refer to the
+// hard skill by it.
+func (s *storage) UpdateHardSkill(ctx context.Context, id string, hs HardSkill, effectiveFrom time.Time) (*HardSkill, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, invalidSkillIdError
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	coll := s.db.Collection(hardSkillCollection)
+	var current HardSkill
+	if err := coll.FindOne(ctx, bson.M{"_id": oid}).Decode(&current); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, skillNotFoundError
+		}
+		return nil, err
+	}
+	if hs.Name != current.Name {
+		return nil, hardSkillRenameError
+	}
+	hs.ID = oid
+	if err := s.checkTaxonomy(ctx, hs); err != nil {
+		return nil, err
+	}
+
+	set := bson.M{
+		"description":   hs.Description,
+		"jobRole":       hs.JobRole,
+		"sort":          hs.Sort,
+		"categoryId":    hs.CategoryID,
+		"prerequisites": hs.Prerequisites,
+		"updatedBy":     hs.UpdatedBy,
+		"updatedAt":     hs.UpdatedAt,
+	}
+	var updated *HardSkill
+	err = s.withTransaction(ctx, func(sc mongo.SessionContext) error {
+		if !slices.Equal(current.SkillLevel, hs.SkillLevel) {
+			version, err := s.addRubricVersion(sc, current, hs.SkillLevel, effectiveFrom, hs.UpdatedBy)
+			if err != nil {
+				return err
+			}
+			set["skillLevel"] = hs.SkillLevel
+			set["rubricVersion"] = version
+		}
+		updated, err = updateOne[HardSkill](sc, coll, bson.M{"_id": oid}, set)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *storage) ArchiveSkill(ctx context.Context, id string, email string) (*Skill, error) {
+	return archive[Skill](ctx, s.db.Collection(skillCollection), id, email)
+}
+
+func (s *storage) ArchiveHardSkill(ctx context.Context, id string, email string) (*HardSkill, error) {
+	return archive[HardSkill](ctx, s.db.Collection(hardSkillCollection), id, email)
+}
+
+// archive takes a skill out of the catalog. Archiving it again keeps the time
+// it was first archived.
+func archive[T any](ctx context.Context, coll *mongo.Collection, id string, email string) (*T, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, invalidSkillIdError
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	res, err := updateOne[T](ctx, coll, bson.M{"_id": oid, "archivedAt": nil}, bson.M{"archivedAt": now, "updatedBy": email, "updatedAt": now})
+	if err != skillNotFoundError {
+		return res, err
+	}
+
+	var archived T
+	if err := coll.FindOne(ctx, bson.M{"_id": oid}).Decode(&archived); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, skillNotFoundError
+		}
+		return nil, err
+	}
+	return &archived, nil
+}
+
+func updateOne[T any](ctx context.Context, coll *mongo.Collection, filter bson.M, set bson.M) (*T, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updated T
+	if err := coll.FindOneAndUpdate(ctx, filter, bson.M{"$set": set}, opts).Decode(&updated); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, skillNotFoundError
+		}
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func findAll[T any](ctx context.Context, coll *mongo.Collection, findOptions *options.FindOptions) ([]T, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cursor, err := coll.Find(ctx, bson.M{}, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	all := []T{}
+	if err := cursor.All(ctx, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// nameTaken returns skillNameTakenError when a skill other than the given one
+// already has the name, or has it as an alias, whatever the case. Cycles tell
+// hard skills apart by name.
+func nameTaken(ctx context.Context, coll *mongo.Collection, name string, except primitive.ObjectID) error {
+	filter := bson.M{
+		"$or": []bson.M{{"name": name}, {"aliases": name}},
+		"_id": bson.M{"$ne": except},
+	}
+	countOptions := options.Count().SetCollation(&options.Collation{Locale: "en", Strength: 2})
+	n, err := coll.CountDocuments(ctx, filter, countOptions)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return skillNameTakenError
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package skill
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Category groups hard skills in the skill tree. A category without a parent
+// is at the top of the tree, one with a parent is a sub-category. Sub-categories
+// can't have sub-categories of their own.
+type Category struct {
+	ID        primitive.ObjectID  `json:"id" bson:"_id"`
+	Name      string              `json:"name" bson:"name"`
+	ParentID  *primitive.ObjectID `json:"parentId,omitempty" bson:"parentId,omitempty"`
+	Sort      int                 `json:"sort" bson:"sort"`
+	UpdatedBy string              `json:"updatedBy,omitempty" bson:"updatedBy,omitempty"`
+	UpdatedAt *time.Time          `json:"updatedAt,omitempty" bson:"updatedAt,omitempty"`
+}
+
+type CategoryInput struct {
+	Name     string `json:"name" binding:"required"`
+	ParentID string `json:"parentId"`
+	Sort     int    `json:"sort" binding:"min=0"`
+}
+
+// Prerequisite is a level of another hard skill needed before reaching a
+// level of this one, such as Docker level 3 for Kubernetes level 3.
+type Prerequisite struct {
+	Level         int                `json:"level" bson:"level" binding:"required,min=1"`
+	SkillID       primitive.ObjectID `json:"skillId" bson:"skillId" binding:"required"`
+	RequiredLevel int                `json:"requiredLevel" bson:"requiredLevel" binding:"required,min=1"`
+}
+
+// CategoryNode is a category of the skill tree with its sub-categories and
+// hard skills, both in catalog order.
+type CategoryNode struct {
+	Category
+	SubCategories []CategoryNode `json:"subCategories"`
+	HardSkills    []HardSkill    `json:"hardSkills"`
+}
+
+// SkillTree is the catalog of hard skills by category. Hard skills without a
+// category, or whose category is gone, are left at the top as uncategorised.
+type SkillTree struct {
+	Categories    []CategoryNode `json:"categories"`
+	Uncategorised []HardSkill    `json:"uncategorised"`
+}
+
+// BuildTree puts the hard skills under their categories, keeping the order
+// both are given in. A sub-category whose parent is gone is put at the top.
+func BuildTree(categories []Category, hardSkills []HardSkill) SkillTree {
+	known := make(map[primitive.ObjectID]bool)
+	for _, cat := range categories {
+		known[cat.ID] = true
+	}
+
+	tree := SkillTree{Categories: []CategoryNode{}, Uncategorised: []HardSkill{}}
+	skills := make(map[primitive.ObjectID][]HardSkill)
+	for _, hs := range hardSkills {
+		if hs.CategoryID == nil || !known[*hs.CategoryID] {
+			tree.Uncategorised = append(tree.Uncategorised, hs)
+			continue
+		}
+		skills[*hs.CategoryID] = append(skills[*hs.CategoryID], hs)
+	}
+
+	node := func(cat Category) CategoryNode {
+		n := CategoryNode{Category: cat, SubCategories: []CategoryNode{}, HardSkills: []HardSkill{}}
+		if hs, ok := skills[cat.ID]; ok {
+			n.HardSkills = hs
+		}
+		return n
+	}
+
+	subCategories := make(map[primitive.ObjectID][]CategoryNode)
+	for _, cat := range categories {
+		if cat.ParentID != nil && known[*cat.ParentID] {
+			subCategories[*cat.ParentID] = append(subCategories[*cat.ParentID], node(cat))
+		}
+	}
+	for _, cat := range categories {
+		if cat.ParentID != nil && known[*cat.ParentID] {
+			continue
+		}
+		n := node(cat)
+		if subs, ok := subCategories[cat.ID]; ok {
+			n.SubCategories = subs
+		}
+		tree.Categories = append(tree.Categories, n)
+	}
+	return tree
+}
+
+// CheckPrerequisites tells what is wrong with the prerequisites of a hard
+// skill among the others of the catalog. Each must be at a level of its
+// rubric and need a level of another hard skill's rubric, and following them
+// must never lead back to the skill itself.
+func CheckPrerequisites(hs HardSkill, catalog []HardSkill) error {
+	byID := make(map[primitive.ObjectID]HardSkill)
+	for _, other := range catalog {
+		byID[other.ID] = other
+	}
+	byID[hs.ID] = hs
+
+	for _, p := range hs.Prerequisites {
+		required, ok := byID[p.SkillID]
+		if !ok || p.SkillID == hs.ID || p.Level > len(hs.SkillLevel) || p.RequiredLevel > len(required.SkillLevel) {
+			return invalidPrerequisiteError
+		}
+	}
+
+	seen := make(map[primitive.ObjectID]bool)
+	next := []primitive.ObjectID{}
+	for _, p := range hs.Prerequisites {
+		next = append(next, p.SkillID)
+	}
+	for len(next) > 0 {
+		id := next[0]
+		next = next[1:]
+		if id == hs.ID {
+			return prerequisiteLoopError
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		for _, p := range byID[id].Prerequisites {
+			next = append(next, p.SkillID)
+		}
+	}
+	return nil
+}
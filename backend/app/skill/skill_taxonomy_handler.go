@@ -0,0 +1,214 @@
+package skill
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type TaxonomyStorage interface {
+	GetCategories(ctx context.Context) ([]Category, error)
+	GetAllHardSkills(ctx context.Context) ([]HardSkill, error)
+	InsertCategory(ctx context.Context, cat Category) (*Category, error)
+	UpdateCategory(ctx context.Context, id string, cat Category) (*Category, error)
+	DeleteCategory(ctx context.Context, id string) error
+}
+
+type taxonomyHandler struct {
+	storage TaxonomyStorage
+	admins  []string
+}
+
+func NewTaxonomyHandler(st TaxonomyStorage, admins []string) *taxonomyHandler {
+	return &taxonomyHandler{
+		storage: st,
+		admins:  admins,
+	}
+}
+
+var invalidCategoryInputError = skillHandlerError{message: "invalid skill category input, name is required"}
+
+// SkillTree godoc
+//
+//	@summary		SkillTree
+//	@description	Browse the hard skills of the catalog by category and sub-category, optionally only those of a job role. Archived hard skills are left out.
+//	@tags			skill
+//	@id				SkillTree
+//	@security		BearerAuth
+//	@produce		json
+//	@param			jobRole	query		string			false	"Job role"
+//	@response		200		{object}	skill.SkillTree	"OK"
+//	@response		450		{object}	app.Response	"Store Error"
+//	@router			/skill-tree [get]
+func (h *taxonomyHandler) SkillTree(c app.Context) {
+	categories, err := h.storage.GetCategories(c.Ctx())
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+	hardSkills, err := h.storage.GetAllHardSkills(c.Ctx())
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	jobRole := JobRole(c.Query("jobRole"))
+	hardSkills = slices.DeleteFunc(hardSkills, func(hs HardSkill) bool {
+		return hs.ArchivedAt != nil || (jobRole != "" && !slices.Contains(hs.JobRole, jobRole))
+	})
+
+	c.OK(BuildTree(categories, hardSkills))
+}
+
+// GetCategories godoc
+//
+//	@summary		GetCategories
+//	@description	List every skill category in catalog order.
+//	@tags			skill
+//	@id				GetCategories
+//	@security		BearerAuth
+//	@produce		json
+//	@response		200	{array}		skill.Category	"OK"
+//	@response		403	{object}	app.Response	"Not an admin"
+//	@response		450	{object}	app.Response	"Store Error"
+//	@router			/admin/skill-categories [get]
+func (h *taxonomyHandler) GetCategories(c app.Context) {
+	if !app.RequireAdmin(c, h.admins, notSkillAdminError) {
+		return
+	}
+
+	categories, err := h.storage.GetCategories(c.Ctx())
+	if err != nil {
+		c.StoreError(err)
+		return
+	}
+
+	c.OK(categories)
+}
+
+// InsertCategory godoc
+//
+//	@summary		InsertCategory
+//	@description	Add a skill category, at the top of the skill tree or under a top category given as parentId.
+//	@tags			skill
+//	@id				InsertCategory
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			reqJson	body		CategoryInput	true	"Skill category"
+//	@response		200		{object}	skill.Category	"OK"
+//	@response		400		{object}	app.Response	"Bad Request"
+//	@response		403		{object}	app.Response	"Not an admin"
+//	@response		404		{object}	app.Response	"Parent category not found"
+//	@response		450		{object}	app.Response	"Store Error"
+//	@router			/admin/skill-categories [post]
+func (h *taxonomyHandler) InsertCategory(c app.Context) {
+	cat, ok := h.category(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.storage.InsertCategory(c.Ctx(), cat)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+
+	c.OK(res)
+}
+
+// UpdateCategory godoc
+//
+//	@summary		UpdateCategory
+//	@description	Rename, move or reorder a skill category. Its sub-categories and hard skills move along with it. A category with sub-categories can't go under another one.
+//	@tags			skill
+//	@id				UpdateCategory
+//	@security		BearerAuth
+//	@accept			json
+//	@produce		json
+//	@param			categoryID	path		string			true	"Skill category ID"
+//	@param			reqJson		body		CategoryInput	true	"Skill category"
+//	@response		200			{object}	skill.Category	"OK"
+//	@response		400			{object}	app.Response	"Bad Request"
+//	@response		403			{object}	app.Response	"Not an admin"
+//	@response		404			{object}	app.Response	"Skill category not found"
+//	@response		450			{object}	app.Response	"Store Error"
+//	@router			/admin/skill-categories/{categoryID} [put]
+func (h *taxonomyHandler) UpdateCategory(c app.Context) {
+	cat, ok := h.category(c)
+	if !ok {
+		return
+	}
+
+	res, err := h.storage.UpdateCategory(c.Ctx(), c.Param("categoryID"), cat)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+
+	c.OK(res)
+}
+
+// DeleteCategory godoc
+//
+//	@summary		DeleteCategory
+//	@description	Remove a skill category that has no sub-categories nor hard skills left.
+//	@tags			skill
+//	@id				DeleteCategory
+//	@security		BearerAuth
+//	@produce		json
+//	@param			categoryID	path		string			true	"Skill category ID"
+//	@response		200			{object}	app.Response	"OK"
+//	@response		400			{object}	app.Response	"Bad Request"
+//	@response		403			{object}	app.Response	"Not an admin"
+//	@response		404			{object}	app.Response	"Skill category not found"
+//	@response		409			{object}	app.Response	"Skill category is not empty"
+//	@response		450			{object}	app.Response	"Store Error"
+//	@router			/admin/skill-categories/{categoryID} [delete]
+func (h *taxonomyHandler) DeleteCategory(c app.Context) {
+	if !app.RequireAdmin(c, h.admins, notSkillAdminError) {
+		return
+	}
+
+	if err := h.storage.DeleteCategory(c.Ctx(), c.Param("categoryID")); err != nil {
+		storeError(c, err)
+		return
+	}
+
+	c.OK(nil)
+}
+
+// category binds the skill category of the request, checking the caller is
+// an admin.
+func (h *taxonomyHandler) category(c app.Context) (Category, bool) {
+	if !app.RequireAdmin(c, h.admins, notSkillAdminError) {
+		return Category{}, false
+	}
+
+	var input CategoryInput
+	if err := c.Bind(&input); err != nil {
+		c.BadRequest(invalidCategoryInputError)
+		return Category{}, false
+	}
+	var parentID *primitive.ObjectID
+	if input.ParentID != "" {
+		oid, err := primitive.ObjectIDFromHex(input.ParentID)
+		if err != nil {
+			c.BadRequest(invalidCategoryIdError)
+			return Category{}, false
+		}
+		parentID = &oid
+	}
+
+	now := time.Now()
+	return Category{
+		Name:      input.Name,
+		ParentID:  parentID,
+		Sort:      input.Sort,
+		UpdatedBy: c.GetString("email"),
+		UpdatedAt: &now,
+	}, true
+}
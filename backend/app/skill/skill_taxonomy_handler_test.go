@@ -0,0 +1,308 @@
+package skill
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type mockTaxonomyStorage struct {
+	categories []Category
+	hardSkills []HardSkill
+	category   *Category
+	id         string
+	err        error
+}
+
+func (m *mockTaxonomyStorage) GetCategories(ctx context.Context) ([]Category, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.categories, nil
+}
+
+func (m *mockTaxonomyStorage) GetAllHardSkills(ctx context.Context) ([]HardSkill, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.hardSkills, nil
+}
+
+func (m *mockTaxonomyStorage) InsertCategory(ctx context.Context, cat Category) (*Category, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	cat.ID = mockOID(1)
+	m.category = &cat
+	return &cat, nil
+}
+
+func (m *mockTaxonomyStorage) UpdateCategory(ctx context.Context, id string, cat Category) (*Category, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.id = id
+	cat.ID = mockOID(1)
+	m.category = &cat
+	return &cat, nil
+}
+
+func (m *mockTaxonomyStorage) DeleteCategory(ctx context.Context, id string) error {
+	m.id = id
+	return m.err
+}
+
+func taxonomyEngine(h *taxonomyHandler, email string) *gin.Engine {
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Set("email", email)
+	})
+	engine.GET("/skill-tree", app.NewGinHandler(h.SkillTree, zap.NewNop()))
+	engine.GET("/admin/skill-categories", app.NewGinHandler(h.GetCategories, zap.NewNop()))
+	engine.POST("/admin/skill-categories", app.NewGinHandler(h.InsertCategory, zap.NewNop()))
+	engine.PUT("/admin/skill-categories/:categoryID", app.NewGinHandler(h.UpdateCategory, zap.NewNop()))
+	engine.DELETE("/admin/skill-categories/:categoryID", app.NewGinHandler(h.DeleteCategory, zap.NewNop()))
+	return engine
+}
+
+func TestSkillTree(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cloud := mockOID(1)
+	archivedAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	categories := []Category{{ID: cloud, Name: "Cloud"}}
+	hardSkills := []HardSkill{
+		{Name: "Kubernetes", JobRole: []JobRole{"devops"}, CategoryID: &cloud},
+		{Name: "Go", JobRole: []JobRole{Backend}},
+		{Name: "Perl", JobRole: []JobRole{Backend}, ArchivedAt: &archivedAt},
+	}
+
+	testCases := []struct {
+		name                  string
+		query                 string
+		storageErr            error
+		expectedStatus        int
+		expectedCategorised   []string
+		expectedUncategorised []string
+	}{
+		{
+			name:                  "should return 200 and the tree without archived hard skills",
+			expectedStatus:        http.StatusOK,
+			expectedCategorised:   []string{"Kubernetes"},
+			expectedUncategorised: []string{"Go"},
+		},
+		{
+			name:                  "should return 200 and only the hard skills of the job role",
+			query:                 "?jobRole=backend",
+			expectedStatus:        http.StatusOK,
+			expectedCategorised:   []string{},
+			expectedUncategorised: []string{"Go"},
+		},
+		{
+			name:           "should return 450 when storage fails",
+			storageErr:     errors.New("connection lost"),
+			expectedStatus: 450,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockTaxonomyStorage{categories: categories, hardSkills: append([]HardSkill{}, hardSkills...), err: tc.storageErr}
+			engine := taxonomyEngine(NewTaxonomyHandler(st, []string{"admin@arise.tech"}), "ariser@arise.tech")
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/skill-tree"+tc.query, nil)
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedStatus != http.StatusOK {
+				return
+			}
+			var res struct {
+				Data SkillTree `json:"data"`
+			}
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+			assert.Len(t, res.Data.Categories, 1)
+			assert.Equal(t, tc.expectedCategorised, names(res.Data.Categories[0].HardSkills))
+			assert.Equal(t, tc.expectedUncategorised, names(res.Data.Uncategorised))
+		})
+	}
+}
+
+func names(hardSkills []HardSkill) []string {
+	got := []string{}
+	for _, hs := range hardSkills {
+		got = append(got, hs.Name)
+	}
+	return got
+}
+
+func TestInsertCategory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name             string
+		email            string
+		reqBody          string
+		storageErr       error
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:           "should return 200 and the sub-category",
+			email:          "admin@arise.tech",
+			reqBody:        `{"name":"Containers","parentId":"000000000000000000000002","sort":1}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:             "should return 403 when user is not an admin",
+			email:            "lead@arise.tech",
+			reqBody:          `{"name":"Containers"}`,
+			expectedStatus:   http.StatusForbidden,
+			expectedResponse: `{"status":"error","message":"only admins can manage the skill catalog"}`,
+		},
+		{
+			name:             "should return 400 when name is missing",
+			email:            "admin@arise.tech",
+			reqBody:          `{"sort":1}`,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid skill category input, name is required"}`,
+		},
+		{
+			name:             "should return 400 when parent id is invalid",
+			email:            "admin@arise.tech",
+			reqBody:          `{"name":"Containers","parentId":"dkls"}`,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid skill category id"}`,
+		},
+		{
+			name:             "should return 400 when parent is a sub-category",
+			email:            "admin@arise.tech",
+			reqBody:          `{"name":"Containers","parentId":"000000000000000000000002"}`,
+			storageErr:       categoryTooDeepError,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"sub-categories can only go under a top category and can't have sub-categories of their own"}`,
+		},
+		{
+			name:             "should return 404 when parent is not found",
+			email:            "admin@arise.tech",
+			reqBody:          `{"name":"Containers","parentId":"000000000000000000000002"}`,
+			storageErr:       categoryNotFoundError,
+			expectedStatus:   http.StatusNotFound,
+			expectedResponse: `{"status":"error","message":"skill category not found"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockTaxonomyStorage{err: tc.storageErr}
+			engine := taxonomyEngine(NewTaxonomyHandler(st, []string{"admin@arise.tech"}), tc.email)
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/admin/skill-categories", strings.NewReader(tc.reqBody))
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedResponse != "" {
+				assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+			}
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, "Containers", st.category.Name)
+				assert.Equal(t, mockOID(2), *st.category.ParentID)
+				assert.Equal(t, 1, st.category.Sort)
+				assert.Equal(t, "admin@arise.tech", st.category.UpdatedBy)
+			}
+		})
+	}
+}
+
+func TestUpdateCategory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("should return 200 and move the category to the top", func(t *testing.T) {
+		st := &mockTaxonomyStorage{}
+		engine := taxonomyEngine(NewTaxonomyHandler(st, []string{"admin@arise.tech"}), "admin@arise.tech")
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/admin/skill-categories/000000000000000000000001", strings.NewReader(`{"name":"Containers"}`))
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "000000000000000000000001", st.id)
+		assert.Nil(t, st.category.ParentID)
+	})
+
+	t.Run("should return 404 when the category is not found", func(t *testing.T) {
+		st := &mockTaxonomyStorage{err: categoryNotFoundError}
+		engine := taxonomyEngine(NewTaxonomyHandler(st, []string{"admin@arise.tech"}), "admin@arise.tech")
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/admin/skill-categories/000000000000000000000001", strings.NewReader(`{"name":"Containers"}`))
+
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestDeleteCategory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name             string
+		email            string
+		storageErr       error
+		expectedStatus   int
+		expectedResponse string
+	}{
+		{
+			name:           "should return 200 when the category is deleted",
+			email:          "admin@arise.tech",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:             "should return 403 when user is not an admin",
+			email:            "lead@arise.tech",
+			expectedStatus:   http.StatusForbidden,
+			expectedResponse: `{"status":"error","message":"only admins can manage the skill catalog"}`,
+		},
+		{
+			name:             "should return 409 when the category is not empty",
+			email:            "admin@arise.tech",
+			storageErr:       categoryNotEmptyError,
+			expectedStatus:   http.StatusConflict,
+			expectedResponse: `{"status":"error","message":"skill category still has sub-categories or hard skills"}`,
+		},
+		{
+			name:             "should return 400 when id is invalid",
+			email:            "admin@arise.tech",
+			storageErr:       invalidCategoryIdError,
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"status":"error","message":"invalid skill category id"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockTaxonomyStorage{err: tc.storageErr}
+			engine := taxonomyEngine(NewTaxonomyHandler(st, []string{"admin@arise.tech"}), tc.email)
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodDelete, "/admin/skill-categories/000000000000000000000001", nil)
+
+			engine.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedResponse != "" {
+				assert.JSONEq(t, tc.expectedResponse, rec.Body.String())
+			}
+		})
+	}
+}
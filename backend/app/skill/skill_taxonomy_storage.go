@@ -0,0 +1,155 @@
+package skill
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const categoryCollection = "skill_categories"
+
+var invalidCategoryIdError = SkillStorageError{message: "invalid skill category id"}
+var categoryNotFoundError = SkillStorageError{message: "skill category not found"}
+var categoryTooDeepError = SkillStorageError{message: "sub-categories can only go under a top category and can't have sub-categories of their own"}
+var categoryNotEmptyError = SkillStorageError{message: "skill category still has sub-categories or hard skills"}
+var invalidPrerequisiteError = SkillStorageError{message: "prerequisites must be levels of the hard skill's rubric and need a level of another hard skill's rubric"}
+var prerequisiteLoopError = SkillStorageError{message: "prerequisites cannot lead back to the hard skill itself"}
+
+// GetCategories lists every skill category in catalog order.
+func (s *storage) GetCategories(ctx context.Context) ([]Category, error) {
+	findOptions := options.Find().SetSort(bson.D{{Key: "sort", Value: 1}, {Key: "name", Value: 1}})
+	return findAll[Category](ctx, s.db.Collection(categoryCollection), findOptions)
+}
+
+func (s *storage) InsertCategory(ctx context.Context, cat Category) (*Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cat.ID = primitive.NewObjectID()
+	if err := s.checkParent(ctx, cat); err != nil {
+		return nil, err
+	}
+	if _, err := s.db.Collection(categoryCollection).InsertOne(ctx, cat); err != nil {
+		return nil, err
+	}
+	return &cat, nil
+}
+
+// UpdateCategory renames, moves or reorders a skill category. Its hard
+// skills and sub-categories move along with it.
+func (s *storage) UpdateCategory(ctx context.Context, id string, cat Category) (*Category, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, invalidCategoryIdError
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	cat.ID = oid
+	if err := s.checkParent(ctx, cat); err != nil {
+		return nil, err
+	}
+	if cat.ParentID != nil {
+		n, err := s.db.Collection(categoryCollection).CountDocuments(ctx, bson.M{"parentId": oid})
+		if err != nil {
+			return nil, err
+		}
+		if n > 0 {
+			return nil, categoryTooDeepError
+		}
+	}
+
+	res, err := updateOne[Category](ctx, s.db.Collection(categoryCollection), bson.M{"_id": oid}, bson.M{
+		"name":      cat.Name,
+		"parentId":  cat.ParentID,
+		"sort":      cat.Sort,
+		"updatedBy": cat.UpdatedBy,
+		"updatedAt": cat.UpdatedAt,
+	})
+	if err == skillNotFoundError {
+		return nil, categoryNotFoundError
+	}
+	return res, err
+}
+
+// DeleteCategory removes a skill category with no sub-categories nor hard
+// skills, archived ones included.
+func (s *storage) DeleteCategory(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return invalidCategoryIdError
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	subCategories, err := s.db.Collection(categoryCollection).CountDocuments(ctx, bson.M{"parentId": oid})
+	if err != nil {
+		return err
+	}
+	hardSkills, err := s.db.Collection(hardSkillCollection).CountDocuments(ctx, bson.M{"categoryId": oid})
+	if err != nil {
+		return err
+	}
+	if subCategories > 0 || hardSkills > 0 {
+		return categoryNotEmptyError
+	}
+
+	res, err := s.db.Collection(categoryCollection).DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return categoryNotFoundError
+	}
+	return nil
+}
+
+// checkParent makes sure the parent of a category is another category at the
+// top of the tree.
+func (s *storage) checkParent(ctx context.Context, cat Category) error {
+	if cat.ParentID == nil {
+		return nil
+	}
+	if *cat.ParentID == cat.ID {
+		return categoryTooDeepError
+	}
+
+	var parent Category
+	if err := s.db.Collection(categoryCollection).FindOne(ctx, bson.M{"_id": cat.ParentID}).Decode(&parent); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return categoryNotFoundError
+		}
+		return err
+	}
+	if parent.ParentID != nil {
+		return categoryTooDeepError
+	}
+	return nil
+}
+
+// checkTaxonomy makes sure the category of a hard skill exists and its
+// prerequisites fit the rest of the catalog.
+func (s *storage) checkTaxonomy(ctx context.Context, hs HardSkill) error {
+	if hs.CategoryID != nil {
+		n, err := s.db.Collection(categoryCollection).CountDocuments(ctx, bson.M{"_id": hs.CategoryID})
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return categoryNotFoundError
+		}
+	}
+	if len(hs.Prerequisites) == 0 {
+		return nil
+	}
+
+	catalog, err := s.GetAllHardSkills(ctx)
+	if err != nil {
+		return err
+	}
+	return CheckPrerequisites(hs, catalog)
+}
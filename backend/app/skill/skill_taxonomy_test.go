@@ -0,0 +1,111 @@
+package skill
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func mockOID(n byte) primitive.ObjectID {
+	return primitive.ObjectID{11: n}
+}
+
+func TestBuildTree(t *testing.T) {
+	infra, containers, languages, gone := mockOID(1), mockOID(2), mockOID(3), mockOID(9)
+	categories := []Category{
+		{ID: infra, Name: "Infrastructure"},
+		{ID: containers, Name: "Containers", ParentID: &infra},
+		{ID: languages, Name: "Languages"},
+		{ID: mockOID(4), Name: "Orphan", ParentID: &gone},
+	}
+	hardSkills := []HardSkill{
+		{Name: "Docker", CategoryID: &containers},
+		{Name: "Terraform", CategoryID: &infra},
+		{Name: "Kubernetes", CategoryID: &containers},
+		{Name: "Go", CategoryID: &languages},
+		{Name: "Agile"},
+		{Name: "Legacy", CategoryID: &gone},
+	}
+
+	tree := BuildTree(categories, hardSkills)
+
+	assert.Equal(t, SkillTree{
+		Categories: []CategoryNode{
+			{
+				Category: categories[0],
+				SubCategories: []CategoryNode{
+					{
+						Category:      categories[1],
+						SubCategories: []CategoryNode{},
+						HardSkills:    []HardSkill{hardSkills[0], hardSkills[2]},
+					},
+				},
+				HardSkills: []HardSkill{hardSkills[1]},
+			},
+			{Category: categories[2], SubCategories: []CategoryNode{}, HardSkills: []HardSkill{hardSkills[3]}},
+			{Category: categories[3], SubCategories: []CategoryNode{}, HardSkills: []HardSkill{}},
+		},
+		Uncategorised: []HardSkill{hardSkills[4], hardSkills[5]},
+	}, tree)
+}
+
+func TestCheckPrerequisites(t *testing.T) {
+	levels := []SkillLevel{{Level: 1}, {Level: 2}, {Level: 3}}
+	docker, kubernetes, helm := mockOID(1), mockOID(2), mockOID(3)
+	catalog := []HardSkill{
+		{ID: docker, Name: "Docker", SkillLevel: levels},
+		{ID: kubernetes, Name: "Kubernetes", SkillLevel: levels, Prerequisites: []Prerequisite{{Level: 3, SkillID: docker, RequiredLevel: 3}}},
+		{ID: helm, Name: "Helm", SkillLevel: levels[:2], Prerequisites: []Prerequisite{{Level: 2, SkillID: kubernetes, RequiredLevel: 2}}},
+	}
+
+	testCases := []struct {
+		name     string
+		hs       HardSkill
+		expected error
+	}{
+		{
+			name:     "should pass prerequisites on other hard skills",
+			hs:       HardSkill{ID: mockOID(4), Name: "Istio", SkillLevel: levels, Prerequisites: []Prerequisite{{Level: 2, SkillID: kubernetes, RequiredLevel: 3}}},
+			expected: nil,
+		},
+		{
+			name:     "should pass a hard skill without prerequisites",
+			hs:       catalog[0],
+			expected: nil,
+		},
+		{
+			name:     "should fail a prerequisite on an unknown hard skill",
+			hs:       HardSkill{ID: mockOID(4), SkillLevel: levels, Prerequisites: []Prerequisite{{Level: 1, SkillID: mockOID(9), RequiredLevel: 1}}},
+			expected: invalidPrerequisiteError,
+		},
+		{
+			name:     "should fail a prerequisite on the hard skill itself",
+			hs:       HardSkill{ID: docker, SkillLevel: levels, Prerequisites: []Prerequisite{{Level: 2, SkillID: docker, RequiredLevel: 1}}},
+			expected: invalidPrerequisiteError,
+		},
+		{
+			name:     "should fail a prerequisite for a level beyond the rubric",
+			hs:       HardSkill{ID: mockOID(4), SkillLevel: levels, Prerequisites: []Prerequisite{{Level: 4, SkillID: docker, RequiredLevel: 1}}},
+			expected: invalidPrerequisiteError,
+		},
+		{
+			name:     "should fail a prerequisite needing a level beyond the other rubric",
+			hs:       HardSkill{ID: mockOID(4), SkillLevel: levels, Prerequisites: []Prerequisite{{Level: 1, SkillID: helm, RequiredLevel: 3}}},
+			expected: invalidPrerequisiteError,
+		},
+		{
+			name:     "should fail prerequisites leading back to the hard skill",
+			hs:       HardSkill{ID: docker, Name: "Docker", SkillLevel: levels, Prerequisites: []Prerequisite{{Level: 3, SkillID: helm, RequiredLevel: 2}}},
+			expected: prerequisiteLoopError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckPrerequisites(tc.hs, catalog)
+
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
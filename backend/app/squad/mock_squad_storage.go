@@ -73,11 +73,14 @@ func (ms *mockSquadStorage) GetOneByID(id string) (*Squad, error) {
 	return ms.squad[0], nil
 }
 
-func (ms *mockSquadStorage) UpdateOneByID(id string, updatedSquad Squad) (*Squad, error) {
+func (ms *mockSquadStorage) UpdateOneByID(id string, version int, updatedSquad Squad) (*Squad, error) {
 	ms.methodsToCall["UpdateOneByID"] = true
 	if ms.err != nil {
 		return nil, ms.err
 	}
+	if ms.squad[0].Version != version {
+		return nil, squadVersionChangedError
+	}
 	return &Squad{
 		Id:            ms.squad[0].Id,
 		Name:          updatedSquad.Name,
@@ -85,6 +88,7 @@ func (ms *mockSquadStorage) UpdateOneByID(id string, updatedSquad Squad) (*Squad
 		Description:   updatedSquad.Description,
 		CreatedAt:     ms.squad[0].CreatedAt,
 		SkillsRatings: updatedSquad.SkillsRatings,
+		Version:       version + 1,
 	}, nil
 }
 
@@ -15,6 +15,9 @@ type Squad struct {
 	Description   string             `json:"desc" bson:"desc"`
 	CreatedAt     time.Time          `json:"createdAt" bson:"created_at"`
 	SkillsRatings []SkillRatings     `json:"skillsRatings" bson:"skills_ratings"`
+	// Version goes up on every update and is given out as the ETag of the
+	// squad.
+	Version int `json:"version" bson:"version"`
 }
 
 type SquadFilter struct {
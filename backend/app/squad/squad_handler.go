@@ -16,7 +16,7 @@ type squadStorageInterface interface {
 	GetAll() ([]*Squad, error)
 	GetByFilter(filter regexFilter) ([]*Squad, error)
 	GetOneByID(id string) (*Squad, error)
-	UpdateOneByID(id string, updatedSquad Squad) (*Squad, error)
+	UpdateOneByID(id string, version int, updatedSquad Squad) (*Squad, error)
 	DeleteByID(id string) error
 	GetAllBySquadId(context context.Context, squadId primitive.ObjectID) ([]user.User, error)
 }
@@ -51,19 +51,22 @@ var squadMemberNotFoundError = squadHandlerError{message: "squad member not foun
 // UpdateOneByID godoc
 //
 //	@summary		UpdateOneByID
-//	@description	Update a squad when lead edit squad data
+//	@description	Update a squad when lead edit squad data. If-Match must hold the ETag of the squad as last read.
 //	@tags			squad
 //	@id				UpdateOneByID
 //	@security		BearerAuth
 //	@accept			json
 //	@produce		json
-//	@param			id		path		string			true	"Squad ID"
-//	@param			squad	body		squad.Squad		true	"Squad"
-//	@response		200		{object}	squad.Squad		"OK"
-//	@response		400		{object}	app.Response	"Bad Request"
-//	@response		401		{object}	app.Response	"Unauthorized"
-//	@response		404		{object}	app.Response	"Not Found"
-//	@response		500		{object}	app.Response	"Internal Server Error"
+//	@param			id			path		string			true	"Squad ID"
+//	@param			If-Match	header		string			true	"ETag of the squad"
+//	@param			squad		body		squad.Squad		true	"Squad"
+//	@response		200			{object}	squad.Squad		"OK"
+//	@response		400			{object}	app.Response	"Bad Request"
+//	@response		401			{object}	app.Response	"Unauthorized"
+//	@response		404			{object}	app.Response	"Not Found"
+//	@response		412			{object}	app.Response	"Squad was changed since it was read"
+//	@response		428			{object}	app.Response	"If-Match is missing"
+//	@response		500			{object}	app.Response	"Internal Server Error"
 //	@router			/squads/{squadID} [put]
 func (h *squadHandler) UpdateOneByID(c app.Context) {
 	sqId := c.Param("squadID")
@@ -89,6 +92,15 @@ func (h *squadHandler) UpdateOneByID(c app.Context) {
 		return
 	}
 
+	version, ok := app.IfMatch(c)
+	if !ok {
+		return
+	}
+	if oldSquad.Version != version {
+		c.PreconditionFailed(squadVersionChangedError)
+		return
+	}
+
 	combinedSquad := *oldSquad
 	updatedSquadValue := reflect.ValueOf(updatedSquad)
 	updatedSquadType := updatedSquadValue.Type()
@@ -107,16 +119,19 @@ func (h *squadHandler) UpdateOneByID(c app.Context) {
 		}
 	}
 
-	res, err := h.storage.UpdateOneByID(sqId, combinedSquad)
+	res, err := h.storage.UpdateOneByID(sqId, version, combinedSquad)
 	if err != nil {
 		if err == invalidIdError {
 			c.BadRequest(err)
+		} else if err == squadVersionChangedError {
+			c.PreconditionFailed(err)
 		} else {
 			c.InternalServerError(err)
 		}
 		return
 	}
 
+	c.Header("ETag", app.ETag(res.Version))
 	c.OK(res)
 }
 
@@ -207,6 +222,7 @@ func (h *squadHandler) GetOneByID(c app.Context) {
 		return
 	}
 
+	c.Header("ETag", app.ETag(res.Version))
 	c.OK(res)
 }
 
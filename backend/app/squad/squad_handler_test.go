@@ -67,6 +67,7 @@ func TestSquadHandlerGetAll(t *testing.T) {
 					"teamleadMail": "john.d@arise.tech",
 					"desc": "Squad in Blockchain team krub",
 					"createdAt": "%s",
+					"version": 0,
 					"skillsRatings": []
 				}, {
 					"id": "%s",
@@ -74,6 +75,7 @@ func TestSquadHandlerGetAll(t *testing.T) {
 					"teamleadMail": "john.d@arise.tech",
 					"desc": "Squad in core team krub",
 					"createdAt": "%s",
+					"version": 0,
 					"skillsRatings": []
 				}
 			]
@@ -159,6 +161,7 @@ func TestSquadHandlerGetAll(t *testing.T) {
 					"teamleadMail": "john.d@arise.tech",
 					"desc": "Squad in Blockchain team krub",
 					"createdAt": "%s",
+					"version": 0,
 					"skillsRatings": []
 				}
 			]
@@ -230,6 +233,7 @@ func TestSquadHandlerGetOneByID(t *testing.T) {
 				"teamleadMail": "john.d@arise.tech",
 				"desc": "Squad in Blockchain team krub",
 				"createdAt": "0001-01-01T00:00:00Z",
+				"version": 0,
 				"skillsRatings": []
 			}
 		}`
@@ -424,6 +428,7 @@ func TestSquadHandlerInsertOne(t *testing.T) {
 				"teamleadMail": "john.d@arise.tech",
 				"desc": "Squad in Blockchain team krub",
 				"createdAt": "0001-01-01T00:00:00Z",
+				"version": 0,
 				"skillsRatings": [
 					{
 						"skid": "%s",
@@ -1113,6 +1118,7 @@ func TestSquadHandlerUpdateOneByID(t *testing.T) {
 			"desc": "This is modified."
 		}`
 		req, _ := http.NewRequest(http.MethodPut, "/squads/"+squadId.hexId, strings.NewReader(body))
+		req.Header.Set("If-Match", `"0"`)
 		engine.ServeHTTP(rec, req)
 
 		resp := rec.Body.String()
@@ -1125,12 +1131,62 @@ func TestSquadHandlerUpdateOneByID(t *testing.T) {
 				"desc": "This is modified.",
 				"teamleadMail":"john.d@arise.tech",
 				"createdAt": "0001-01-01T00:00:00Z",
+				"version": 1,
 				"skillsRatings": []
 			}
 		}`, squadId.hexId)
 
 		assert.Equal(t, 200, rec.Code)
 		assert.JSONEq(t, want, resp)
+		assert.Equal(t, `"1"`, rec.Header().Get("ETag"))
+		mockStorage.Verify(t)
+	})
+
+	t.Run("should return 412 if the squad was changed since it was read", func(t *testing.T) {
+		squadId := mockObjectId(20)
+		mockStorage := &mockSquadStorage{
+			squad: []*Squad{
+				{
+					Id:      squadId.objectId,
+					Name:    "Aster",
+					Version: 3,
+				},
+			},
+		}
+		mockStorage.ExpectToCall("GetOneByID")
+		handler := NewSquadHandler(mockStorage)
+
+		engine := gin.New()
+		engine.PUT("/squads/:squadID", app.NewGinHandler(handler.UpdateOneByID, zap.NewNop()))
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/squads/"+squadId.hexId, strings.NewReader(`{"desc": "This is modified."}`))
+		req.Header.Set("If-Match", `"2"`)
+		engine.ServeHTTP(rec, req)
+
+		want := fmt.Sprintf(`{
+			"status": "error",
+			"message": "%s"
+		}`, squadVersionChangedError.Error())
+		assert.Equal(t, 412, rec.Code)
+		assert.JSONEq(t, want, rec.Body.String())
+		mockStorage.Verify(t)
+	})
+
+	t.Run("should return 428 if If-Match is missing", func(t *testing.T) {
+		squadId := mockObjectId(20)
+		mockStorage := &mockSquadStorage{
+			squad: []*Squad{{Id: squadId.objectId, Name: "Aster"}},
+		}
+		mockStorage.ExpectToCall("GetOneByID")
+		handler := NewSquadHandler(mockStorage)
+
+		engine := gin.New()
+		engine.PUT("/squads/:squadID", app.NewGinHandler(handler.UpdateOneByID, zap.NewNop()))
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/squads/"+squadId.hexId, strings.NewReader(`{"desc": "This is modified."}`))
+		engine.ServeHTTP(rec, req)
+
+		assert.Equal(t, 428, rec.Code)
 		mockStorage.Verify(t)
 	})
 
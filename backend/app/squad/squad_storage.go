@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"gitdev.devops.krungthai.com/aster/ariskill/app/user"
+	"gitdev.devops.krungthai.com/aster/ariskill/database"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -33,6 +34,7 @@ func (e SquadStorageError) Error() string {
 
 var invalidIdError = SquadStorageError{message: "invalid squad id"}
 var squadNotFoundError = SquadStorageError{message: "squad not found"}
+var squadVersionChangedError = SquadStorageError{message: "squad was changed by someone else, reload it and try again"}
 
 func convertIdToObjectId(id string) (*primitive.ObjectID, error) {
 	objectId, err := primitive.ObjectIDFromHex(id)
@@ -56,6 +58,7 @@ func (s *storage) InsertOne(profileId string, sq Squad) (*Squad, error) {
 		}
 	}
 	sq.CreatedAt = time.Now()
+	sq.Version = 0
 
 	res, err := s.db.Collection(squadCollection).InsertOne(context.TODO(), sq, options.InsertOne())
 	if err != nil {
@@ -116,15 +119,19 @@ func (s *storage) GetOneByID(id string) (*Squad, error) {
 	return squad, nil
 }
 
-func (s *storage) UpdateOneByID(id string, updatedSquad Squad) (*Squad, error) {
+// UpdateOneByID replaces a squad that is still at the given version and moves
+// it to the next one.
+func (s *storage) UpdateOneByID(id string, version int, updatedSquad Squad) (*Squad, error) {
 	objectId, err := convertIdToObjectId(id)
 	if err != nil {
 		return nil, invalidIdError
 	}
 
+	updatedSquad.Version = version + 1
 	update := bson.M{"$set": updatedSquad}
+	filter := bson.M{"_id": *objectId, "version": database.VersionFilter(version)}
 
-	result, err := s.db.Collection(squadCollection).UpdateOne(context.TODO(), bson.M{"_id": *objectId}, update, options.Update())
+	result, err := s.db.Collection(squadCollection).UpdateOne(context.TODO(), filter, update, options.Update())
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, squadNotFoundError
@@ -133,7 +140,7 @@ func (s *storage) UpdateOneByID(id string, updatedSquad Squad) (*Squad, error) {
 	}
 
 	if result.MatchedCount == 0 {
-		return nil, squadNotFoundError
+		return nil, squadVersionChangedError
 	}
 
 	updatedSquad.Id = *objectId
@@ -6,14 +6,18 @@ import (
 	"os"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/caarlos0/env/v10"
 )
 
 type Config struct {
-	Server     server
-	Database   Database
-	GoogleOidc GoogleOidc
+	Server         server
+	Database       Database
+	GoogleOidc     GoogleOidc
+	CycleMonitor   CycleMonitor
+	CycleRetention CycleRetention
+	Admin          Admin
 }
 
 type server struct { // TODO: private type
@@ -36,6 +40,24 @@ type GoogleOidc struct { // TODO: private type
 	IsDevMode    bool   `env:"GOOGLE_OIDC_IS_DEV_MODE"`
 }
 
+// CycleMonitor configures the background scan for overdue cycles.
+type CycleMonitor struct {
+	Interval         time.Duration `env:"CYCLE_MONITOR_INTERVAL" envDefault:"1h"`
+	PendingReviewFor time.Duration `env:"CYCLE_PENDING_REVIEW_FOR" envDefault:"168h"`
+}
+
+// CycleRetention configures the background purge of deleted cycles.
+type CycleRetention struct {
+	Interval       time.Duration `env:"CYCLE_RETENTION_INTERVAL" envDefault:"24h"`
+	KeepDeletedFor time.Duration `env:"CYCLE_KEEP_DELETED_FOR" envDefault:"2160h"`
+}
+
+// Admin lists who may run company-wide operations such as handing cycles
+// over to another team lead.
+type Admin struct {
+	Emails []string `env:"ADMIN_EMAILS" envSeparator:","`
+}
+
 func Env(key string) string {
 	value := os.Getenv(key)
 	if value == "" {
@@ -78,6 +100,21 @@ func C(envPrefix ...string) Config {
 			log.Fatal(err)
 		}
 
+		monitor := &CycleMonitor{}
+		if err := env.ParseWithOptions(monitor, opts); err != nil {
+			log.Fatal(err)
+		}
+
+		retention := &CycleRetention{}
+		if err := env.ParseWithOptions(retention, opts); err != nil {
+			log.Fatal(err)
+		}
+
+		admin := &Admin{}
+		if err := env.ParseWithOptions(admin, opts); err != nil {
+			log.Fatal(err)
+		}
+
 		srvConf := &server{}
 		if err := env.ParseWithOptions(srvConf, opts); err != nil {
 			log.Fatal(err)
@@ -107,6 +144,9 @@ func C(envPrefix ...string) Config {
 				RedirectUri:  googleOidc.RedirectUri,
 				IsDevMode:    googleOidc.IsDevMode,
 			},
+			CycleMonitor:   *monitor,
+			CycleRetention: *retention,
+			Admin:          *admin,
 		}
 	})
 
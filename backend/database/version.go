@@ -0,0 +1,13 @@
+package database
+
+import "go.mongodb.org/mongo-driver/bson"
+
+// VersionFilter matches the version field of a document at the given version.
+// Documents written before they were versioned have no version field and
+// count as version 0.
+func VersionFilter(version int) any {
+	if version == 0 {
+		return bson.M{"$in": bson.A{0, nil}}
+	}
+	return version
+}
@@ -12,6 +12,7 @@ import (
 
 	"gitdev.devops.krungthai.com/aster/ariskill/app/cycle"
 	"gitdev.devops.krungthai.com/aster/ariskill/app/membersquad"
+	"gitdev.devops.krungthai.com/aster/ariskill/app/notification"
 	"gitdev.devops.krungthai.com/aster/ariskill/app/profile"
 	"gitdev.devops.krungthai.com/aster/ariskill/app/skill"
 	"gitdev.devops.krungthai.com/aster/ariskill/app/squad"
@@ -45,6 +46,21 @@ func main() {
 	db, cleanupDBFunc := database.NewMongo(cfg.Database)
 	r := NewRouter(mlog, cfg, db)
 
+	if err := cycle.NewCycleStorage(db).EnsureIndexes(context.Background()); err != nil {
+		mlog.Error("cannot create cycle indexes: " + err.Error())
+	}
+	if err := skill.NewStorage(db).EnsureIndexes(context.Background()); err != nil {
+		mlog.Error("cannot create skill indexes: " + err.Error())
+	}
+
+	monitorCtx, stopMonitor := context.WithCancel(context.Background())
+	monitor := cycle.NewMonitor(cycle.NewCycleStorage(db), app.RealClock{}, cfg.CycleMonitor.Interval, cfg.CycleMonitor.PendingReviewFor, mlog)
+	go monitor.Run(monitorCtx)
+
+	retentionCtx, stopRetention := context.WithCancel(context.Background())
+	retention := cycle.NewRetention(cycle.NewCycleStorage(db), app.RealClock{}, cfg.CycleRetention.Interval, cfg.CycleRetention.KeepDeletedFor, mlog)
+	go retention.Run(retentionCtx)
+
 	srv := http.Server{
 		Addr:              ":" + cfg.Server.Port,
 		Handler:           r,
@@ -59,6 +75,8 @@ func main() {
 		signal.Notify(sigint, syscall.SIGINT, syscall.SIGTERM)
 		<-sigint
 
+		stopMonitor()
+		stopRetention()
 		cleanupDBFunc()
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
@@ -125,8 +143,36 @@ func NewRouter(mlog *zap.Logger, cfg config.Config, db *mongo.Database) *app.Rou
 	skillHandler := skill.NewSkillHandler(skillStorage)
 	r.GET("/skills/kind/:kindtype", skillHandler.GetSkillsByKind)
 	r.GET("/skills/:id", skillHandler.SkillByID)
+	r.GET("/skills/search", skillHandler.SearchSkills)
 	r.GET("/hard-skills", skillHandler.SkillByJobRole)
 
+	skillAdminHandler := skill.NewSkillAdminHandler(skillStorage, cfg.Admin.Emails)
+	r.GET("/admin/skills", skillAdminHandler.GetAllSkills)
+	r.POST("/admin/skills", skillAdminHandler.InsertSkill)
+	r.PUT("/admin/skills/:skillID", skillAdminHandler.UpdateSkill)
+	r.POST("/admin/skills/:skillID/archive", skillAdminHandler.ArchiveSkill)
+	r.PUT("/admin/skills/:skillID/aliases", skillAdminHandler.UpdateSkillAliases)
+	r.GET("/admin/hard-skills", skillAdminHandler.GetAllHardSkills)
+	r.POST("/admin/hard-skills", skillAdminHandler.InsertHardSkill)
+	r.PUT("/admin/hard-skills/:skillID", skillAdminHandler.UpdateHardSkill)
+	r.POST("/admin/hard-skills/:skillID/archive", skillAdminHandler.ArchiveHardSkill)
+	r.PUT("/admin/hard-skills/:skillID/aliases", skillAdminHandler.UpdateHardSkillAliases)
+
+	rubricHandler := skill.NewRubricHandler(skillStorage)
+	r.GET("/hard-skills/:skillID/rubrics", rubricHandler.RubricVersions)
+	r.GET("/hard-skills/:skillID/rubrics/diff", rubricHandler.RubricDiff)
+
+	taxonomyHandler := skill.NewTaxonomyHandler(skillStorage, cfg.Admin.Emails)
+	r.GET("/skill-tree", taxonomyHandler.SkillTree)
+	r.GET("/admin/skill-categories", taxonomyHandler.GetCategories)
+	r.POST("/admin/skill-categories", taxonomyHandler.InsertCategory)
+	r.PUT("/admin/skill-categories/:categoryID", taxonomyHandler.UpdateCategory)
+	r.DELETE("/admin/skill-categories/:categoryID", taxonomyHandler.DeleteCategory)
+
+	catalogHandler := skill.NewCatalogHandler(skillStorage, cfg.Admin.Emails)
+	r.GET("/admin/catalog/export", catalogHandler.ExportCatalog)
+	r.POST("/admin/catalog/import", catalogHandler.ImportCatalog)
+
 	// packages squad
 	squadStorage := squad.NewSquadStorage(db)
 	squadHandler := squad.NewSquadHandler(squadStorage)
@@ -141,17 +187,80 @@ func NewRouter(mlog *zap.Logger, cfg config.Config, db *mongo.Database) *app.Rou
 	// packages cycle
 	cycleStorage := cycle.NewCycleStorage(db)
 	cycleHandler := cycle.NewCycleHandler(cycleStorage)
-	r.POST("/cycles", cycleHandler.InsertOne)
 	r.GET("/cycles/email/user", cycleHandler.GetAllFromUserEmail)
-	r.GET("/cycles/email/:status/:page", cycleHandler.GetAllFromReceiverEmail)
+	r.GET("/cycles/email/:status", cycleHandler.GetAllFromReceiverEmail)
 	r.POST("/cycles/:id", cycleHandler.UpdateByID)
 	r.POST("/cycles/save/:id", cycleHandler.UpdateByIDSave)
 	r.GET("/cycles/:id", cycleHandler.GetOneByID)
-	r.DELETE("/cycles/:id", cycleHandler.DeleteByID)
 	r.GET("/cycles/progress/:id", cycleHandler.GetCycleProgess)
 	r.POST("/cycles/update/:id", cycleHandler.UpdateUserFinalScore)
 	r.PUT("/cycles/goal", cycleHandler.UpdateHardSkillsByEmail)
+	r.PUT("/cycles/:id/status", cycleHandler.UpdateStatusByID)
+	r.GET("/cycles/:id/history", cycleHandler.HistoryByID)
 	r.GET("/cycles/email/lastest", cycleHandler.GetLatestCycleFromUserEmail)
+
+	searchHandler := cycle.NewSearchHandler(cycleStorage, cfg.Admin.Emails)
+	r.GET("/cycles/search", searchHandler.Search)
+
+	scoreHandler := cycle.NewScoreHandler(cycleStorage)
+	r.PUT("/cycles/:id/lead-scores", scoreHandler.LeadScores)
+	r.PUT("/cycles/:id/agreement", scoreHandler.Agreement)
+
+	commentHandler := cycle.NewCommentHandler(cycleStorage)
+	r.GET("/cycles/:id/comments", commentHandler.Comments)
+	r.POST("/cycles/:id/comments", commentHandler.PostComment)
+	r.PUT("/cycles/:id/comments/:commentID", commentHandler.EditComment)
+	r.PUT("/cycles/:id/comments/:commentID/resolve", commentHandler.ResolveComment)
+
+	reportHandler := cycle.NewReportHandler(cycleStorage, app.RealClock{})
+	r.GET("/cycles/:id/report", reportHandler.Report)
+
+	draftHandler := cycle.NewDraftHandler(cycleStorage, skillStorage)
+	r.GET("/cycles/draft", draftHandler.Draft)
+	r.POST("/cycles", draftHandler.InsertNew)
+
+	rolloverHandler := cycle.NewRolloverHandler(cycleStorage, skillStorage)
+	r.POST("/cycles/:id/rollover", rolloverHandler.Rollover)
+
+	deletionHandler := cycle.NewDeletionHandler(cycleStorage, cfg.Admin.Emails)
+	r.DELETE("/cycles/:id", deletionHandler.DeleteByID)
+	r.POST("/cycles/:id/restore", deletionHandler.Restore)
+	r.GET("/admin/cycles/deleted", deletionHandler.GetDeleted)
+
+	bulkHandler := cycle.NewBulkHandler(cycleStorage, skillStorage, squadStorage)
+	r.POST("/squads/:squadID/cycles", bulkHandler.InsertForSquad)
+
+	periodHandler := cycle.NewPeriodHandler(cycleStorage, squadStorage, cfg.Admin.Emails)
+	r.POST("/review-periods", periodHandler.InsertPeriod)
+	r.GET("/review-periods", periodHandler.GetPeriods)
+	r.GET("/review-periods/open", periodHandler.GetOpenPeriod)
+	r.GET("/review-periods/:periodID", periodHandler.GetPeriodByID)
+	r.GET("/review-periods/:periodID/cycles", periodHandler.GetPeriodCycles)
+	r.GET("/review-periods/:periodID/not-started", periodHandler.GetNotStarted)
+
+	// packages notification
+	notificationStorage := notification.NewStorage(db)
+	notificationHandler := notification.NewNotificationHandler(notificationStorage)
+	r.GET("/notifications", notificationHandler.GetMine)
+	r.PUT("/notifications/:id/read", notificationHandler.MarkRead)
+
+	handoverHandler := cycle.NewHandoverHandler(cycleStorage, notificationStorage, cfg.Admin.Emails)
+	r.POST("/admin/cycles/handover", handoverHandler.Handover)
+
+	peerHandler := cycle.NewPeerHandler(cycleStorage, notificationStorage)
+	r.POST("/cycles/:id/peers", peerHandler.InvitePeer)
+	r.GET("/cycles/:id/peers", peerHandler.Peers)
+	r.PUT("/cycles/:id/peer-feedback", peerHandler.SubmitPeerFeedback)
+	r.GET("/peer-feedback", peerHandler.PeerRequests)
+
+	prerequisiteHandler := cycle.NewPrerequisiteHandler(cycleStorage, skillStorage)
+	r.GET("/cycles/:id/prerequisites", prerequisiteHandler.CheckPrerequisites)
+
+	policyHandler := cycle.NewGoalPolicyHandler(cycleStorage, cfg.Admin.Emails)
+	r.GET("/admin/goal-policies", policyHandler.GetGoalPolicies)
+	r.PUT("/admin/goal-policies", policyHandler.SaveGoalPolicy)
+	r.DELETE("/admin/goal-policies/:policyID", policyHandler.DeleteGoalPolicy)
+
 	r.GET("/swagger/*any", app.NewSwaggerHandler())
 	return r
 }
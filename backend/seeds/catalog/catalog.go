@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app/skill"
+	"gitdev.devops.krungthai.com/aster/ariskill/config"
+	"gitdev.devops.krungthai.com/aster/ariskill/database"
+)
+
+// catalog exports the skill catalog to a CSV or YAML file, or imports one
+// back, adding and updating skills by name. The format follows the extension
+// of the file unless -format says otherwise.
+//
+//	go run seeds/catalog/catalog.go -export -file skill-catalog.csv
+//	go run seeds/catalog/catalog.go -import -file skill-catalog.csv [-dry-run]
+func main() {
+	export := flag.Bool("export", false, "write the catalog to the file")
+	importing := flag.Bool("import", false, "add and update skills from the file")
+	file := flag.String("file", "skill-catalog.csv", "catalog file")
+	format := flag.String("format", "", "csv or yaml, by default from the file extension")
+	dryRun := flag.Bool("dry-run", false, "report what an import would change without writing")
+	flag.Parse()
+
+	if *export == *importing {
+		log.Fatal("use either -export or -import")
+	}
+	if *format == "" {
+		*format = skill.CatalogFormatCSV
+		if ext := strings.ToLower(filepath.Ext(*file)); ext == ".yaml" || ext == ".yml" {
+			*format = skill.CatalogFormatYAML
+		}
+	}
+
+	cfg := config.C(os.Getenv("ENV"))
+	db, teardown := database.NewMongo(cfg.Database)
+	defer teardown()
+
+	st := skill.NewStorage(db)
+	ctx := context.Background()
+
+	if *export {
+		catalog, err := st.ExportCatalog(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		f, err := os.Create(*file)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		if err := skill.WriteCatalog(f, catalog, *format); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("%d skills and %d hard skills exported to %s", len(catalog.Skills), len(catalog.HardSkills), *file)
+		return
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	imported, err := skill.ReadCatalog(f, *format)
+	if err == nil {
+		var diff *skill.CatalogDiff
+		diff, err = st.ImportCatalog(ctx, imported, skill.CatalogImportActor, *dryRun)
+		if err == nil {
+			report(diff)
+			return
+		}
+	}
+	if problems, ok := err.(skill.CatalogError); ok {
+		for _, p := range problems.Problems {
+			log.Printf("%s: %s", p.At, p.Message)
+		}
+	}
+	log.Fatal(err)
+}
+
+func report(diff *skill.CatalogDiff) {
+	for _, change := range diff.Changes {
+		if change.Change == skill.CatalogUnchanged {
+			continue
+		}
+		if len(change.Fields) > 0 {
+			log.Printf("%s %s %q: %s", change.Change, change.Kind, change.Name, strings.Join(change.Fields, ", "))
+			continue
+		}
+		log.Printf("%s %s %q", change.Change, change.Kind, change.Name)
+	}
+
+	verb := "were"
+	if diff.DryRun {
+		verb = "would be"
+	}
+	log.Printf("%d skills %s added, %d updated, %d unchanged", diff.Added, verb, diff.Updated, diff.Unchanged)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"gitdev.devops.krungthai.com/aster/ariskill/app/cycle"
+	"gitdev.devops.krungthai.com/aster/ariskill/config"
+	"gitdev.devops.krungthai.com/aster/ariskill/database"
+)
+
+// migrate_cycles copies the legacy cycles collection into new_cycles. With
+// -comments it instead moves the comment strings left on new_cycles into
+// cycle_comments.
+//
+//	go run seeds/migrate/migrate_cycles.go [-dry-run] [-rollback | -comments]
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report what would change without writing")
+	rollback := flag.Bool("rollback", false, "remove migrated cycles and restore the legacy ones")
+	comments := flag.Bool("comments", false, "move comments stored on new_cycles into cycle_comments")
+	flag.Parse()
+
+	cfg := config.C(os.Getenv("ENV"))
+	db, teardown := database.NewMongo(cfg.Database)
+	defer teardown()
+
+	st := cycle.NewCycleStorage(db)
+	progress := func(p cycle.MigrationProgress) {
+		if p.Skipped {
+			log.Printf("[%d/%d] %s skipped, already in new_cycles", p.Done, p.Total, p.ID.Hex())
+			return
+		}
+		log.Printf("[%d/%d] %s", p.Done, p.Total, p.ID.Hex())
+	}
+
+	run, verb := st.MigrateLegacyCycles, "migrated"
+	if *rollback {
+		run, verb = st.RollbackLegacyMigration, "rolled back"
+	}
+	if *comments {
+		run, verb = st.MoveInlineComments, "moved to comment threads"
+	}
+
+	report, err := run(context.Background(), *dryRun, progress)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *dryRun {
+		verb = "would be " + verb
+	}
+	log.Printf("%d of %d cycles %s, %d skipped", report.Applied, report.Total, verb, report.Skipped)
+}